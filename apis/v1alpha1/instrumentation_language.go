@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// InstrumentationLanguage represents the language or runtime of an auto-instrumentation.
+	// +kubebuilder:validation:Enum=java;nodejs;python;dotnet;go;apache-httpd;nginx;ruby;php;sdk
+	InstrumentationLanguage string
+)
+
+const (
+	// LanguageJava represents the Java auto-instrumentation.
+	LanguageJava InstrumentationLanguage = "java"
+	// LanguageNodeJS represents the NodeJS auto-instrumentation.
+	LanguageNodeJS InstrumentationLanguage = "nodejs"
+	// LanguagePython represents the Python auto-instrumentation.
+	LanguagePython InstrumentationLanguage = "python"
+	// LanguageDotNet represents the .NET auto-instrumentation.
+	LanguageDotNet InstrumentationLanguage = "dotnet"
+	// LanguageGo represents the Go auto-instrumentation.
+	LanguageGo InstrumentationLanguage = "go"
+	// LanguageApacheHttpd represents the Apache HTTPD auto-instrumentation.
+	LanguageApacheHttpd InstrumentationLanguage = "apache-httpd"
+	// LanguageNginx represents the Nginx auto-instrumentation.
+	LanguageNginx InstrumentationLanguage = "nginx"
+	// LanguageRuby represents the Ruby auto-instrumentation.
+	LanguageRuby InstrumentationLanguage = "ruby"
+	// LanguagePHP represents the PHP auto-instrumentation.
+	LanguagePHP InstrumentationLanguage = "php"
+	// LanguageSdk represents the injection of the SDK configuration only.
+	LanguageSdk InstrumentationLanguage = "sdk"
+)
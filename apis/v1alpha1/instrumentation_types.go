@@ -15,6 +15,8 @@
 package v1alpha1
 
 import (
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,6 +42,11 @@ type InstrumentationSpec struct {
 	// +optional
 	Sampler `json:"sampler,omitempty"`
 
+	// ConfigFile defines whether the exporter, propagators and sampler are also provided to the SDKs as a declarative
+	// configuration file, which SDKs supporting its reload pick up without restarting the workloads.
+	// +optional
+	ConfigFile ConfigFile `json:"configFile,omitempty"`
+
 	// Defaults defines default values for the instrumentation.
 	Defaults Defaults `json:"defaults,omitempty"`
 
@@ -79,6 +86,77 @@ type InstrumentationSpec struct {
 	// Nginx defines configuration for Nginx auto-instrumentation.
 	// +optional
 	Nginx Nginx `json:"nginx,omitempty"`
+
+	// Ruby defines configuration for Ruby auto-instrumentation.
+	// +optional
+	Ruby Ruby `json:"ruby,omitempty"`
+
+	// PHP defines configuration for PHP auto-instrumentation.
+	// +optional
+	PHP PHP `json:"php,omitempty"`
+
+	// RolloutPolicy defines whether the operator restarts the workloads running an outdated agent image, e.g. after
+	// the agent images were upgraded. It requires the Instrumentation status to be reported.
+	// +optional
+	RolloutPolicy *RolloutPolicy `json:"rolloutPolicy,omitempty"`
+
+	// Selector defines the pods instrumented by this Instrumentation without the
+	// instrumentation.opentelemetry.io/inject-* annotations. The annotations of a pod or its namespace take precedence.
+	// +optional
+	Selector *InstrumentationSelector `json:"selector,omitempty"`
+}
+
+type (
+	// RolloutMode defines how workloads running an outdated agent image are rolled out.
+	// +kubebuilder:validation:Enum=manual;automatic
+	RolloutMode string
+)
+
+const (
+	// RolloutModeManual leaves the restart of workloads to the user.
+	RolloutModeManual RolloutMode = "manual"
+	// RolloutModeAutomatic restarts the workloads running an outdated agent image.
+	RolloutModeAutomatic RolloutMode = "automatic"
+)
+
+// RolloutPolicy defines how the Deployments, StatefulSets and DaemonSets running an outdated agent image are restarted.
+type RolloutPolicy struct {
+	// Mode defines whether the workloads are restarted by the operator.
+	// +optional
+	// +kubebuilder:default:=manual
+	Mode RolloutMode `json:"mode,omitempty"`
+
+	// MaxConcurrent is the maximum number of workloads being rolled out at the same time.
+	// +optional
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrent int32 `json:"maxConcurrent,omitempty"`
+
+	// Interval is the minimum duration between two batches of restarts. A batch restarts as many workloads as
+	// MaxConcurrent allows.
+	// +optional
+	// +kubebuilder:default:="5m"
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+// InstrumentationSelector defines which pods are instrumented without annotations, and with which languages.
+// When several Instrumentations select a pod for the same language, the one in the namespace of the pod wins,
+// otherwise the first one by namespace and name.
+type InstrumentationSelector struct {
+	// PodSelector selects the pods to instrument by their labels. An empty selector selects all pods.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// NamespaceSelector selects the namespaces of the pods to instrument by their labels.
+	// When not set, only pods in the namespace of the Instrumentation are selected. Other namespaces must also opt in
+	// with the instrumentation.opentelemetry.io/allowed-instrumentations annotation, listing the Instrumentation as
+	// <namespace>/<name> or <namespace>/*.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Languages defines the auto-instrumentations injected into the selected pods.
+	// +kubebuilder:validation:MinItems=1
+	Languages []InstrumentationLanguage `json:"languages"`
 }
 
 // Resource defines the configuration for the resource attributes, as defined by the OpenTelemetry specification.
@@ -104,6 +182,100 @@ type Exporter struct {
 	// TLS defines certificates for TLS.
 	// TLS needs to be enabled by specifying https:// scheme in the Endpoint.
 	TLS *TLS `json:"tls,omitempty"`
+
+	// Protocol defines the transport protocol of the exporter.
+	// The value will be set in the OTEL_EXPORTER_OTLP_PROTOCOL env var.
+	// The Python, Ruby and PHP auto-instrumentations only support http/protobuf.
+	// +optional
+	// +kubebuilder:validation:Enum=grpc;http/protobuf;http/json
+	Protocol ExporterProtocol `json:"protocol,omitempty"`
+
+	// Headers defines the headers sent along with the exported telemetry, e.g. for authentication.
+	// The values will be set in the OTEL_EXPORTER_OTLP_HEADERS env var.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Headers []ExporterHeader `json:"headers,omitempty"`
+
+	// Compression defines the compression of the exported telemetry, gzip or none.
+	// The value will be set in the OTEL_EXPORTER_OTLP_COMPRESSION env var.
+	// +optional
+	// +kubebuilder:validation:Enum=gzip;none
+	Compression ExporterCompression `json:"compression,omitempty"`
+
+	// Timeout defines the maximum time the exporter waits for each batch export.
+	// The value will be set in milliseconds in the OTEL_EXPORTER_OTLP_TIMEOUT env var.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// TracesEndpoint is the address of the collector receiving traces, when it differs from the Endpoint.
+	// The value will be set in the OTEL_EXPORTER_OTLP_TRACES_ENDPOINT env var.
+	// +optional
+	TracesEndpoint string `json:"tracesEndpoint,omitempty"`
+
+	// MetricsEndpoint is the address of the collector receiving metrics, when it differs from the Endpoint.
+	// The value will be set in the OTEL_EXPORTER_OTLP_METRICS_ENDPOINT env var.
+	// +optional
+	MetricsEndpoint string `json:"metricsEndpoint,omitempty"`
+
+	// LogsEndpoint is the address of the collector receiving logs, when it differs from the Endpoint.
+	// The value will be set in the OTEL_EXPORTER_OTLP_LOGS_ENDPOINT env var.
+	// +optional
+	LogsEndpoint string `json:"logsEndpoint,omitempty"`
+}
+
+// ExporterProtocol represents the transport protocol of the OTLP exporter.
+type ExporterProtocol string
+
+const (
+	// ExporterProtocolGRPC exports over gRPC.
+	ExporterProtocolGRPC ExporterProtocol = "grpc"
+	// ExporterProtocolHTTPProtobuf exports protobuf payloads over HTTP.
+	ExporterProtocolHTTPProtobuf ExporterProtocol = "http/protobuf"
+	// ExporterProtocolHTTPJSON exports JSON payloads over HTTP.
+	ExporterProtocolHTTPJSON ExporterProtocol = "http/json"
+)
+
+// ExporterCompression represents the compression of the OTLP exporter.
+type ExporterCompression string
+
+const (
+	// ExporterCompressionGzip compresses the exported telemetry with gzip.
+	ExporterCompressionGzip ExporterCompression = "gzip"
+	// ExporterCompressionNone doesn't compress the exported telemetry.
+	ExporterCompressionNone ExporterCompression = "none"
+)
+
+// ExporterHeader defines a header sent by the OTLP exporter.
+type ExporterHeader struct {
+	// Name of the header.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Value of the header.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// SecretKeyRef selects the value of the header in a secret in the namespace of the Instrumentation, e.g. for
+	// authentication tokens. The value must be percent-encoded. It can't be set along with Value, nor with a
+	// namespace selector: the header is skipped for the pods of other namespaces.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// EnvName returns the name of the env var holding the value of the header when it's sourced from a secret.
+// Header names differing only in case or in non-alphanumeric characters share the same env var.
+func (h ExporterHeader) EnvName() string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, h.Name)
+	return "OTEL_EXPORTER_OTLP_HEADER_" + name
 }
 
 // TLS defines TLS configuration for exporter.
@@ -146,6 +318,17 @@ type Sampler struct {
 	Argument string `json:"argument,omitempty"`
 }
 
+// ConfigFile defines the declarative configuration file of the SDKs.
+type ConfigFile struct {
+	// Enabled defines whether the operator maintains a ConfigMap with the declarative configuration file of the SDKs
+	// and mounts it in the instrumented containers, pointing the OTEL_EXPERIMENTAL_CONFIG_FILE env var to it.
+	// The ConfigMap is created in the namespace of the Instrumentation, therefore only pods in the same namespace
+	// get the file mounted.
+	// The jaeger_remote, parentbased_jaeger_remote and xray samplers are not supported in the file.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
 // Defaults defines default values for the instrumentation.
 type Defaults struct {
 	// UseLabelsForResourceAttributes defines whether to use common labels for resource attributes:
@@ -243,6 +426,56 @@ type Python struct {
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
 }
 
+// Ruby defines Ruby SDK and instrumentation configuration.
+type Ruby struct {
+	// Image is a container image with Ruby SDK and auto-instrumentation.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// VolumeClaimTemplate defines a ephemeral volume used for auto-instrumentation.
+	// If omitted, an emptyDir is used with size limit VolumeSizeLimit
+	VolumeClaimTemplate corev1.PersistentVolumeClaimTemplate `json:"volumeClaimTemplate,omitempty"`
+
+	// VolumeSizeLimit defines size limit for volume used for auto-instrumentation.
+	// The default size is 200Mi.
+	VolumeSizeLimit *resource.Quantity `json:"volumeLimitSize,omitempty"`
+
+	// Env defines ruby specific env vars. There are four layers for env vars' definitions and
+	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
+	// If the former var had been defined, then the other vars would be ignored.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Resources describes the compute resource requirements.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+}
+
+// PHP defines PHP SDK and instrumentation configuration.
+type PHP struct {
+	// Image is a container image with PHP SDK and auto-instrumentation.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// VolumeClaimTemplate defines a ephemeral volume used for auto-instrumentation.
+	// If omitted, an emptyDir is used with size limit VolumeSizeLimit
+	VolumeClaimTemplate corev1.PersistentVolumeClaimTemplate `json:"volumeClaimTemplate,omitempty"`
+
+	// VolumeSizeLimit defines size limit for volume used for auto-instrumentation.
+	// The default size is 200Mi.
+	VolumeSizeLimit *resource.Quantity `json:"volumeLimitSize,omitempty"`
+
+	// Env defines PHP specific env vars. There are four layers for env vars' definitions and
+	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
+	// If the former var had been defined, then the other vars would be ignored.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Resources describes the compute resource requirements.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+}
+
 // DotNet defines DotNet SDK and instrumentation configuration.
 type DotNet struct {
 	// Image is a container image with DotNet SDK and auto-instrumentation.
@@ -369,6 +602,55 @@ type Nginx struct {
 
 // InstrumentationStatus defines status of the instrumentation.
 type InstrumentationStatus struct {
+	// InjectedPods is the number of pods running an auto-instrumentation injected from this Instrumentation.
+	// +optional
+	InjectedPods int32 `json:"injectedPods,omitempty"`
+
+	// OutdatedPods is the number of injected pods running an agent image that differs from the one in the spec.
+	// Their workloads need a restart to pick up the current agent image.
+	// +optional
+	OutdatedPods int32 `json:"outdatedPods,omitempty"`
+
+	// Workloads lists the workloads with pods injected from this Instrumentation.
+	// +optional
+	// +listType=atomic
+	Workloads []InstrumentedWorkload `json:"workloads,omitempty"`
+
+	// LastRolloutTime is the last time a workload was restarted according to the rollout policy.
+	// +optional
+	LastRolloutTime *metav1.Time `json:"lastRolloutTime,omitempty"`
+}
+
+// InstrumentedWorkload describes a workload with pods running an auto-instrumentation injected from an Instrumentation.
+type InstrumentedWorkload struct {
+	// Kind of the workload owning the pods, e.g. Deployment, or Pod for pods without owner.
+	Kind string `json:"kind"`
+
+	// Namespace of the workload.
+	Namespace string `json:"namespace"`
+
+	// Name of the workload.
+	Name string `json:"name"`
+
+	// Language of the injected auto-instrumentation.
+	Language InstrumentationLanguage `json:"language"`
+
+	// Containers lists the injected containers.
+	// +optional
+	// +listType=atomic
+	Containers []string `json:"containers,omitempty"`
+
+	// Images lists the agent images the pods of the workload are running.
+	// +optional
+	// +listType=atomic
+	Images []string `json:"images,omitempty"`
+
+	// Pods is the number of injected pods of the workload.
+	Pods int32 `json:"pods"`
+
+	// OutdatedPods is the number of pods of the workload running an agent image that differs from the one in the spec.
+	// +optional
+	OutdatedPods int32 `json:"outdatedPods,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -378,6 +660,8 @@ type InstrumentationStatus struct {
 // +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.exporter.endpoint"
 // +kubebuilder:printcolumn:name="Sampler",type="string",JSONPath=".spec.sampler.type"
 // +kubebuilder:printcolumn:name="Sampler Arg",type="string",JSONPath=".spec.sampler.argument"
+// +kubebuilder:printcolumn:name="Injected",type="integer",JSONPath=".status.injectedPods",priority=1
+// +kubebuilder:printcolumn:name="Outdated",type="integer",JSONPath=".status.outdatedPods",priority=1
 // +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Instrumentation"
 // +operator-sdk:csv:customresourcedefinitions:resources={{Pod,v1}}
 
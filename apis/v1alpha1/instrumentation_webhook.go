@@ -20,18 +20,24 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
+// DefaultRolloutInterval is the minimum duration between two batches of restarts when the rollout policy sets none.
+const DefaultRolloutInterval = 5 * time.Minute
+
 var (
 	_                                  admission.CustomValidator = &InstrumentationWebhook{}
 	_                                  admission.CustomDefaulter = &InstrumentationWebhook{}
@@ -194,6 +200,36 @@ func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
 	if r.Spec.Nginx.ConfigFile == "" {
 		r.Spec.Nginx.ConfigFile = "/etc/nginx/nginx.conf"
 	}
+	if r.Spec.Ruby.Image == "" {
+		r.Spec.Ruby.Image = w.cfg.AutoInstrumentationRubyImage()
+	}
+	if r.Spec.Ruby.Resources.Limits == nil {
+		r.Spec.Ruby.Resources.Limits = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		}
+	}
+	if r.Spec.Ruby.Resources.Requests == nil {
+		r.Spec.Ruby.Resources.Requests = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		}
+	}
+	if r.Spec.PHP.Image == "" {
+		r.Spec.PHP.Image = w.cfg.AutoInstrumentationPHPImage()
+	}
+	if r.Spec.PHP.Resources.Limits == nil {
+		r.Spec.PHP.Resources.Limits = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		}
+	}
+	if r.Spec.PHP.Resources.Requests == nil {
+		r.Spec.PHP.Resources.Requests = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		}
+	}
 	// Set the defaulting annotations
 	if r.Annotations == nil {
 		r.Annotations = map[string]string{}
@@ -205,6 +241,19 @@ func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
 	r.Annotations[constants.AnnotationDefaultAutoInstrumentationGo] = w.cfg.AutoInstrumentationGoImage()
 	r.Annotations[constants.AnnotationDefaultAutoInstrumentationApacheHttpd] = w.cfg.AutoInstrumentationApacheHttpdImage()
 	r.Annotations[constants.AnnotationDefaultAutoInstrumentationNginx] = w.cfg.AutoInstrumentationNginxImage()
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationRuby] = w.cfg.AutoInstrumentationRubyImage()
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationPHP] = w.cfg.AutoInstrumentationPHPImage()
+	if r.Spec.RolloutPolicy != nil {
+		if r.Spec.RolloutPolicy.Mode == "" {
+			r.Spec.RolloutPolicy.Mode = RolloutModeManual
+		}
+		if r.Spec.RolloutPolicy.MaxConcurrent == 0 {
+			r.Spec.RolloutPolicy.MaxConcurrent = 1
+		}
+		if r.Spec.RolloutPolicy.Interval.Duration == 0 {
+			r.Spec.RolloutPolicy.Interval = metav1.Duration{Duration: DefaultRolloutInterval}
+		}
+	}
 	return nil
 }
 
@@ -237,6 +286,12 @@ func (w InstrumentationWebhook) validate(r *Instrumentation) (admission.Warnings
 	default:
 		return warnings, fmt.Errorf("spec.sampler.type is not valid: %s", r.Spec.Sampler.Type)
 	}
+	if r.Spec.ConfigFile.Enabled {
+		switch r.Spec.Sampler.Type {
+		case JaegerRemote, ParentBasedJaegerRemote, XRaySampler:
+			return warnings, fmt.Errorf("spec.sampler.type %s is not supported with spec.configFile", r.Spec.Sampler.Type)
+		}
+	}
 
 	var err error
 	err = validateInstrVolume(r.Spec.ApacheHttpd.VolumeClaimTemplate, r.Spec.ApacheHttpd.VolumeSizeLimit)
@@ -267,12 +322,49 @@ func (w InstrumentationWebhook) validate(r *Instrumentation) (admission.Warnings
 	if err != nil {
 		return warnings, fmt.Errorf("spec.python.volumeClaimTemplate and spec.python.volumeSizeLimit cannot both be defined: %w", err)
 	}
+	err = validateInstrVolume(r.Spec.Ruby.VolumeClaimTemplate, r.Spec.Ruby.VolumeSizeLimit)
+	if err != nil {
+		return warnings, fmt.Errorf("spec.ruby.volumeClaimTemplate and spec.ruby.volumeSizeLimit cannot both be defined: %w", err)
+	}
+	err = validateInstrVolume(r.Spec.PHP.VolumeClaimTemplate, r.Spec.PHP.VolumeSizeLimit)
+	if err != nil {
+		return warnings, fmt.Errorf("spec.php.volumeClaimTemplate and spec.php.volumeSizeLimit cannot both be defined: %w", err)
+	}
 
+	if err := validateExporterSettings(r.Spec.Exporter, r.Spec.Selector); err != nil {
+		return warnings, err
+	}
 	warnings = append(warnings, validateExporter(r.Spec.Exporter)...)
 
+	if err = validateSelector(r.Spec.Selector); err != nil {
+		return warnings, err
+	}
+	if r.Spec.RolloutPolicy != nil && r.Spec.RolloutPolicy.MaxConcurrent < 0 {
+		return warnings, fmt.Errorf("spec.rolloutPolicy.maxConcurrent should be greater than 0: %d", r.Spec.RolloutPolicy.MaxConcurrent)
+	}
+	if r.Spec.RolloutPolicy != nil && !featuregate.EnableInstrumentationStatus.IsEnabled() {
+		warnings = append(warnings, fmt.Sprintf("spec.rolloutPolicy has no effect unless the %s feature gate is enabled", featuregate.EnableInstrumentationStatus.ID()))
+	}
+
 	return warnings, nil
 }
 
+func validateSelector(selector *InstrumentationSelector) error {
+	if selector == nil {
+		return nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(selector.PodSelector); err != nil {
+		return fmt.Errorf("spec.selector.podSelector is invalid: %w", err)
+	}
+	if _, err := metav1.LabelSelectorAsSelector(selector.NamespaceSelector); err != nil {
+		return fmt.Errorf("spec.selector.namespaceSelector is invalid: %w", err)
+	}
+	if len(selector.Languages) == 0 {
+		return fmt.Errorf("spec.selector.languages must contain at least one language")
+	}
+	return nil
+}
+
 func validateExporter(exporter Exporter) []string {
 	var warnings []string
 	if exporter.TLS != nil {
@@ -292,6 +384,32 @@ func validateExporter(exporter Exporter) []string {
 	return warnings
 }
 
+func validateExporterSettings(exporter Exporter, selector *InstrumentationSelector) error {
+	names := map[string]string{}
+	for i, header := range exporter.Headers {
+		if header.Name == "" || strings.ContainsAny(header.Name, "=, ") {
+			return fmt.Errorf("spec.exporter.headers[%d].name is not a valid header name: %q", i, header.Name)
+		}
+		if name, ok := names[header.EnvName()]; ok {
+			return fmt.Errorf("spec.exporter.headers[%d].name is duplicated: %s shares the env var %s with %s", i, header.Name, header.EnvName(), name)
+		}
+		names[header.EnvName()] = header.Name
+		if header.Value != "" && header.SecretKeyRef != nil {
+			return fmt.Errorf("spec.exporter.headers[%d] cannot set both value and secretKeyRef", i)
+		}
+		if header.SecretKeyRef != nil && (header.SecretKeyRef.Name == "" || header.SecretKeyRef.Key == "") {
+			return fmt.Errorf("spec.exporter.headers[%d].secretKeyRef must set the name and key of the secret", i)
+		}
+		if header.SecretKeyRef != nil && selector != nil && selector.NamespaceSelector != nil {
+			return fmt.Errorf("spec.exporter.headers[%d].secretKeyRef cannot be used with spec.selector.namespaceSelector, the secret isn't available in the other namespaces", i)
+		}
+	}
+	if exporter.Timeout != nil && exporter.Timeout.Duration <= 0 {
+		return fmt.Errorf("spec.exporter.timeout should be greater than 0: %s", exporter.Timeout.Duration)
+	}
+	return nil
+}
+
 func validateJaegerRemoteSamplerArgument(argument string) error {
 	parts := strings.Split(argument, ",")
 
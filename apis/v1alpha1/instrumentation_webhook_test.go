@@ -17,10 +17,12 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -38,6 +40,8 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 			config.WithAutoInstrumentationDotNetImage("dotnet-img:1"),
 			config.WithAutoInstrumentationApacheHttpdImage("apache-httpd-img:1"),
 			config.WithAutoInstrumentationNginxImage("nginx-img:1"),
+			config.WithAutoInstrumentationRubyImage("ruby-img:1"),
+			config.WithAutoInstrumentationPHPImage("php-img:1"),
 		),
 	}.Default(context.Background(), inst)
 	assert.NoError(t, err)
@@ -47,6 +51,23 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 	assert.Equal(t, "dotnet-img:1", inst.Spec.DotNet.Image)
 	assert.Equal(t, "apache-httpd-img:1", inst.Spec.ApacheHttpd.Image)
 	assert.Equal(t, "nginx-img:1", inst.Spec.Nginx.Image)
+	assert.Equal(t, "ruby-img:1", inst.Spec.Ruby.Image)
+	assert.Equal(t, "php-img:1", inst.Spec.PHP.Image)
+}
+
+func TestInstrumentationDefaultingWebhookRolloutPolicy(t *testing.T) {
+	inst := &Instrumentation{
+		Spec: InstrumentationSpec{
+			RolloutPolicy: &RolloutPolicy{},
+		},
+	}
+	err := InstrumentationWebhook{
+		cfg: config.New(),
+	}.Default(context.Background(), inst)
+	assert.NoError(t, err)
+	assert.Equal(t, RolloutModeManual, inst.Spec.RolloutPolicy.Mode)
+	assert.Equal(t, int32(1), inst.Spec.RolloutPolicy.MaxConcurrent)
+	assert.Equal(t, 5*time.Minute, inst.Spec.RolloutPolicy.Interval.Duration)
 }
 
 func TestInstrumentationValidatingWebhook(t *testing.T) {
@@ -96,6 +117,191 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "invalid pod selector",
+			err:  "spec.selector.podSelector is invalid",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: AlwaysOn,
+					},
+					Selector: &InstrumentationSelector{
+						PodSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+						},
+						Languages: []InstrumentationLanguage{LanguageJava},
+					},
+				},
+			},
+		},
+		{
+			name: "selector without languages",
+			err:  "spec.selector.languages must contain at least one language",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: AlwaysOn,
+					},
+					Selector: &InstrumentationSelector{
+						PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "shop"}},
+					},
+				},
+			},
+		},
+		{
+			name: "negative rollout concurrency",
+			err:  "spec.rolloutPolicy.maxConcurrent should be greater than 0",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: AlwaysOn,
+					},
+					RolloutPolicy: &RolloutPolicy{
+						Mode:          RolloutModeAutomatic,
+						MaxConcurrent: -1,
+					},
+				},
+			},
+		},
+		{
+			name:     "rollout policy without the instrumentation status",
+			warnings: []string{"spec.rolloutPolicy has no effect unless the operator.instrumentation.status feature gate is enabled"},
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: AlwaysOn,
+					},
+					RolloutPolicy: &RolloutPolicy{
+						Mode:          RolloutModeAutomatic,
+						MaxConcurrent: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "sampler not supported in the config file",
+			err:  "spec.sampler.type xray is not supported with spec.configFile",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: XRaySampler,
+					},
+					ConfigFile: ConfigFile{Enabled: true},
+				},
+			},
+		},
+		{
+			name: "exporter header with value and secret",
+			err:  "spec.exporter.headers[0] cannot set both value and secretKeyRef",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: AlwaysOn,
+					},
+					Exporter: Exporter{
+						Headers: []ExporterHeader{{
+							Name:         "Authorization",
+							Value:        "token",
+							SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "auth"}, Key: "token"},
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "exporter header secret without key",
+			err:  "spec.exporter.headers[0].secretKeyRef must set the name and key of the secret",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: AlwaysOn,
+					},
+					Exporter: Exporter{
+						Headers: []ExporterHeader{{
+							Name:         "Authorization",
+							SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "auth"}},
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "exporter header secret with namespace selector",
+			err:  "spec.exporter.headers[0].secretKeyRef cannot be used with spec.selector.namespaceSelector, the secret isn't available in the other namespaces",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: AlwaysOn,
+					},
+					Selector: &InstrumentationSelector{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "shop"}},
+						Languages:         []InstrumentationLanguage{LanguageJava},
+					},
+					Exporter: Exporter{
+						Headers: []ExporterHeader{{
+							Name:         "Authorization",
+							SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "auth"}, Key: "token"},
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "exporter header duplicated",
+			err:  "spec.exporter.headers[1].name is duplicated: X-Tenant",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: AlwaysOn,
+					},
+					Exporter: Exporter{
+						Headers: []ExporterHeader{{Name: "x-tenant", Value: "a"}, {Name: "X-Tenant", Value: "b"}},
+					},
+				},
+			},
+		},
+		{
+			name: "exporter header env var collision",
+			err:  "spec.exporter.headers[1].name is duplicated: X_Api_Key shares the env var OTEL_EXPORTER_OTLP_HEADER_X_API_KEY with X-Api-Key",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: AlwaysOn,
+					},
+					Exporter: Exporter{
+						Headers: []ExporterHeader{{Name: "X-Api-Key", Value: "a"}, {Name: "X_Api_Key", Value: "b"}},
+					},
+				},
+			},
+		},
+		{
+			name: "exporter header name invalid",
+			err:  "spec.exporter.headers[0].name is not a valid header name: \"x=tenant\"",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: AlwaysOn,
+					},
+					Exporter: Exporter{
+						Headers: []ExporterHeader{{Name: "x=tenant", Value: "a"}},
+					},
+				},
+			},
+		},
+		{
+			name: "exporter timeout not positive",
+			err:  "spec.exporter.timeout should be greater than 0: 0s",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: AlwaysOn,
+					},
+					Exporter: Exporter{
+						Timeout: &metav1.Duration{},
+					},
+				},
+			},
+		},
 		{
 			name: "argument is a number",
 			inst: Instrumentation{
@@ -134,6 +340,40 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 			},
 			warnings: []string{"sampler type not set"},
 		},
+		{
+			name: "with ruby volume and volumeSizeLimit",
+			err:  "spec.ruby.volumeClaimTemplate and spec.ruby.volumeSizeLimit cannot both be defined",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Ruby: Ruby{
+						VolumeClaimTemplate: corev1.PersistentVolumeClaimTemplate{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+							},
+						},
+						VolumeSizeLimit: &defaultVolumeSize,
+					},
+				},
+			},
+			warnings: []string{"sampler type not set"},
+		},
+		{
+			name: "with php volume and volumeSizeLimit",
+			err:  "spec.php.volumeClaimTemplate and spec.php.volumeSizeLimit cannot both be defined",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					PHP: PHP{
+						VolumeClaimTemplate: corev1.PersistentVolumeClaimTemplate{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+							},
+						},
+						VolumeSizeLimit: &defaultVolumeSize,
+					},
+				},
+			},
+			warnings: []string{"sampler type not set"},
+		},
 		{
 			name: "exporter: tls cert set but missing key",
 			inst: Instrumentation{
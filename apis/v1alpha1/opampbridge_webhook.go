@@ -130,12 +130,16 @@ func (o *OpAMPBridgeWebhook) validate(r *OpAMPBridge) (admission.Warnings, error
 	return warnings, nil
 }
 
-func SetupOpAMPBridgeWebhook(mgr ctrl.Manager, cfg config.Config) error {
-	webhook := &OpAMPBridgeWebhook{
-		logger: mgr.GetLogger().WithValues("handler", "OpAMPBridgeWebhook"),
-		scheme: mgr.GetScheme(),
+func NewOpAMPBridgeWebhook(logger logr.Logger, scheme *runtime.Scheme, cfg config.Config) *OpAMPBridgeWebhook {
+	return &OpAMPBridgeWebhook{
+		logger: logger,
+		scheme: scheme,
 		cfg:    cfg,
 	}
+}
+
+func SetupOpAMPBridgeWebhook(mgr ctrl.Manager, cfg config.Config) error {
+	webhook := NewOpAMPBridgeWebhook(mgr.GetLogger().WithValues("handler", "OpAMPBridgeWebhook"), mgr.GetScheme(), cfg)
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&OpAMPBridge{}).
 		WithValidator(webhook).
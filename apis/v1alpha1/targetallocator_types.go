@@ -55,6 +55,12 @@ type TargetAllocatorStatus struct {
 	// Image indicates the container image to use for the Target Allocator.
 	// +optional
 	Image string `json:"image,omitempty"`
+
+	// Conditions represent the latest available observations of the resource's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // TargetAllocatorSpec defines the desired state of TargetAllocator.
@@ -133,13 +133,17 @@ func (w TargetAllocatorWebhook) validate(ctx context.Context, ta *TargetAllocato
 	return warnings, nil
 }
 
-func SetupTargetAllocatorWebhook(mgr ctrl.Manager, cfg config.Config, reviewer *rbac.Reviewer) error {
-	cvw := &TargetAllocatorWebhook{
+func NewTargetAllocatorWebhook(logger logr.Logger, scheme *runtime.Scheme, cfg config.Config, reviewer *rbac.Reviewer) *TargetAllocatorWebhook {
+	return &TargetAllocatorWebhook{
 		reviewer: reviewer,
-		logger:   mgr.GetLogger().WithValues("handler", "TargetAllocatorWebhook", "version", "v1beta1"),
-		scheme:   mgr.GetScheme(),
+		logger:   logger,
+		scheme:   scheme,
 		cfg:      cfg,
 	}
+}
+
+func SetupTargetAllocatorWebhook(mgr ctrl.Manager, cfg config.Config, reviewer *rbac.Reviewer) error {
+	cvw := NewTargetAllocatorWebhook(mgr.GetLogger().WithValues("handler", "TargetAllocatorWebhook", "version", "v1beta1"), mgr.GetScheme(), cfg, reviewer)
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&TargetAllocator{}).
 		WithValidator(cvw).
@@ -17,20 +17,30 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"path"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/hashicorp/cronexpr"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/schema"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/fips"
 	ta "github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
@@ -118,6 +128,11 @@ func (c CollectorWebhook) ValidateCreate(ctx context.Context, obj runtime.Object
 	if err != nil {
 		return warnings, err
 	}
+	schemaWarnings, err := c.validateConfigSchema(otelcol)
+	warnings = append(warnings, schemaWarnings...)
+	if err != nil {
+		return warnings, err
+	}
 	if c.metrics != nil {
 		c.metrics.create(ctx, otelcol)
 	}
@@ -146,6 +161,11 @@ func (c CollectorWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj run
 	if err != nil {
 		return warnings, err
 	}
+	schemaWarnings, err := c.validateConfigSchema(otelcol)
+	warnings = append(warnings, schemaWarnings...)
+	if err != nil {
+		return warnings, err
+	}
 
 	if c.metrics != nil {
 		c.metrics.update(ctx, otelcolOld, otelcol)
@@ -213,6 +233,75 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'AdditionalContainers'", r.Spec.Mode)
 	}
 
+	if r.Spec.SidecarTemplate != nil {
+		if r.Spec.Mode != ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'sidecarTemplate'", r.Spec.Mode)
+		}
+		if err := validateSidecarTemplate(r.Spec.SidecarTemplate); err != nil {
+			return warnings, err
+		}
+	}
+
+	if r.Spec.SidecarResources != nil {
+		if r.Spec.Mode != ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'sidecarResources'", r.Spec.Mode)
+		}
+		for name, minAllowed := range r.Spec.SidecarResources.MinAllowed {
+			if maxAllowed, ok := r.Spec.SidecarResources.MaxAllowed[name]; ok && minAllowed.Cmp(maxAllowed) > 0 {
+				return warnings, fmt.Errorf("the OpenTelemetry Spec sidecarResources.minAllowed %s is greater than sidecarResources.maxAllowed", name)
+			}
+		}
+	}
+
+	if r.Spec.Scaling != nil {
+		if r.Spec.Mode != ModeDeployment && r.Spec.Mode != ModeStatefulSet {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'scaling'", r.Spec.Mode)
+		}
+		if err := checkScaling(r); err != nil {
+			return warnings, err
+		}
+		if !featuregate.EnableCollectorScaling.IsEnabled() {
+			warnings = append(warnings, fmt.Sprintf("spec.scaling has no effect unless the %s feature gate is enabled", featuregate.EnableCollectorScaling.ID()))
+		}
+	}
+
+	if r.Spec.Rollout != nil {
+		if r.Spec.Mode != ModeDeployment {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'rollout'", r.Spec.Mode)
+		}
+		if r.Spec.Rollout.Analysis.Duration.Duration < 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec rollout configuration is incorrect, analysis.duration should not be negative")
+		}
+		if r.Spec.Rollout.Analysis.MaxExportFailurePercentage < 100 {
+			if telemetry := r.Spec.Config.Service.GetTelemetry(); telemetry != nil && telemetry.Metrics.Level == "none" {
+				warnings = append(warnings, "the export failures of the rolled out configurations can't be analyzed, the collector telemetry metrics are disabled by service.telemetry.metrics.level")
+			}
+		}
+		if !featuregate.EnableCollectorRollout.IsEnabled() {
+			warnings = append(warnings, fmt.Sprintf("spec.rollout has no effect unless the %s feature gate is enabled", featuregate.EnableCollectorRollout.ID()))
+		}
+	}
+
+	if len(r.Spec.NodePools) > 0 {
+		if r.Spec.Mode != ModeDaemonSet {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'nodePools'", r.Spec.Mode)
+		}
+		poolWarnings, err := checkNodePools(r)
+		warnings = append(warnings, poolWarnings...)
+		if err != nil {
+			return warnings, err
+		}
+	}
+
+	if r.Spec.Persistence != nil {
+		if r.Spec.Mode != ModeStatefulSet && r.Spec.Mode != ModeDaemonSet {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'persistence'", r.Spec.Mode)
+		}
+		if err := checkPersistence(r); err != nil {
+			return warnings, err
+		}
+	}
+
 	// validate target allocator configs
 	if r.Spec.TargetAllocator.Enabled {
 		taWarnings, err := c.validateTargetAllocatorConfig(ctx, r)
@@ -229,6 +318,21 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 		return warnings, err
 	}
 
+	// validate config fragments
+	if err := ValidateConfigFragments(r.Namespace, r.Spec.ConfigFragments); err != nil {
+		return warnings, err
+	}
+
+	// validate autoscale on the collector telemetry
+	if r.Spec.Autoscaler != nil && len(r.Spec.Autoscaler.Triggers) > 0 {
+		if err := c.checkAutoscalerTriggers(r); err != nil {
+			return warnings, err
+		}
+		if !r.Spec.Observability.Metrics.EnableMetrics {
+			warnings = append(warnings, "spec.autoscaler.triggers are queried from Prometheus, which only scrapes the collector telemetry through the operator when spec.observability.metrics.enableMetrics is set")
+		}
+	}
+
 	var maxReplicas *int32
 	if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.MaxReplicas != nil {
 		maxReplicas = r.Spec.Autoscaler.MaxReplicas
@@ -276,6 +380,25 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 			ModeDeployment, ModeDaemonSet, ModeStatefulSet,
 		)
 	}
+	if r.Spec.Ingress.Type == IngressTypeGateway {
+		if r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. Gateway routes can only be used in combination with the modes: %s, %s, %s",
+				ModeDeployment, ModeDaemonSet, ModeStatefulSet,
+			)
+		}
+		if r.Spec.Ingress.Gateway.ParentRef.Name == "" {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. The name of the Gateway the routes are attached to has to be defined for the gateway type")
+		}
+		// the GRPCRoutes of the receivers can only be told apart by their hostname
+		if r.Spec.Ingress.RuleType != IngressRuleTypeSubdomain {
+			ports, err := r.Spec.Config.GetReceiverPorts(c.logger)
+			if err == nil && slices.ContainsFunc(ports, func(p corev1.ServicePort) bool {
+				return p.AppProtocol != nil && strings.EqualFold(*p.AppProtocol, "grpc")
+			}) {
+				return warnings, fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. The gRPC receivers can only be exposed through the Gateway API with the %s ruleType", IngressRuleTypeSubdomain)
+			}
+		}
+	}
 	if r.Spec.Ingress.RuleType == IngressRuleTypeSubdomain && (r.Spec.Ingress.Hostname == "" || r.Spec.Ingress.Hostname == "*") {
 		return warnings, fmt.Errorf("a valid Ingress hostname has to be defined for subdomain ruleType")
 	}
@@ -289,6 +412,12 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 	if err != nil {
 		return warnings, err
 	}
+	if err = validateProbePipeline("LivenessProbe", r.Spec.LivenessProbe, r.Spec.Config); err != nil {
+		return warnings, err
+	}
+	if err = validateProbePipeline("ReadinessProbe", r.Spec.ReadinessProbe, r.Spec.Config); err != nil {
+		return warnings, err
+	}
 
 	// validate updateStrategy for DaemonSet
 	if r.Spec.Mode != ModeDaemonSet && len(r.Spec.DaemonSetUpdateStrategy.Type) > 0 {
@@ -310,6 +439,64 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 	return warnings, nil
 }
 
+// validateConfigSchema checks the configuration of every component against the schema bundle of the operator. It's
+// only done on create and update, a collector must remain deletable when the bundle changes.
+func (c CollectorWebhook) validateConfigSchema(r *OpenTelemetryCollector) (admission.Warnings, error) {
+	mode := c.cfg.CollectorConfigSchemaValidation()
+	if mode != schema.ValidationWarn && mode != schema.ValidationEnforce {
+		return nil, nil
+	}
+
+	collectorVersion := r.Status.Version
+	if collectorVersion == "" {
+		collectorVersion = version.OpenTelemetryCollector()
+	}
+	sections := []struct {
+		kind   string
+		config *AnyConfig
+	}{
+		{"receiver", &r.Spec.Config.Receivers},
+		{"processor", r.Spec.Config.Processors},
+		{"exporter", &r.Spec.Config.Exporters},
+		{"connector", r.Spec.Config.Connectors},
+		{"extension", r.Spec.Config.Extensions},
+	}
+	schemas := c.cfg.CollectorConfigSchemas()
+	var problems, unvalidated []string
+	for _, section := range sections {
+		if section.config == nil {
+			continue
+		}
+		ids := make([]string, 0, len(section.config.Object))
+		for id := range section.config.Object {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			if !schemas.Has(collectorVersion, section.kind, id) {
+				unvalidated = append(unvalidated, fmt.Sprintf("%ss.%s", section.kind, id))
+				continue
+			}
+			problems = append(problems, schemas.Validate(collectorVersion, section.kind, id, section.config.Object[id])...)
+		}
+	}
+
+	warnings := admission.Warnings{}
+	if len(unvalidated) > 0 {
+		warnings = append(warnings, fmt.Sprintf("Collector config spec.config has components without a schema for collector version %s, their configuration isn't validated: %s", collectorVersion, strings.Join(unvalidated, ", ")))
+	}
+	if len(problems) == 0 {
+		return warnings, nil
+	}
+	if mode == schema.ValidationEnforce {
+		return warnings, fmt.Errorf("the OpenTelemetry Spec Config configuration is incorrect, it doesn't match the component schemas: %s", strings.Join(problems, "; "))
+	}
+	for _, problem := range problems {
+		warnings = append(warnings, fmt.Sprintf("Collector config spec.config doesn't match the component schema: %s", problem))
+	}
+	return warnings, nil
+}
+
 func (c CollectorWebhook) validateTargetAllocatorConfig(ctx context.Context, r *OpenTelemetryCollector) (admission.Warnings, error) {
 	if r.Spec.Mode != ModeStatefulSet && r.Spec.Mode != ModeDaemonSet {
 		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the target allocation deployment", r.Spec.Mode)
@@ -380,6 +567,16 @@ func ValidateProbe(probeName string, probe *Probe) error {
 	return nil
 }
 
+func validateProbePipeline(probeName string, probe *Probe, cfg Config) error {
+	if probe == nil || probe.Pipeline == "" {
+		return nil
+	}
+	if _, ok := cfg.Service.Pipelines[probe.Pipeline]; !ok {
+		return fmt.Errorf("the OpenTelemetry Spec %s Pipeline configuration is incorrect. The pipeline %s doesn't exist", probeName, probe.Pipeline)
+	}
+	return nil
+}
+
 func ValidatePorts(ports []PortsSpec) error {
 	for _, p := range ports {
 		nameErrs := validation.IsValidPortName(p.Name)
@@ -392,6 +589,25 @@ func ValidatePorts(ports []PortsSpec) error {
 	return nil
 }
 
+func ValidateConfigFragments(namespace string, fragments []ConfigFragmentReference) error {
+	seen := map[string]bool{}
+	for _, f := range fragments {
+		if f.Name == "" {
+			return fmt.Errorf("the OpenTelemetry Spec configFragments configuration is incorrect, a config fragment name must be set")
+		}
+		fragmentNamespace := f.Namespace
+		if fragmentNamespace == "" {
+			fragmentNamespace = namespace
+		}
+		key := fmt.Sprintf("%s/%s/%s", fragmentNamespace, f.Name, f.Key)
+		if seen[key] {
+			return fmt.Errorf("the OpenTelemetry Spec configFragments configuration is incorrect, config fragment %s/%s is referenced more than once", fragmentNamespace, f.Name)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
 func checkAutoscalerSpec(autoscaler *AutoscalerSpec) error {
 	if autoscaler.Behavior != nil {
 		if autoscaler.Behavior.ScaleDown != nil && autoscaler.Behavior.ScaleDown.StabilizationWindowSeconds != nil &&
@@ -433,6 +649,166 @@ func checkAutoscalerSpec(autoscaler *AutoscalerSpec) error {
 	return nil
 }
 
+// checkAutoscalerTriggers ensures the telemetry metrics the triggers reference are enabled, and that the triggers can
+// be served by KEDA when it's available.
+func (c CollectorWebhook) checkAutoscalerTriggers(r *OpenTelemetryCollector) error {
+	if telemetry := r.Spec.Config.Service.GetTelemetry(); telemetry != nil && telemetry.Metrics.Level == "none" {
+		return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, the triggers reference the collector telemetry metrics, which are disabled by service.telemetry.metrics.level")
+	}
+	for _, trigger := range r.Spec.Autoscaler.Triggers {
+		if trigger.TargetAverageValue.Sign() <= 0 {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, targetAverageValue of the %s trigger should be greater than 0", trigger.Metric)
+		}
+	}
+	if c.cfg.KEDAAvailability() == keda.Available {
+		if r.Spec.Autoscaler.PrometheusServerAddress == "" {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, prometheusServerAddress has to be defined for the triggers to be queried by KEDA")
+		}
+		if len(r.Spec.Autoscaler.Metrics) > 0 {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, metrics can't be combined with triggers when the collector is scaled by KEDA")
+		}
+	}
+	return nil
+}
+
+// checkScaling ensures the schedules of the collector can be evaluated, and that the items it receives can be observed
+// for the idle scale down.
+func checkScaling(r *OpenTelemetryCollector) error {
+	scaling := r.Spec.Scaling
+	if scaling.TimeZone != "" {
+		if _, err := time.LoadLocation(scaling.TimeZone); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec scaling configuration is incorrect, invalid timeZone %s: %w", scaling.TimeZone, err)
+		}
+	}
+	names := map[string]bool{}
+	for _, schedule := range scaling.Schedules {
+		if names[schedule.Name] {
+			return fmt.Errorf("the OpenTelemetry Spec scaling configuration is incorrect, the %s schedule is defined more than once", schedule.Name)
+		}
+		names[schedule.Name] = true
+		if _, err := cronexpr.Parse(schedule.Start); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec scaling configuration is incorrect, invalid start of the %s schedule: %w", schedule.Name, err)
+		}
+		if schedule.Duration.Duration <= 0 {
+			return fmt.Errorf("the OpenTelemetry Spec scaling configuration is incorrect, the duration of the %s schedule should be greater than 0", schedule.Name)
+		}
+	}
+	if scaling.IdleScaleDown != nil {
+		if scaling.IdleScaleDown.After.Duration <= 0 {
+			return fmt.Errorf("the OpenTelemetry Spec scaling configuration is incorrect, idleScaleDown.after should be greater than 0")
+		}
+		if telemetry := r.Spec.Config.Service.GetTelemetry(); telemetry != nil && telemetry.Metrics.Level == "none" {
+			return fmt.Errorf("the OpenTelemetry Spec scaling configuration is incorrect, the idle scale down reads the collector telemetry metrics, which are disabled by service.telemetry.metrics.level")
+		}
+	}
+	return nil
+}
+
+// checkNodePools ensures the configuration of every node pool remains a valid collector configuration once patched, and
+// warns about the pools which can't be scheduled on any node because a previous pool already selects all their nodes.
+func checkNodePools(r *OpenTelemetryCollector) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	for i, pool := range r.Spec.NodePools {
+		for _, previous := range r.Spec.NodePools[:i] {
+			if selectsAll(previous.NodeSelector, pool.NodeSelector) {
+				warnings = append(warnings, fmt.Sprintf("the %s node pool doesn't run on any node, all its nodes belong to the %s node pool", pool.Name, previous.Name))
+				break
+			}
+		}
+		if pool.Config == nil {
+			continue
+		}
+		cfg := *r.Spec.Config.DeepCopy()
+		if err := cfg.ApplyPatch(pool.Config.Object); err != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec nodePools configuration is incorrect, the config of the %s node pool can't be applied: %w", pool.Name, err)
+		}
+		for name, pipeline := range cfg.Service.Pipelines {
+			if pipeline == nil {
+				continue
+			}
+			if undefined := undefinedComponents(pipeline.Receivers, &cfg.Receivers, cfg.Connectors); len(undefined) > 0 {
+				return warnings, fmt.Errorf("the OpenTelemetry Spec nodePools configuration is incorrect, the %s pipeline of the %s node pool uses undefined receivers: %s", name, pool.Name, strings.Join(undefined, ", "))
+			}
+			if undefined := undefinedComponents(pipeline.Processors, cfg.Processors); len(undefined) > 0 {
+				return warnings, fmt.Errorf("the OpenTelemetry Spec nodePools configuration is incorrect, the %s pipeline of the %s node pool uses undefined processors: %s", name, pool.Name, strings.Join(undefined, ", "))
+			}
+			if undefined := undefinedComponents(pipeline.Exporters, &cfg.Exporters, cfg.Connectors); len(undefined) > 0 {
+				return warnings, fmt.Errorf("the OpenTelemetry Spec nodePools configuration is incorrect, the %s pipeline of the %s node pool uses undefined exporters: %s", name, pool.Name, strings.Join(undefined, ", "))
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// selectsAll returns whether every node matching the second node selector matches the first one.
+func selectsAll(selector, other map[string]string) bool {
+	for key, value := range selector {
+		if otherValue, ok := other[key]; !ok || otherValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// undefinedComponents returns the ids which aren't defined in any of the given component sections.
+func undefinedComponents(ids []string, sections ...*AnyConfig) []string {
+	var undefined []string
+	for _, id := range ids {
+		defined := false
+		for _, section := range sections {
+			if section == nil {
+				continue
+			}
+			if _, ok := section.Object[id]; ok {
+				defined = true
+				break
+			}
+		}
+		if !defined {
+			undefined = append(undefined, id)
+		}
+	}
+	return undefined
+}
+
+// checkPersistence ensures the volume of the sending queues can be mounted in the collector container.
+func checkPersistence(r *OpenTelemetryCollector) error {
+	persistence := r.Spec.Persistence
+	if persistence.Directory != "" && !path.IsAbs(persistence.Directory) {
+		return fmt.Errorf("the OpenTelemetry Spec persistence configuration is incorrect, directory should be an absolute path")
+	}
+	if persistence.HostPath != "" && !path.IsAbs(persistence.HostPath) {
+		return fmt.Errorf("the OpenTelemetry Spec persistence configuration is incorrect, hostPath should be an absolute path")
+	}
+	// the kubelet creates the missing hostPath directories for root, with 0755 permissions
+	if persistence.HostPath != "" && !runsAsRoot(r) {
+		return fmt.Errorf("the OpenTelemetry Spec persistence configuration is incorrect, the hostPath directories are created for the root user, the collector must run as root with runAsUser: 0 to write to them")
+	}
+	if persistence.Size.Sign() < 0 {
+		return fmt.Errorf("the OpenTelemetry Spec persistence configuration is incorrect, size should not be negative")
+	}
+	for _, volume := range r.Spec.Volumes {
+		if volume.Name == naming.PersistenceVolume() {
+			return fmt.Errorf("the OpenTelemetry Spec persistence configuration is incorrect, the %s volume is reserved for the sending queues", volume.Name)
+		}
+	}
+	for _, claim := range r.Spec.VolumeClaimTemplates {
+		if claim.Name == naming.PersistenceVolume() {
+			return fmt.Errorf("the OpenTelemetry Spec persistence configuration is incorrect, the %s volume is reserved for the sending queues", claim.Name)
+		}
+	}
+	return nil
+}
+
+// runsAsRoot returns whether the collector container runs as the root user, from its security context or the one of
+// its pod.
+func runsAsRoot(r *OpenTelemetryCollector) bool {
+	if r.Spec.SecurityContext != nil && r.Spec.SecurityContext.RunAsUser != nil {
+		return *r.Spec.SecurityContext.RunAsUser == 0
+	}
+	return r.Spec.PodSecurityContext != nil && r.Spec.PodSecurityContext.RunAsUser != nil && *r.Spec.PodSecurityContext.RunAsUser == 0
+}
+
 // BuildValidator enables running the manifest generators for the collector reconciler
 // +kubebuilder:object:generate=false
 type BuildValidator func(ctx context.Context, c OpenTelemetryCollector) admission.Warnings
@@ -465,3 +841,20 @@ func SetupCollectorWebhook(mgr ctrl.Manager, cfg config.Config, reviewer *rbac.R
 		WithDefaulter(cvw).
 		Complete()
 }
+
+func validateSidecarTemplate(template *SidecarTemplate) error {
+	if _, err := metav1.LabelSelectorAsSelector(&template.NamespaceSelector); err != nil {
+		return fmt.Errorf("the OpenTelemetry Spec sidecarTemplate.namespaceSelector is invalid: %w", err)
+	}
+	for i, override := range template.Overrides {
+		if _, err := metav1.LabelSelectorAsSelector(&override.NamespaceSelector); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec sidecarTemplate.overrides[%d].namespaceSelector is invalid: %w", i, err)
+		}
+		for key := range override.ResourceAttributes {
+			if key == "" || strings.ContainsAny(key, "=,") {
+				return fmt.Errorf("the OpenTelemetry Spec sidecarTemplate.overrides[%d].resourceAttributes key %q is invalid", i, key)
+			}
+		}
+	}
+	return nil
+}
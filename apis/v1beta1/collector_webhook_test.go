@@ -19,7 +19,9 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
@@ -39,6 +41,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/schema"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	collectorManifests "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
@@ -1124,6 +1128,66 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: fmt.Sprintf("Ingress can only be used in combination with the modes: %s, %s, %s", v1beta1.ModeDeployment, v1beta1.ModeDaemonSet, v1beta1.ModeStatefulSet),
 		},
+		{
+			name: "invalid sidecar mode with gateway routes",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeSidecar,
+					Ingress: v1beta1.Ingress{
+						Type: v1beta1.IngressTypeGateway,
+						Gateway: v1beta1.GatewayRoutes{
+							ParentRef: v1beta1.GatewayParentReference{Name: "otlp"},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Sprintf("Gateway routes can only be used in combination with the modes: %s, %s, %s", v1beta1.ModeDeployment, v1beta1.ModeDaemonSet, v1beta1.ModeStatefulSet),
+		},
+		{
+			name: "missing gateway of the gateway routes",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					Ingress: v1beta1.Ingress{
+						Type: v1beta1.IngressTypeGateway,
+					},
+				},
+			},
+			expectedErr: "The name of the Gateway the routes are attached to has to be defined for the gateway type",
+		},
+		{
+			name: "gateway routes of grpc receivers without subdomain",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					Ingress: v1beta1.Ingress{
+						Type:     v1beta1.IngressTypeGateway,
+						Hostname: "otel.example.com",
+						Gateway: v1beta1.GatewayRoutes{
+							ParentRef: v1beta1.GatewayParentReference{Name: "public"},
+						},
+					},
+					Config: v1beta1.Config{
+						Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+							"otlp": map[string]interface{}{
+								"protocols": map[string]interface{}{
+									"grpc": map[string]interface{}{},
+								},
+							},
+						}},
+						Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{
+							"debug": map[string]interface{}{},
+						}},
+						Service: v1beta1.Service{
+							Pipelines: map[string]*v1beta1.Pipeline{
+								"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "The gRPC receivers can only be exposed through the Gateway API with the subdomain ruleType",
+		},
 		{
 			name: "invalid mode with priorityClassName",
 			otelcol: v1beta1.OpenTelemetryCollector{
@@ -1186,6 +1250,17 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Spec ReadinessProbe InitialDelaySeconds configuration is incorrect",
 		},
+		{
+			name: "unknown probe pipeline",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					ReadinessProbe: &v1beta1.Probe{
+						Pipeline: "traces/missing",
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec ReadinessProbe Pipeline configuration is incorrect. The pipeline traces/missing doesn't exist",
+		},
 		{
 			name: "invalid PeriodSeconds",
 			otelcol: v1beta1.OpenTelemetryCollector{
@@ -1312,6 +1387,67 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'AdditionalContainers'",
 		},
+		{
+			name: "invalid mode with sidecarTemplate",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:            v1beta1.ModeDeployment,
+					SidecarTemplate: &v1beta1.SidecarTemplate{},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to deployment, which does not support the attribute 'sidecarTemplate'",
+		},
+		{
+			name: "invalid mode with sidecarResources",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:             v1beta1.ModeDeployment,
+					SidecarResources: &v1beta1.SidecarResourcesSpec{},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to deployment, which does not support the attribute 'sidecarResources'",
+		},
+		{
+			name: "invalid sidecarResources bounds",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeSidecar,
+					SidecarResources: &v1beta1.SidecarResourcesSpec{
+						MinAllowed: v1.ResourceList{v1.ResourceMemory: resource.MustParse("128Mi")},
+						MaxAllowed: v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Mi")},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec sidecarResources.minAllowed memory is greater than sidecarResources.maxAllowed",
+		},
+		{
+			name: "invalid sidecarTemplate namespace selector",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeSidecar,
+					SidecarTemplate: &v1beta1.SidecarTemplate{
+						NamespaceSelector: metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Contains"}},
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec sidecarTemplate.namespaceSelector is invalid",
+		},
+		{
+			name: "invalid sidecarTemplate resource attribute",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeSidecar,
+					SidecarTemplate: &v1beta1.SidecarTemplate{
+						Overrides: []v1beta1.SidecarTemplateOverride{{
+							ResourceAttributes: map[string]string{"team=a": "b"},
+						}},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec sidecarTemplate.overrides[0].resourceAttributes key \"team=a\" is invalid",
+		},
 		{
 			name: "missing ingress hostname for subdomain ruleType",
 			otelcol: v1beta1.OpenTelemetryCollector{
@@ -1373,6 +1509,32 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
 		},
+		{
+			name: "missing config fragment name",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					ConfigFragments: []v1beta1.ConfigFragmentReference{
+						{Key: "processors.yaml"},
+					},
+				},
+			},
+			expectedErr: "a config fragment name must be set",
+		},
+		{
+			name: "duplicated config fragment",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "apps",
+				},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					ConfigFragments: []v1beta1.ConfigFragmentReference{
+						{Name: "shared-exporters"},
+						{Name: "shared-exporters", Namespace: "apps"},
+					},
+				},
+			},
+			expectedErr: "config fragment apps/shared-exporters is referenced more than once",
+		},
 	}
 
 	bv := func(_ context.Context, collector v1beta1.OpenTelemetryCollector) admission.Warnings {
@@ -1490,6 +1652,94 @@ func TestOTELColValidateUpdateWebhook(t *testing.T) {
 	}
 }
 
+func TestOTELColConfigSchemaValidation(t *testing.T) {
+	schemas, err := schema.Shipped()
+	require.NoError(t, err)
+
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"otlp":   map[string]interface{}{"protocols": map[string]interface{}{"grpc": map[string]interface{}{}}},
+					"jaeger": map[string]interface{}{"protocols": map[string]interface{}{"grpc": map[string]interface{}{}}},
+				}},
+				Processors: &v1beta1.AnyConfig{Object: map[string]interface{}{
+					"batch": map[string]interface{}{"timeout": "5 parsecs"},
+				}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"debug": map[string]interface{}{"verbosty": "detailed"},
+				}},
+				Service: v1beta1.Service{
+					Pipelines: map[string]*v1beta1.Pipeline{
+						"traces": {Receivers: []string{"otlp", "jaeger"}, Processors: []string{"batch"}, Exporters: []string{"debug"}},
+					},
+				},
+			},
+		},
+		Status: v1beta1.OpenTelemetryCollectorStatus{Version: "0.116.1"},
+	}
+
+	tests := []struct {
+		name             string
+		mode             schema.ValidationMode
+		expectedErr      string
+		expectedWarnings []string
+	}{
+		{
+			name: "disabled",
+			mode: schema.ValidationDisabled,
+		},
+		{
+			name: "warn",
+			mode: schema.ValidationWarn,
+			expectedWarnings: []string{
+				"Collector config spec.config has components without a schema for collector version 0.116.1, their configuration isn't validated: receivers.jaeger",
+				"Collector config spec.config doesn't match the component schema: processors.batch.timeout in body must be of type duration: \"5 parsecs\"",
+				"Collector config spec.config doesn't match the component schema: exporters.debug.verbosty in body is a forbidden property",
+			},
+		},
+		{
+			name:        "enforce",
+			mode:        schema.ValidationEnforce,
+			expectedErr: "the OpenTelemetry Spec Config configuration is incorrect, it doesn't match the component schemas: processors.batch.timeout in body must be of type duration: \"5 parsecs\"; exporters.debug.verbosty in body is a forbidden property",
+			expectedWarnings: []string{
+				"Collector config spec.config has components without a schema for collector version 0.116.1, their configuration isn't validated: receivers.jaeger",
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cvw := v1beta1.NewCollectorWebhook(
+				logr.Discard(),
+				testScheme,
+				config.New(
+					config.WithCollectorImage("collector:v0.0.0"),
+					config.WithTargetAllocatorImage("ta:v0.0.0"),
+					config.WithCollectorConfigSchemaValidation(test.mode),
+					config.WithCollectorConfigSchemas(schemas),
+				),
+				getReviewer(false),
+				nil,
+				nil,
+				nil,
+			)
+			warnings, err := cvw.ValidateCreate(context.Background(), otelcol.DeepCopy())
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+			assert.ElementsMatch(t, test.expectedWarnings, warnings)
+
+			// deleting a collector never fails because of its schema
+			_, err = cvw.ValidateDelete(context.Background(), otelcol.DeepCopy())
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func getReviewer(shouldFailSAR bool) *rbac.Reviewer {
 	c := fake.NewSimpleClientset()
 	c.PrependReactor("create", "subjectaccessreviews", func(action kubeTesting.Action) (handled bool, ret runtime.Object, err error) {
@@ -1509,3 +1759,509 @@ func getReviewer(shouldFailSAR bool) *rbac.Reviewer {
 	})
 	return rbac.NewReviewer(c)
 }
+
+func TestOTELColAutoscalerTriggersValidation(t *testing.T) {
+	queueSize := v1beta1.AutoscalerTrigger{Metric: "otelcol_exporter_queue_size", TargetAverageValue: resource.MustParse("500")}
+	newCollector := func(autoscaler v1beta1.AutoscalerSpec, telemetry *v1beta1.AnyConfig) *v1beta1.OpenTelemetryCollector {
+		maxReplicas := int32(5)
+		autoscaler.MaxReplicas = &maxReplicas
+		return &v1beta1.OpenTelemetryCollector{
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:       v1beta1.ModeDeployment,
+				Autoscaler: &autoscaler,
+				Observability: v1beta1.ObservabilitySpec{
+					Metrics: v1beta1.MetricsConfigSpec{EnableMetrics: true},
+				},
+				Config: v1beta1.Config{
+					Service: v1beta1.Service{Telemetry: telemetry},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		keda            keda.Availability
+		otelcol         *v1beta1.OpenTelemetryCollector
+		expectedErr     string
+		expectedWarning string
+	}{
+		{
+			name:    "external metrics",
+			otelcol: newCollector(v1beta1.AutoscalerSpec{Triggers: []v1beta1.AutoscalerTrigger{queueSize}}, nil),
+		},
+		{
+			name: "telemetry metrics disabled",
+			otelcol: newCollector(v1beta1.AutoscalerSpec{Triggers: []v1beta1.AutoscalerTrigger{queueSize}},
+				&v1beta1.AnyConfig{Object: map[string]interface{}{"metrics": map[string]interface{}{"level": "none"}}}),
+			expectedErr: "the triggers reference the collector telemetry metrics, which are disabled by service.telemetry.metrics.level",
+		},
+		{
+			name: "target average value not greater than 0",
+			otelcol: newCollector(v1beta1.AutoscalerSpec{Triggers: []v1beta1.AutoscalerTrigger{
+				{Metric: "otelcol_exporter_queue_size", TargetAverageValue: resource.MustParse("0")},
+			}}, nil),
+			expectedErr: "targetAverageValue of the otelcol_exporter_queue_size trigger should be greater than 0",
+		},
+		{
+			name: "keda",
+			keda: keda.Available,
+			otelcol: newCollector(v1beta1.AutoscalerSpec{
+				Triggers:                []v1beta1.AutoscalerTrigger{queueSize},
+				PrometheusServerAddress: "http://prometheus.monitoring:9090",
+			}, nil),
+		},
+		{
+			name: "keda without the collector metrics scraped",
+			keda: keda.Available,
+			otelcol: func() *v1beta1.OpenTelemetryCollector {
+				otelcol := newCollector(v1beta1.AutoscalerSpec{
+					Triggers:                []v1beta1.AutoscalerTrigger{queueSize},
+					PrometheusServerAddress: "http://prometheus.monitoring:9090",
+				}, nil)
+				otelcol.Spec.Observability.Metrics.EnableMetrics = false
+				return otelcol
+			}(),
+			expectedWarning: "spec.autoscaler.triggers are queried from Prometheus, which only scrapes the collector telemetry through the operator when spec.observability.metrics.enableMetrics is set",
+		},
+		{
+			name:        "keda without prometheus server",
+			keda:        keda.Available,
+			otelcol:     newCollector(v1beta1.AutoscalerSpec{Triggers: []v1beta1.AutoscalerTrigger{queueSize}}, nil),
+			expectedErr: "prometheusServerAddress has to be defined for the triggers to be queried by KEDA",
+		},
+		{
+			name: "keda with pods metrics",
+			keda: keda.Available,
+			otelcol: newCollector(v1beta1.AutoscalerSpec{
+				Triggers:                []v1beta1.AutoscalerTrigger{queueSize},
+				PrometheusServerAddress: "http://prometheus.monitoring:9090",
+				Metrics: []v1beta1.MetricSpec{{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: "custom1"},
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: resource.NewQuantity(1, resource.DecimalSI)},
+					},
+				}},
+			}, nil),
+			expectedErr: "metrics can't be combined with triggers when the collector is scaled by KEDA",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cvw := v1beta1.NewCollectorWebhook(
+				logr.Discard(),
+				testScheme,
+				config.New(
+					config.WithCollectorImage("collector:v0.0.0"),
+					config.WithTargetAllocatorImage("ta:v0.0.0"),
+					config.WithKEDAAvailability(test.keda),
+				),
+				getReviewer(false),
+				nil,
+				nil,
+				nil,
+			)
+			warnings, err := cvw.ValidateCreate(context.Background(), test.otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+			if test.expectedWarning != "" {
+				assert.Contains(t, warnings, test.expectedWarning)
+			}
+		})
+	}
+}
+
+func TestOTELColScalingValidation(t *testing.T) {
+	workdays := v1beta1.ScalingSchedule{
+		Name:     "workdays",
+		Start:    "0 8 * * 1-5",
+		Duration: metav1.Duration{Duration: 10 * time.Hour},
+		Replicas: 3,
+	}
+	idle := &v1beta1.IdleScaleDown{After: metav1.Duration{Duration: 30 * time.Minute}}
+	newCollector := func(mode v1beta1.Mode, scaling v1beta1.ScalingSpec, telemetry *v1beta1.AnyConfig) *v1beta1.OpenTelemetryCollector {
+		return &v1beta1.OpenTelemetryCollector{
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:    mode,
+				Scaling: &scaling,
+				Config: v1beta1.Config{
+					Service: v1beta1.Service{Telemetry: telemetry},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		otelcol          *v1beta1.OpenTelemetryCollector
+		expectedErr      string
+		expectedWarnings []string
+	}{
+		{
+			name: "schedules and idle scale down",
+			otelcol: newCollector(v1beta1.ModeDeployment, v1beta1.ScalingSpec{
+				Schedules:     []v1beta1.ScalingSchedule{workdays},
+				TimeZone:      "Europe/Paris",
+				IdleScaleDown: idle,
+			}, nil),
+			expectedWarnings: []string{"spec.scaling has no effect unless the operator.collector.scaling feature gate is enabled"},
+		},
+		{
+			name:        "daemonset",
+			otelcol:     newCollector(v1beta1.ModeDaemonSet, v1beta1.ScalingSpec{IdleScaleDown: idle}, nil),
+			expectedErr: "does not support the attribute 'scaling'",
+		},
+		{
+			name:        "invalid time zone",
+			otelcol:     newCollector(v1beta1.ModeDeployment, v1beta1.ScalingSpec{TimeZone: "Mars/Olympus"}, nil),
+			expectedErr: "invalid timeZone Mars/Olympus",
+		},
+		{
+			name: "invalid start",
+			otelcol: newCollector(v1beta1.ModeStatefulSet, v1beta1.ScalingSpec{Schedules: []v1beta1.ScalingSchedule{
+				{Name: "nightly", Start: "every night", Duration: metav1.Duration{Duration: time.Hour}},
+			}}, nil),
+			expectedErr: "invalid start of the nightly schedule",
+		},
+		{
+			name: "schedule without duration",
+			otelcol: newCollector(v1beta1.ModeDeployment, v1beta1.ScalingSpec{Schedules: []v1beta1.ScalingSchedule{
+				{Name: "nightly", Start: "0 0 * * *"},
+			}}, nil),
+			expectedErr: "the duration of the nightly schedule should be greater than 0",
+		},
+		{
+			name: "duplicate schedule",
+			otelcol: newCollector(v1beta1.ModeDeployment, v1beta1.ScalingSpec{
+				Schedules: []v1beta1.ScalingSchedule{workdays, workdays},
+			}, nil),
+			expectedErr: "the workdays schedule is defined more than once",
+		},
+		{
+			name: "idle scale down without period",
+			otelcol: newCollector(v1beta1.ModeDeployment, v1beta1.ScalingSpec{
+				IdleScaleDown: &v1beta1.IdleScaleDown{},
+			}, nil),
+			expectedErr: "idleScaleDown.after should be greater than 0",
+		},
+		{
+			name: "idle scale down with telemetry metrics disabled",
+			otelcol: newCollector(v1beta1.ModeDeployment, v1beta1.ScalingSpec{IdleScaleDown: idle},
+				&v1beta1.AnyConfig{Object: map[string]interface{}{"metrics": map[string]interface{}{"level": "none"}}}),
+			expectedErr: "the idle scale down reads the collector telemetry metrics",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cvw := v1beta1.NewCollectorWebhook(
+				logr.Discard(),
+				testScheme,
+				config.New(
+					config.WithCollectorImage("collector:v0.0.0"),
+					config.WithTargetAllocatorImage("ta:v0.0.0"),
+				),
+				getReviewer(false),
+				nil,
+				nil,
+				nil,
+			)
+			warnings, err := cvw.ValidateCreate(context.Background(), test.otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, test.expectedWarnings, warnings)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOTELColRolloutValidation(t *testing.T) {
+	newCollector := func(mode v1beta1.Mode, rollout v1beta1.RolloutSpec, telemetry *v1beta1.AnyConfig) *v1beta1.OpenTelemetryCollector {
+		return &v1beta1.OpenTelemetryCollector{
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:    mode,
+				Rollout: &rollout,
+				Config: v1beta1.Config{
+					Service: v1beta1.Service{Telemetry: telemetry},
+				},
+			},
+		}
+	}
+	metricsDisabled := &v1beta1.AnyConfig{Object: map[string]interface{}{"metrics": map[string]interface{}{"level": "none"}}}
+
+	tests := []struct {
+		name            string
+		otelcol         *v1beta1.OpenTelemetryCollector
+		expectedErr     string
+		expectedWarning string
+	}{
+		{
+			name: "canary",
+			otelcol: newCollector(v1beta1.ModeDeployment, v1beta1.RolloutSpec{
+				Strategy:       v1beta1.RolloutStrategyCanary,
+				CanaryReplicas: 1,
+				Analysis:       v1beta1.RolloutAnalysis{Duration: metav1.Duration{Duration: 5 * time.Minute}, MaxExportFailurePercentage: 5},
+			}, nil),
+			expectedWarning: "spec.rollout has no effect unless the operator.collector.rollout feature gate is enabled",
+		},
+		{
+			name:        "statefulset",
+			otelcol:     newCollector(v1beta1.ModeStatefulSet, v1beta1.RolloutSpec{Strategy: v1beta1.RolloutStrategyBlueGreen}, nil),
+			expectedErr: "does not support the attribute 'rollout'",
+		},
+		{
+			name: "negative analysis duration",
+			otelcol: newCollector(v1beta1.ModeDeployment, v1beta1.RolloutSpec{
+				Strategy: v1beta1.RolloutStrategyCanary,
+				Analysis: v1beta1.RolloutAnalysis{Duration: metav1.Duration{Duration: -time.Minute}},
+			}, nil),
+			expectedErr: "analysis.duration should not be negative",
+		},
+		{
+			name: "export failures without telemetry metrics",
+			otelcol: newCollector(v1beta1.ModeDeployment, v1beta1.RolloutSpec{
+				Strategy: v1beta1.RolloutStrategyCanary,
+				Analysis: v1beta1.RolloutAnalysis{MaxExportFailurePercentage: 5},
+			}, metricsDisabled),
+			expectedWarning: "the export failures of the rolled out configurations can't be analyzed",
+		},
+		{
+			name: "export failures not analyzed",
+			otelcol: newCollector(v1beta1.ModeDeployment, v1beta1.RolloutSpec{
+				Strategy: v1beta1.RolloutStrategyCanary,
+				Analysis: v1beta1.RolloutAnalysis{MaxExportFailurePercentage: 100},
+			}, metricsDisabled),
+			expectedWarning: "spec.rollout has no effect unless the operator.collector.rollout feature gate is enabled",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cvw := v1beta1.NewCollectorWebhook(
+				logr.Discard(),
+				testScheme,
+				config.New(
+					config.WithCollectorImage("collector:v0.0.0"),
+					config.WithTargetAllocatorImage("ta:v0.0.0"),
+				),
+				getReviewer(false),
+				nil,
+				nil,
+				nil,
+			)
+			warnings, err := cvw.ValidateCreate(context.Background(), test.otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+			if test.expectedWarning != "" {
+				assert.Contains(t, strings.Join(warnings, "\n"), test.expectedWarning)
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func TestOTELColNodePoolsValidation(t *testing.T) {
+	newCollector := func(mode v1beta1.Mode, pools ...v1beta1.NodePool) *v1beta1.OpenTelemetryCollector {
+		return &v1beta1.OpenTelemetryCollector{
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:      mode,
+				NodePools: pools,
+				Config: v1beta1.Config{
+					Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{}}},
+					Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": map[string]interface{}{}}},
+					Service: v1beta1.Service{
+						Pipelines: map[string]*v1beta1.Pipeline{
+							"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+						},
+					},
+				},
+			},
+		}
+	}
+	gpu := v1beta1.NodePool{Name: "gpu", NodeSelector: map[string]string{"accelerator": "nvidia"}}
+	withConfig := func(pool v1beta1.NodePool, config map[string]interface{}) v1beta1.NodePool {
+		pool.Config = &v1beta1.AnyConfig{Object: config}
+		return pool
+	}
+
+	tests := []struct {
+		name            string
+		otelcol         *v1beta1.OpenTelemetryCollector
+		expectedErr     string
+		expectedWarning string
+	}{
+		{
+			name: "patched config",
+			otelcol: newCollector(v1beta1.ModeDaemonSet, withConfig(gpu, map[string]interface{}{
+				"processors": map[string]interface{}{"batch": map[string]interface{}{}},
+				"service": map[string]interface{}{
+					"pipelines": map[string]interface{}{
+						"traces": map[string]interface{}{"processors": []interface{}{"batch"}},
+					},
+				},
+			})),
+		},
+		{
+			name:        "deployment",
+			otelcol:     newCollector(v1beta1.ModeDeployment, gpu),
+			expectedErr: "does not support the attribute 'nodePools'",
+		},
+		{
+			name: "undefined component",
+			otelcol: newCollector(v1beta1.ModeDaemonSet, withConfig(gpu, map[string]interface{}{
+				"service": map[string]interface{}{
+					"pipelines": map[string]interface{}{
+						"traces": map[string]interface{}{"exporters": []interface{}{"otlp"}},
+					},
+				},
+			})),
+			expectedErr: "the traces pipeline of the gpu node pool uses undefined exporters: otlp",
+		},
+		{
+			name: "invalid config",
+			otelcol: newCollector(v1beta1.ModeDaemonSet, withConfig(gpu, map[string]interface{}{
+				"service": map[string]interface{}{"pipelines": "traces"},
+			})),
+			expectedErr: "the config of the gpu node pool can't be applied",
+		},
+		{
+			name: "pool without nodes",
+			otelcol: newCollector(v1beta1.ModeDaemonSet, gpu, v1beta1.NodePool{
+				Name:         "gpu-edge",
+				NodeSelector: map[string]string{"accelerator": "nvidia", "node-role": "edge"},
+			}),
+			expectedWarning: "the gpu-edge node pool doesn't run on any node, all its nodes belong to the gpu node pool",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cvw := v1beta1.NewCollectorWebhook(
+				logr.Discard(),
+				testScheme,
+				config.New(
+					config.WithCollectorImage("collector:v0.0.0"),
+					config.WithTargetAllocatorImage("ta:v0.0.0"),
+				),
+				getReviewer(false),
+				nil,
+				nil,
+				nil,
+			)
+			warnings, err := cvw.ValidateCreate(context.Background(), test.otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+			if test.expectedWarning != "" {
+				assert.Contains(t, strings.Join(warnings, "\n"), test.expectedWarning)
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func TestOTELColPersistenceValidation(t *testing.T) {
+	newCollector := func(mode v1beta1.Mode, persistence v1beta1.PersistenceSpec) *v1beta1.OpenTelemetryCollector {
+		return &v1beta1.OpenTelemetryCollector{
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:        mode,
+				Persistence: &persistence,
+			},
+		}
+	}
+	asUser := func(otelcol *v1beta1.OpenTelemetryCollector, user int64) *v1beta1.OpenTelemetryCollector {
+		otelcol.Spec.PodSecurityContext = &v1.PodSecurityContext{RunAsUser: &user}
+		return otelcol
+	}
+	withClaim := func(otelcol *v1beta1.OpenTelemetryCollector, name string) *v1beta1.OpenTelemetryCollector {
+		otelcol.Spec.VolumeClaimTemplates = []v1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: name}}}
+		return otelcol
+	}
+
+	tests := []struct {
+		name        string
+		otelcol     *v1beta1.OpenTelemetryCollector
+		expectedErr string
+	}{
+		{
+			name:    "statefulset",
+			otelcol: withClaim(newCollector(v1beta1.ModeStatefulSet, v1beta1.PersistenceSpec{Size: resource.MustParse("1Gi")}), "data"),
+		},
+		{
+			name:    "daemonset on the nodes",
+			otelcol: asUser(newCollector(v1beta1.ModeDaemonSet, v1beta1.PersistenceSpec{HostPath: "/var/lib/otelcol"}), 0),
+		},
+		{
+			name:        "daemonset on the nodes without root",
+			otelcol:     newCollector(v1beta1.ModeDaemonSet, v1beta1.PersistenceSpec{HostPath: "/var/lib/otelcol"}),
+			expectedErr: "the collector must run as root with runAsUser: 0",
+		},
+		{
+			name: "daemonset on the nodes with the container not running as root",
+			otelcol: func() *v1beta1.OpenTelemetryCollector {
+				otelcol := asUser(newCollector(v1beta1.ModeDaemonSet, v1beta1.PersistenceSpec{HostPath: "/var/lib/otelcol"}), 0)
+				collectorUser := int64(10001)
+				otelcol.Spec.SecurityContext = &v1.SecurityContext{RunAsUser: &collectorUser}
+				return otelcol
+			}(),
+			expectedErr: "the collector must run as root with runAsUser: 0",
+		},
+		{
+			name:        "deployment",
+			otelcol:     newCollector(v1beta1.ModeDeployment, v1beta1.PersistenceSpec{}),
+			expectedErr: "does not support the attribute 'persistence'",
+		},
+		{
+			name:        "relative directory",
+			otelcol:     newCollector(v1beta1.ModeStatefulSet, v1beta1.PersistenceSpec{Directory: "queues"}),
+			expectedErr: "directory should be an absolute path",
+		},
+		{
+			name:        "relative host path",
+			otelcol:     asUser(newCollector(v1beta1.ModeDaemonSet, v1beta1.PersistenceSpec{HostPath: "otelcol"}), 0),
+			expectedErr: "hostPath should be an absolute path",
+		},
+		{
+			name:        "negative size",
+			otelcol:     newCollector(v1beta1.ModeStatefulSet, v1beta1.PersistenceSpec{Size: resource.MustParse("-1Gi")}),
+			expectedErr: "size should not be negative",
+		},
+		{
+			name:        "reserved volume claim",
+			otelcol:     withClaim(newCollector(v1beta1.ModeStatefulSet, v1beta1.PersistenceSpec{}), "otc-persistence"),
+			expectedErr: "the otc-persistence volume is reserved for the sending queues",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cvw := v1beta1.NewCollectorWebhook(
+				logr.Discard(),
+				testScheme,
+				config.New(
+					config.WithCollectorImage("collector:v0.0.0"),
+					config.WithTargetAllocatorImage("ta:v0.0.0"),
+				),
+				getReviewer(false),
+				nil,
+				nil,
+				nil,
+			)
+			_, err := cvw.ValidateCreate(context.Background(), test.otelcol)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -66,6 +67,25 @@ type AutoscalerSpec struct {
 	// +optional
 	// TargetMemoryUtilization sets the target average memory utilization across all replicas
 	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+	// Triggers scale the collector on the metrics of its own telemetry, e.g. otelcol_exporter_queue_size.
+	// When the KEDA CRDs are available, a KEDA ScaledObject querying PrometheusServerAddress is created
+	// instead of the HPA. Otherwise, the HPA gets the triggers as External metrics, which have to be served
+	// by a metrics adapter.
+	// +optional
+	Triggers []AutoscalerTrigger `json:"triggers,omitempty"`
+	// PrometheusServerAddress is the address of the Prometheus server scraping the telemetry of the collector,
+	// queried by KEDA for the triggers. It's required when the KEDA CRDs are available.
+	// +optional
+	PrometheusServerAddress string `json:"prometheusServerAddress,omitempty"`
+}
+
+// AutoscalerTrigger scales the collector on a metric of its own telemetry, as exposed by its monitoring service.
+type AutoscalerTrigger struct {
+	// Metric is the name of the telemetry metric, e.g. otelcol_exporter_queue_size.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_:][a-zA-Z0-9_:]*$`
+	Metric string `json:"metric"`
+	// TargetAverageValue is the value of the metric per replica the collector is scaled to.
+	TargetAverageValue resource.Quantity `json:"targetAverageValue"`
 }
 
 // PodDisruptionBudgetSpec defines the OpenTelemetryCollector's pod disruption budget specification.
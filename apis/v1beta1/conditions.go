@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+// Condition types reported in the status of the resources managed by the operator.
+const (
+	// ConditionTypeReady indicates that all the pods of the workload are updated and ready.
+	ConditionTypeReady = "Ready"
+
+	// ConditionTypeProgressing indicates that the workload is rolling out a new revision.
+	ConditionTypeProgressing = "Progressing"
+
+	// ConditionTypeDegraded indicates that the last reconciliation failed or the rollout is stuck.
+	ConditionTypeDegraded = "Degraded"
+
+	// ConditionTypeConfigValid indicates whether the collector configuration could be turned into manifests.
+	ConditionTypeConfigValid = "ConfigValid"
+
+	// ConditionTypeTargetAllocatorReady indicates that the target allocator of the collector is updated and ready.
+	ConditionTypeTargetAllocatorReady = "TargetAllocatorReady"
+)
+
+// Condition reasons reported in the status of the resources managed by the operator.
+const (
+	// ConditionReasonRolloutComplete means that all the pods of the workload are updated and ready.
+	ConditionReasonRolloutComplete = "RolloutComplete"
+
+	// ConditionReasonRolloutInProgress means that the workload is still rolling out its latest revision.
+	ConditionReasonRolloutInProgress = "RolloutInProgress"
+
+	// ConditionReasonProgressDeadlineExceeded means that the workload didn't finish its rollout in time.
+	ConditionReasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+	// ConditionReasonReconcileError means that the operator failed to reconcile the resource.
+	ConditionReasonReconcileError = "ReconcileError"
+
+	// ConditionReasonReconcileSucceeded means that the operator reconciled the resource successfully.
+	ConditionReasonReconcileSucceeded = "ReconcileSucceeded"
+
+	// ConditionReasonSidecar means that the collector runs as a sidecar and has no workload of its own.
+	ConditionReasonSidecar = "Sidecar"
+
+	// ConditionReasonInvalidConfig means that the collector configuration couldn't be turned into manifests.
+	ConditionReasonInvalidConfig = "InvalidConfig"
+
+	// ConditionReasonConfigAccepted means that the collector configuration was turned into manifests.
+	ConditionReasonConfigAccepted = "ConfigAccepted"
+)
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
 	"regexp"
 	"sort"
@@ -30,6 +31,7 @@ import (
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 	"github.com/open-telemetry/opentelemetry-operator/internal/components/exporters"
@@ -38,6 +40,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/components/receivers"
 )
 
+const (
+	healthCheckV1Type = "health_check"
+	healthCheckV2Type = "healthcheckv2"
+)
+
 type ComponentKind int
 
 const (
@@ -380,6 +387,102 @@ func (c *Config) GetReadinessProbe(logger logr.Logger) (*corev1.Probe, error) {
 	return nil, nil
 }
 
+// ProbesSupportPipeline returns whether the probes of the enabled extensions can be narrowed down to the health of a
+// single pipeline, i.e. when every extension generating a probe is a healthcheck v2 extension outside of its legacy mode.
+func (c *Config) ProbesSupportPipeline(logger logr.Logger) bool {
+	supported := false
+	for componentName := range c.GetEnabledComponents()[KindExtension] {
+		parser := extensions.ParserFor(componentName)
+		if probe, err := parser.GetLivenessProbe(logger, c.Extensions.Object[componentName]); err != nil || probe == nil {
+			continue
+		}
+		if !extensions.SupportsProbePipeline(componentName, c.Extensions.Object[componentName]) {
+			return false
+		}
+		supported = true
+	}
+	return supported
+}
+
+// GetFallbackProbe returns a TCP probe on the port of an enabled OTLP receiver, for collectors without a health
+// extension. Only protocols with an explicit endpoint outside the loopback interface are considered, the kubelet
+// can't reach the others.
+func (c *Config) GetFallbackProbe() *corev1.Probe {
+	var names []string
+	for name := range c.GetEnabledComponents()[KindReceiver] {
+		if components.ComponentType(name) == "otlp" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		receiver, ok := c.Receivers.Object[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		protocols, ok := receiver["protocols"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, protocol := range []string{"grpc", "http"} {
+			settings, ok := protocols[protocol].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			endpoint, ok := settings["endpoint"].(string)
+			if !ok || isLoopbackEndpoint(endpoint) {
+				continue
+			}
+			port, err := components.PortFromEndpoint(endpoint)
+			if err != nil {
+				continue
+			}
+			return &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					TCPSocket: &corev1.TCPSocketAction{
+						Port: intstr.FromInt32(port),
+					},
+				},
+			}
+		}
+	}
+	return nil
+}
+
+func isLoopbackEndpoint(endpoint string) bool {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// InjectHealthCheck enables a health_check extension listening on all interfaces, unless a health extension is
+// enabled already. An existing health_check extension that isn't enabled is enabled as is.
+func (c *Config) InjectHealthCheck() {
+	for name := range c.GetEnabledComponents()[KindExtension] {
+		if t := components.ComponentType(name); t == healthCheckV1Type || t == healthCheckV2Type {
+			return
+		}
+	}
+	if c.Extensions == nil {
+		c.Extensions = &AnyConfig{}
+	}
+	if c.Extensions.Object == nil {
+		c.Extensions.Object = map[string]interface{}{}
+	}
+	if _, ok := c.Extensions.Object[healthCheckV1Type]; !ok {
+		c.Extensions.Object[healthCheckV1Type] = map[string]interface{}{
+			"endpoint": fmt.Sprintf("0.0.0.0:%d", extensions.DefaultHealthcheckV1Port),
+		}
+	}
+	c.Service.Extensions = append(c.Service.Extensions, healthCheckV1Type)
+}
+
 // Yaml encodes the current object and returns it as a string.
 func (c *Config) Yaml() (string, error) {
 	var buf bytes.Buffer
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ConfigFragment is a partial collector configuration that gets merged into a Config.
+// +kubebuilder:object:generate=false
+type ConfigFragment struct {
+	// Source identifies where the fragment comes from, it is used in conflict messages.
+	Source string
+	// Object is the parsed content of the fragment.
+	Object map[string]interface{}
+}
+
+// MergeFragments deep-merges the given fragments into the config. Maps are merged recursively, any other value
+// (including lists) is treated as atomic. Fragments are merged in order, and values already set in the config
+// always take precedence over the fragments. Two fragments setting different values for the same key is
+// reported as a conflict.
+func (c *Config) MergeFragments(fragments ...ConfigFragment) error {
+	if len(fragments) == 0 {
+		return nil
+	}
+
+	merged := map[string]interface{}{}
+	owners := map[string]string{}
+	for _, fragment := range fragments {
+		if err := mergeFragmentInto(merged, fragment.Object, "", fragment.Source, owners); err != nil {
+			return err
+		}
+	}
+
+	cfgBytes, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	base := map[string]interface{}{}
+	if err = json.Unmarshal(cfgBytes, &base); err != nil {
+		return err
+	}
+	overrideWith(merged, base)
+
+	mergedBytes, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+	result := Config{}
+	if err = json.Unmarshal(mergedBytes, &result); err != nil {
+		return fmt.Errorf("the merged collector configuration is invalid: %w", err)
+	}
+	*c = result
+	return nil
+}
+
+// mergeFragmentInto merges src into dst, recording which source set each leaf value to detect conflicts.
+func mergeFragmentInto(dst, src map[string]interface{}, path, source string, owners map[string]string) error {
+	keys := make([]string, 0, len(src))
+	for k := range src {
+		keys = append(keys, k)
+	}
+	// Make the conflict reporting deterministic.
+	sort.Strings(keys)
+	for _, k := range keys {
+		keyPath := strings.TrimPrefix(path+"."+k, ".")
+		srcVal := src[k]
+		dstVal, exists := dst[k]
+		if !exists || dstVal == nil {
+			dst[k] = srcVal
+			owners[keyPath] = source
+			continue
+		}
+		if srcVal == nil {
+			continue
+		}
+		srcMap, srcIsMap := srcVal.(map[string]interface{})
+		dstMap, dstIsMap := dstVal.(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			if err := mergeFragmentInto(dstMap, srcMap, keyPath, source, owners); err != nil {
+				return err
+			}
+			continue
+		}
+		if !reflect.DeepEqual(srcVal, dstVal) {
+			return fmt.Errorf("config fragment %s conflicts with config fragment %s at %s", source, ownerOf(owners, keyPath), keyPath)
+		}
+	}
+	return nil
+}
+
+// ownerOf returns the source that set the value at the given path, or at the closest parent path.
+func ownerOf(owners map[string]string, path string) string {
+	for {
+		if owner, ok := owners[path]; ok {
+			return owner
+		}
+		i := strings.LastIndex(path, ".")
+		if i < 0 {
+			return "<unknown>"
+		}
+		path = path[:i]
+	}
+}
+
+// overrideWith merges src into dst, values in src take precedence. Null values in src are considered unset.
+func overrideWith(dst, src map[string]interface{}) {
+	for k, srcVal := range src {
+		if srcVal == nil {
+			if _, exists := dst[k]; !exists {
+				dst[k] = nil
+			}
+			continue
+		}
+		srcMap, srcIsMap := srcVal.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			overrideWith(dstMap, srcMap)
+			continue
+		}
+		dst[k] = srcVal
+	}
+}
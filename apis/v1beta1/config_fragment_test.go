@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	go_yaml "gopkg.in/yaml.v3"
+)
+
+func parseFragment(t *testing.T, source, content string) ConfigFragment {
+	object := map[string]interface{}{}
+	require.NoError(t, go_yaml.Unmarshal([]byte(content), &object))
+	return ConfigFragment{Source: source, Object: object}
+}
+
+func TestMergeFragments(t *testing.T) {
+	const appConfig = `
+receivers:
+  otlp:
+    protocols:
+      grpc: {}
+exporters: {}
+processors:
+  batch:
+    timeout: 5s
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+`
+	processors := `
+processors:
+  memory_limiter:
+    check_interval: 1s
+  batch:
+    timeout: 1s
+    send_batch_size: 1000
+service:
+  pipelines:
+    traces:
+      processors: [memory_limiter, batch]
+`
+	exporters := `
+exporters:
+  otlp/corporate:
+    endpoint: gateway.observability:4317
+extensions:
+  health_check: {}
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      exporters: [otlp/corporate]
+`
+
+	t.Run("fragments are merged underneath the config", func(t *testing.T) {
+		cfg := Config{}
+		require.NoError(t, go_yaml.Unmarshal([]byte(appConfig), &cfg))
+
+		err := cfg.MergeFragments(
+			parseFragment(t, "observability/processors", processors),
+			parseFragment(t, "observability/exporters", exporters),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{"timeout": "5s", "send_batch_size": float64(1000)}, cfg.Processors.Object["batch"])
+		assert.Contains(t, cfg.Processors.Object, "memory_limiter")
+		assert.Contains(t, cfg.Receivers.Object, "otlp")
+		assert.Contains(t, cfg.Exporters.Object, "otlp/corporate")
+		assert.Contains(t, cfg.Extensions.Object, "health_check")
+		assert.Equal(t, []string{"health_check"}, cfg.Service.Extensions)
+		assert.Equal(t, &Pipeline{
+			Receivers:  []string{"otlp"},
+			Processors: []string{"memory_limiter", "batch"},
+			Exporters:  []string{"otlp/corporate"},
+		}, cfg.Service.Pipelines["traces"])
+	})
+
+	t.Run("config takes precedence over fragments", func(t *testing.T) {
+		cfg := Config{}
+		require.NoError(t, go_yaml.Unmarshal([]byte(`
+receivers:
+  otlp: {}
+exporters: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+`), &cfg))
+
+		err := cfg.MergeFragments(parseFragment(t, "observability/processors", processors))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"batch"}, cfg.Service.Pipelines["traces"].Processors)
+	})
+
+	t.Run("conflicting fragments", func(t *testing.T) {
+		cfg := Config{}
+		require.NoError(t, go_yaml.Unmarshal([]byte(appConfig), &cfg))
+
+		err := cfg.MergeFragments(
+			parseFragment(t, "observability/processors", processors),
+			parseFragment(t, "team/processors", `
+processors:
+  batch:
+    send_batch_size: 10
+`),
+		)
+		assert.EqualError(t, err, "config fragment team/processors conflicts with config fragment observability/processors at processors.batch.send_batch_size")
+	})
+
+	t.Run("identical values in fragments don't conflict", func(t *testing.T) {
+		cfg := Config{}
+		require.NoError(t, go_yaml.Unmarshal([]byte(appConfig), &cfg))
+
+		err := cfg.MergeFragments(
+			parseFragment(t, "observability/exporters", exporters),
+			parseFragment(t, "team/extensions", `
+extensions:
+  health_check: {}
+service:
+  extensions: [health_check]
+`),
+		)
+		assert.NoError(t, err)
+	})
+
+	t.Run("no fragments", func(t *testing.T) {
+		cfg := Config{}
+		require.NoError(t, go_yaml.Unmarshal([]byte(appConfig), &cfg))
+		expected := cfg.DeepCopy()
+
+		require.NoError(t, cfg.MergeFragments())
+		assert.Equal(t, expected, &cfg)
+	})
+}
@@ -753,3 +753,185 @@ func TestConfig_GetExporterPorts(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_GetFallbackProbe(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   *v1.Probe
+	}{
+		{
+			name: "otlp grpc endpoint",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+      http:
+        endpoint: 0.0.0.0:4318
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+			want: &v1.Probe{
+				ProbeHandler: v1.ProbeHandler{
+					TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt32(4317)},
+				},
+			},
+		},
+		{
+			name: "otlp http endpoint only",
+			config: `receivers:
+  otlp/http:
+    protocols:
+      http:
+        endpoint: 0.0.0.0:14318
+service:
+  pipelines:
+    traces:
+      receivers: [otlp/http]
+      exporters: [debug]
+`,
+			want: &v1.Probe{
+				ProbeHandler: v1.ProbeHandler{
+					TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt32(14318)},
+				},
+			},
+		},
+		{
+			name: "loopback endpoint",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: localhost:4317
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+		},
+		{
+			name: "receiver not in a pipeline",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{}
+			require.NoError(t, go_yaml.Unmarshal([]byte(tt.config), c))
+			assert.Equal(t, tt.want, c.GetFallbackProbe())
+		})
+	}
+}
+
+func TestConfig_InjectHealthCheck(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         string
+		wantExtensions []string
+		wantEndpoint   interface{}
+	}{
+		{
+			name: "no extensions",
+			config: `service:
+  pipelines: {}
+`,
+			wantExtensions: []string{"health_check"},
+			wantEndpoint:   "0.0.0.0:13133",
+		},
+		{
+			name: "health_check defined but not enabled",
+			config: `extensions:
+  health_check:
+    endpoint: 0.0.0.0:8080
+service:
+  extensions: [pprof]
+`,
+			wantExtensions: []string{"pprof", "health_check"},
+			wantEndpoint:   "0.0.0.0:8080",
+		},
+		{
+			name: "healthcheckv2 enabled",
+			config: `extensions:
+  healthcheckv2: {}
+service:
+  extensions: [healthcheckv2]
+`,
+			wantExtensions: []string{"healthcheckv2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{}
+			require.NoError(t, go_yaml.Unmarshal([]byte(tt.config), c))
+			c.InjectHealthCheck()
+			assert.Equal(t, tt.wantExtensions, c.Service.Extensions)
+			if tt.wantEndpoint != nil {
+				healthCheck, ok := c.Extensions.Object["health_check"].(map[string]interface{})
+				require.True(t, ok)
+				assert.Equal(t, tt.wantEndpoint, healthCheck["endpoint"])
+			}
+		})
+	}
+}
+
+func TestConfig_InjectFileStorage(t *testing.T) {
+	c := &Config{}
+	require.NoError(t, go_yaml.Unmarshal([]byte(`exporters:
+  otlp:
+    endpoint: gateway:4317
+  otlphttp:
+  otlp/unqueued:
+    sending_queue:
+      enabled: false
+  otlp/stored:
+    sending_queue:
+      storage: file_storage/other
+  debug: {}
+  prometheusremotewrite:
+    endpoint: http://prometheus:9090/api/v1/write
+  loadbalancing/queued:
+    sending_queue:
+      num_consumers: 2
+service:
+  extensions: [pprof]
+`), c))
+
+	c.InjectFileStorage("/var/lib/queues")
+	// injecting it again doesn't enable the extension twice
+	c.InjectFileStorage("/var/lib/queues")
+
+	assert.Equal(t, []string{"pprof", "file_storage/persistence"}, c.Service.Extensions)
+	assert.Equal(t, map[string]interface{}{"directory": "/var/lib/queues", "create_directory": true}, c.Extensions.Object["file_storage/persistence"])
+	assert.Equal(t, map[string]interface{}{
+		"otlp": map[string]interface{}{
+			"endpoint":      "gateway:4317",
+			"sending_queue": map[string]interface{}{"storage": "file_storage/persistence"},
+		},
+		"otlphttp": map[string]interface{}{
+			"sending_queue": map[string]interface{}{"storage": "file_storage/persistence"},
+		},
+		"otlp/unqueued": map[string]interface{}{
+			"sending_queue": map[string]interface{}{"enabled": false},
+		},
+		"otlp/stored": map[string]interface{}{
+			"sending_queue": map[string]interface{}{"storage": "file_storage/other"},
+		},
+		"debug": map[string]interface{}{},
+		"prometheusremotewrite": map[string]interface{}{
+			"endpoint": "http://prometheus:9090/api/v1/write",
+		},
+		"loadbalancing/queued": map[string]interface{}{
+			"sending_queue": map[string]interface{}{"num_consumers": 2, "storage": "file_storage/persistence"},
+		},
+	}, c.Exporters.Object)
+}
@@ -17,8 +17,8 @@ package v1beta1
 import networkingv1 "k8s.io/api/networking/v1"
 
 type (
-	// IngressType represents how a collector should be exposed (ingress vs route).
-	// +kubebuilder:validation:Enum=ingress;route
+	// IngressType represents how a collector should be exposed (ingress vs route vs gateway).
+	// +kubebuilder:validation:Enum=ingress;route;gateway
 	IngressType string
 )
 
@@ -27,6 +27,8 @@ const (
 	IngressTypeIngress IngressType = "ingress"
 	// IngressTypeRoute IngressTypeOpenshiftRoute specifies that an route should be created.
 	IngressTypeRoute IngressType = "route"
+	// IngressTypeGateway specifies that Gateway API HTTPRoutes and GRPCRoutes should be created.
+	IngressTypeGateway IngressType = "gateway"
 )
 
 type (
@@ -76,7 +78,7 @@ const (
 // SEE: OpenTelemetryCollector.spec.ports[index].
 type Ingress struct {
 	// Type default value is: ""
-	// Supported types are: ingress, route
+	// Supported types are: ingress, route, gateway
 	Type IngressType `json:"type,omitempty"`
 
 	// RuleType defines how Ingress exposes collector receivers.
@@ -108,6 +110,11 @@ type Ingress struct {
 	// type "route" is used.
 	// +optional
 	Route OpenShiftRoute `json:"route,omitempty"`
+
+	// Gateway is a Gateway API specific section that is only considered when
+	// type "gateway" is used.
+	// +optional
+	Gateway GatewayRoutes `json:"gateway,omitempty"`
 }
 
 // OpenShiftRoute defines openshift route specific settings.
@@ -115,3 +122,26 @@ type OpenShiftRoute struct {
 	// Termination indicates termination type. By default "edge" is used.
 	Termination TLSRouteTerminationType `json:"termination,omitempty"`
 }
+
+// GatewayRoutes defines the Gateway API specific settings. A GRPCRoute is created for each
+// gRPC receiver port and an HTTPRoute for each other receiver port. The gRPC receivers require
+// the subdomain rule type.
+type GatewayRoutes struct {
+	// ParentRef is the Gateway the routes are attached to.
+	ParentRef GatewayParentReference `json:"parentRef,omitempty"`
+}
+
+// GatewayParentReference identifies the Gateway, and optionally the listener of the Gateway, the routes are attached to.
+type GatewayParentReference struct {
+	// Name of the Gateway.
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the Gateway. By default, the namespace of the collector is used.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the Gateway listener the routes are attached to.
+	// By default, the routes are attached to all the listeners of the Gateway.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
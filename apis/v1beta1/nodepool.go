@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NodePool defines the collector of a pool of nodes, which differs from the collector of the other nodes.
+type NodePool struct {
+	// Name of the pool, appended to the names of its DaemonSet and ConfigMap.
+	// +required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=20
+	Name string `json:"name"`
+	// NodeSelector selects the nodes of the pool. A node selected by several pools belongs to the first one.
+	// +required
+	// +kubebuilder:validation:MinProperties=1
+	NodeSelector map[string]string `json:"nodeSelector"`
+	// Config is merged into the collector configuration on the nodes of the pool. Maps are merged recursively, the
+	// values of the pool take precedence and any other value, including lists, is replaced.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config *AnyConfig `json:"config,omitempty"`
+	// Resources of the collector container on the nodes of the pool, instead of the resources of the collector.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// NodePoolStatus reports the collector of a pool of nodes.
+type NodePoolStatus struct {
+	// Name of the pool.
+	Name string `json:"name"`
+	// ConfigMap holding the configuration of the collector on the nodes of the pool.
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+	// DesiredNumberScheduled is the number of nodes of the pool that should run the collector.
+	// +optional
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled,omitempty"`
+	// NumberReady is the number of nodes of the pool running a ready collector.
+	// +optional
+	NumberReady int32 `json:"numberReady,omitempty"`
+	// UpdatedNumberScheduled is the number of nodes of the pool running the latest collector.
+	// +optional
+	UpdatedNumberScheduled int32 `json:"updatedNumberScheduled,omitempty"`
+}
+
+// ApplyPatch merges the given patch into the config. Maps are merged recursively, the values of the patch take
+// precedence and any other value, including lists, is replaced.
+func (c *Config) ApplyPatch(patch map[string]interface{}) error {
+	if len(patch) == 0 {
+		return nil
+	}
+	cfgBytes, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	merged := map[string]interface{}{}
+	if err = json.Unmarshal(cfgBytes, &merged); err != nil {
+		return err
+	}
+	overrideWith(merged, patch)
+
+	mergedBytes, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+	result := Config{}
+	if err = json.Unmarshal(mergedBytes, &result); err != nil {
+		return fmt.Errorf("the patched collector configuration is invalid: %w", err)
+	}
+	*c = result
+	return nil
+}
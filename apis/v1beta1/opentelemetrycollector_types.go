@@ -74,6 +74,69 @@ type OpenTelemetryCollectorStatus struct {
 	// Image indicates the container image to use for the OpenTelemetry Collector.
 	// +optional
 	Image string `json:"image,omitempty"`
+
+	// ConfigFragments lists the configuration fragments that were merged into the collector
+	// configuration during the last reconciliation.
+	// +optional
+	// +listType=atomic
+	ConfigFragments []ConfigFragmentStatus `json:"configFragments,omitempty"`
+
+	// ConfigHash is the hash of the effective collector configuration, after config fragments were merged.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// ConfigMap is the name of the generated ConfigMap holding the effective collector configuration.
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+
+	// Pipelines lists the pipelines of the effective collector configuration, sorted by name.
+	// +optional
+	// +listType=atomic
+	Pipelines []PipelineStatus `json:"pipelines,omitempty"`
+
+	// Ports lists the ports exposed by the collector container.
+	// +optional
+	// +listType=atomic
+	Ports []PortStatus `json:"ports,omitempty"`
+
+	// UnknownComponents lists the enabled components that are neither part of the core and contrib collector
+	// distributions nor known to the operator, which usually means a typo or a component of a custom distribution.
+	// Ports, RBAC rules and probes are not derived from the configuration of these components.
+	// +optional
+	// +listType=atomic
+	UnknownComponents []ComponentStatus `json:"unknownComponents,omitempty"`
+
+	// Sidecars reports the pods a sidecar collector is injected into. It's only set when the sidecars read their
+	// configuration from a ConfigMap, with the operator.sidecarcollector.configmap feature gate.
+	// +optional
+	Sidecars *SidecarsStatus `json:"sidecars,omitempty"`
+
+	// SidecarResources lists the resources recommended for the sidecar collector of each workload it is injected
+	// into, from their observed usage. It's only set when spec.sidecarResources is, with the
+	// operator.sidecarcollector.resourcerecommendations feature gate.
+	// +optional
+	// +listType=atomic
+	SidecarResources []SidecarResourcesRecommendation `json:"sidecarResources,omitempty"`
+
+	// Scaling reports the replicas the collector is scaled to by spec.scaling, and why.
+	// +optional
+	Scaling *ScalingStatus `json:"scaling,omitempty"`
+
+	// Rollout reports the progress of the rollout of the collector configuration with spec.rollout.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// NodePools reports the collector of each pool of nodes of spec.nodePools.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	NodePools []NodePoolStatus `json:"nodePools,omitempty"`
+
+	// Conditions represent the latest available observations of the resource's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // OpenTelemetryCollectorSpec defines the desired state of OpenTelemetryCollector.
@@ -86,6 +149,28 @@ type OpenTelemetryCollectorSpec struct {
 	// for the workload.
 	// +optional
 	Autoscaler *AutoscalerSpec `json:"autoscaler,omitempty"`
+	// Scaling scales the collector on a schedule, and down to zero replicas when it's idle. It overrides the
+	// replicas and the autoscaler bounds while a schedule window is active or the collector is idle.
+	// This is only applicable to Deployment and StatefulSet modes.
+	// +optional
+	Scaling *ScalingSpec `json:"scaling,omitempty"`
+	// Rollout rolls out the configuration changes progressively, to a canary or a blue/green Deployment analyzed
+	// before being promoted or rolled back automatically.
+	// This is only applicable to Deployment mode.
+	// +optional
+	Rollout *RolloutSpec `json:"rollout,omitempty"`
+	// NodePools run a different collector on pools of nodes, with its own configuration and resources, in a
+	// DaemonSet per pool. The collector DaemonSet runs on the nodes of no pool.
+	// This is only applicable to DaemonSet mode.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	NodePools []NodePool `json:"nodePools,omitempty"`
+	// Persistence stores the sending queues of the exporters in a volume with the file_storage extension, so that
+	// the queued data survives the restarts of the collector.
+	// This is only applicable to StatefulSet and DaemonSet mode.
+	// +optional
+	Persistence *PersistenceSpec `json:"persistence,omitempty"`
 	// TargetAllocator indicates a value which determines whether to spawn a target allocation resource or not.
 	// +optional
 	TargetAllocator TargetAllocatorEmbedded `json:"targetAllocator,omitempty"`
@@ -100,6 +185,14 @@ type OpenTelemetryCollectorSpec struct {
 	// +required
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Config Config `json:"config"`
+	// ConfigFragments is a list of ConfigMaps holding reusable pieces of collector configuration, e.g. a
+	// standard processor chain or a shared exporter, which are deep-merged into Config before it is rendered.
+	// Fragments are merged in the listed order and values set in Config always take precedence over them.
+	// Two fragments setting different values for the same key is a conflict and fails the reconciliation.
+	// Only ConfigMaps labeled with `opentelemetry.io/config-fragment: "true"` can be referenced.
+	// +optional
+	// +listType=atomic
+	ConfigFragments []ConfigFragmentReference `json:"configFragments,omitempty"`
 	// ConfigVersions defines the number versions to keep for the collector config. Each config version is stored in a separate ConfigMap.
 	// Defaults to 3. The minimum value is 1.
 	// +optional
@@ -141,6 +234,73 @@ type OpenTelemetryCollectorSpec struct {
 	// This is only applicable to Deployment mode.
 	// +optional
 	DeploymentUpdateStrategy appsv1.DeploymentStrategy `json:"deploymentUpdateStrategy,omitempty"`
+	// SidecarTemplate shares a sidecar collector with the pods of other namespaces, e.g. a collector maintained by
+	// a platform team in the operator namespace. The pods of the selected namespaces opt into it with the
+	// `sidecar.opentelemetry.io/inject` annotation set to `<namespace>/<name>` of the collector, or to `true` when
+	// their namespace has no sidecar collector of its own.
+	// This is only applicable to Sidecar mode.
+	// +optional
+	SidecarTemplate *SidecarTemplate `json:"sidecarTemplate,omitempty"`
+	// SidecarResources recommends the resources of the sidecar collector for each workload it is injected into,
+	// from the usage reported by the metrics.k8s.io API, e.g. served by the metrics-server. It requires the
+	// operator.sidecarcollector.resourcerecommendations feature gate.
+	// This is only applicable to Sidecar mode.
+	// +optional
+	SidecarResources *SidecarResourcesSpec `json:"sidecarResources,omitempty"`
+}
+
+// SidecarResourcesMode defines what is done with the sidecar resource recommendations.
+// +kubebuilder:validation:Enum=Recommend;Auto
+type SidecarResourcesMode string
+
+const (
+	// SidecarResourcesModeRecommend only reports the recommendations in the status of the collector.
+	SidecarResourcesModeRecommend SidecarResourcesMode = "Recommend"
+	// SidecarResourcesModeAuto also sets the recommended resources on the sidecars injected afterwards, instead of
+	// the resources of the collector.
+	SidecarResourcesModeAuto SidecarResourcesMode = "Auto"
+)
+
+// SidecarResourcesSpec defines how the resources of the sidecar collector are recommended.
+type SidecarResourcesSpec struct {
+	// Mode is either Recommend, to only report the recommendations in the status, or Auto, to also apply them to
+	// the sidecars injected afterwards.
+	// +optional
+	// +kubebuilder:default:=Recommend
+	Mode SidecarResourcesMode `json:"mode,omitempty"`
+	// MinAllowed is the lower bound of the recommended requests.
+	// +optional
+	MinAllowed v1.ResourceList `json:"minAllowed,omitempty"`
+	// MaxAllowed is the upper bound of the recommended requests.
+	// +optional
+	MaxAllowed v1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+// SidecarTemplate defines the namespaces allowed to use a sidecar collector, and how its sidecars are
+// customized for them.
+type SidecarTemplate struct {
+	// NamespaceSelector selects the namespaces whose pods can use the collector as their sidecar.
+	// An empty selector selects all the namespaces.
+	// +required
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	// Overrides customize the sidecars injected into the pods of some namespaces.
+	// All the overrides selecting the namespace of a pod are applied, in order.
+	// +optional
+	// +listType=atomic
+	Overrides []SidecarTemplateOverride `json:"overrides,omitempty"`
+}
+
+// SidecarTemplateOverride defines the customizations of the sidecars injected into the pods of some namespaces.
+type SidecarTemplateOverride struct {
+	// NamespaceSelector selects the namespaces the override applies to.
+	// +required
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	// ResourceAttributes are added to the resource attributes the sidecar reads from `OTEL_RESOURCE_ATTRIBUTES`.
+	// +optional
+	ResourceAttributes map[string]string `json:"resourceAttributes,omitempty"`
+	// ExporterHeaders are added to the headers of the otlp and otlphttp exporters of the sidecar.
+	// +optional
+	ExporterHeaders map[string]string `json:"exporterHeaders,omitempty"`
 }
 
 // TargetAllocatorEmbedded defines the configuration for the Prometheus target allocator, embedded in the
@@ -259,6 +419,10 @@ type Probe struct {
 	// Minimum value is 1. spec.terminationGracePeriodSeconds is used if unset.
 	// +optional
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// Pipeline narrows the probe down to the health of the given pipeline, e.g. traces or metrics/prometheus.
+	// Only supported with the healthcheckv2 extension running with use_v2.
+	// +optional
+	Pipeline string `json:"pipeline,omitempty"`
 }
 
 // ObservabilitySpec defines how telemetry data gets handled.
@@ -308,6 +472,92 @@ type ScaleSubresourceStatus struct {
 	StatusReplicas string `json:"statusReplicas,omitempty"`
 }
 
+// ConfigFragmentReference references a ConfigMap holding a collector configuration fragment.
+type ConfigFragmentReference struct {
+	// Name of the ConfigMap holding the fragment.
+	// +required
+	Name string `json:"name"`
+	// Namespace of the ConfigMap holding the fragment. Defaults to the namespace of the OpenTelemetryCollector.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Key of the ConfigMap entry holding the fragment. Defaults to `fragment.yaml`.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// ConfigFragmentStatus describes a configuration fragment applied to the collector configuration.
+type ConfigFragmentStatus struct {
+	// Name of the ConfigMap holding the fragment.
+	Name string `json:"name"`
+	// Namespace of the ConfigMap holding the fragment.
+	Namespace string `json:"namespace"`
+	// ResourceVersion of the ConfigMap at the time it was applied.
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// PipelineStatus describes a pipeline of the effective collector configuration.
+type PipelineStatus struct {
+	// Name of the pipeline, e.g. traces or metrics/prometheus.
+	Name string `json:"name"`
+	// Receivers of the pipeline.
+	// +optional
+	// +listType=atomic
+	Receivers []string `json:"receivers,omitempty"`
+	// Processors of the pipeline.
+	// +optional
+	// +listType=atomic
+	Processors []string `json:"processors,omitempty"`
+	// Exporters of the pipeline.
+	// +optional
+	// +listType=atomic
+	Exporters []string `json:"exporters,omitempty"`
+}
+
+// PortStatus describes a port exposed by the collector container.
+type PortStatus struct {
+	// Name of the port.
+	Name string `json:"name"`
+	// Port number.
+	Port int32 `json:"port"`
+	// Protocol of the port.
+	// +optional
+	Protocol v1.Protocol `json:"protocol,omitempty"`
+}
+
+// SidecarsStatus reports the pods a sidecar collector is injected into.
+type SidecarsStatus struct {
+	// Pods is the number of running pods the sidecar collector is injected into.
+	Pods int32 `json:"pods"`
+	// OutdatedPods is the number of those pods whose sidecar runs an outdated configuration, until they are
+	// recreated. The sidecars reloading their configuration are never outdated.
+	OutdatedPods int32 `json:"outdatedPods"`
+}
+
+// SidecarResourcesRecommendation is the resources recommended for the sidecar collector of a workload.
+type SidecarResourcesRecommendation struct {
+	// Kind of the workload owning the pods, e.g. Deployment.
+	Kind string `json:"kind"`
+	// Namespace of the workload.
+	Namespace string `json:"namespace"`
+	// Name of the workload.
+	Name string `json:"name"`
+	// Requests are the recommended cpu and memory requests of the sidecar.
+	Requests v1.ResourceList `json:"requests"`
+	// Limits are the cpu and memory limits of the sidecar, keeping the ratio between the limits and the requests
+	// of the collector resources.
+	// +optional
+	Limits v1.ResourceList `json:"limits,omitempty"`
+}
+
+// ComponentStatus identifies a component of the collector configuration.
+type ComponentStatus struct {
+	// Kind of the component, one of receiver, exporter, processor or extension.
+	Kind string `json:"kind"`
+	// ID of the component as it appears in the configuration, e.g. otlp/internal.
+	ID string `json:"id"`
+}
+
 type ConfigMapsSpec struct {
 	// Configmap defines name and path where the configMaps should be mounted.
 	Name      string `json:"name"`
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+// FileStorageExtension is the id of the file_storage extension the sending queues of the exporters are persisted with.
+const FileStorageExtension = "file_storage/persistence"
+
+// exportersWithSendingQueue are the types of the exporters known to queue the data they send in a sending_queue. The
+// other exporters only get a persistent sending queue when their configuration already declares one.
+var exportersWithSendingQueue = map[string]bool{
+	"carbon":        true,
+	"clickhouse":    true,
+	"coralogix":     true,
+	"datadog":       true,
+	"elasticsearch": true,
+	"googlecloud":   true,
+	"influxdb":      true,
+	"kafka":         true,
+	"logzio":        true,
+	"mezmo":         true,
+	"opensearch":    true,
+	"otelarrow":     true,
+	"otlp":          true,
+	"otlphttp":      true,
+	"pulsar":        true,
+	"rabbitmq":      true,
+	"sapm":          true,
+	"signalfx":      true,
+	"splunk_hec":    true,
+	"sumologic":     true,
+	"syslog":        true,
+	"zipkin":        true,
+}
+
+// PersistenceSpec defines the storage the exporters of the collector persist their sending queues to, with the
+// file_storage extension.
+type PersistenceSpec struct {
+	// Directory is the path the storage is mounted at in the collector container.
+	// +optional
+	// +kubebuilder:default:="/var/lib/otelcol/file_storage"
+	Directory string `json:"directory,omitempty"`
+	// Size of the persistent volume claimed by each replica in StatefulSet mode, or the size limit of the emptyDir
+	// volume in DaemonSet mode.
+	// +optional
+	// +kubebuilder:default:="1Gi"
+	Size resource.Quantity `json:"size,omitempty"`
+	// StorageClassName of the persistent volumes claimed in StatefulSet mode. The default storage class of the
+	// cluster is used when unset.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// HostPath is the directory of the nodes the collectors store their queues in, in DaemonSet mode, under a
+	// <namespace>/<name> sub-directory. An emptyDir volume, lost when the pod is deleted, is used when unset. The
+	// directories are created for the root user, the collector must run as root to use it.
+	// +optional
+	HostPath string `json:"hostPath,omitempty"`
+	// FSGroup is set as the fsGroup of the pod security context, unless it already sets one, to let the
+	// collector write to the volume. It doesn't apply to hostPath volumes.
+	// +optional
+	// +kubebuilder:default:=10001
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+}
+
+// InjectFileStorage enables a file_storage extension storing its data in the given directory, and makes the sending
+// queue of the exporters use it: the exporters known to have one, and the ones declaring a sending_queue. The exporters
+// which disable their sending queue or set its storage are left as is.
+func (c *Config) InjectFileStorage(directory string) {
+	if c.Extensions == nil {
+		c.Extensions = &AnyConfig{}
+	}
+	if c.Extensions.Object == nil {
+		c.Extensions.Object = map[string]interface{}{}
+	}
+	c.Extensions.Object[FileStorageExtension] = map[string]interface{}{
+		"directory":        directory,
+		"create_directory": true,
+	}
+	enabled := false
+	for _, extension := range c.Service.Extensions {
+		enabled = enabled || extension == FileStorageExtension
+	}
+	if !enabled {
+		c.Service.Extensions = append(c.Service.Extensions, FileStorageExtension)
+	}
+
+	for id, exporter := range c.Exporters.Object {
+		exporterCfg, ok := exporter.(map[string]interface{})
+		if !ok && exporter != nil {
+			continue
+		}
+		if _, declared := exporterCfg["sending_queue"]; !declared && !exportersWithSendingQueue[components.ComponentType(id)] {
+			continue
+		}
+		queue, ok := exporterCfg["sending_queue"].(map[string]interface{})
+		if !ok && exporterCfg["sending_queue"] != nil {
+			continue
+		}
+		if queueEnabled, ok := queue["enabled"].(bool); ok && !queueEnabled {
+			continue
+		}
+		if _, ok := queue["storage"]; ok {
+			continue
+		}
+		// the nested maps may be shared with other copies of the config
+		persistentQueue := map[string]interface{}{"storage": FileStorageExtension}
+		for key, value := range queue {
+			persistentQueue[key] = value
+		}
+		persistentExporter := map[string]interface{}{"sending_queue": persistentQueue}
+		for key, value := range exporterCfg {
+			if key != "sending_queue" {
+				persistentExporter[key] = value
+			}
+		}
+		c.Exporters.Object[id] = persistentExporter
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// RolloutStrategy is how a new configuration of the collector is rolled out.
+	// +kubebuilder:validation:Enum=Canary;BlueGreen
+	RolloutStrategy string
+
+	// RolloutPhase is the phase of the rollout of a new configuration.
+	RolloutPhase string
+)
+
+const (
+	// RolloutStrategyCanary runs the new configuration in a small canary Deployment, which receives a share of the
+	// traffic proportional to its replicas.
+	RolloutStrategyCanary RolloutStrategy = "Canary"
+	// RolloutStrategyBlueGreen runs the new configuration in a Deployment with as many replicas as the collector,
+	// which receives all the traffic once it's promoted.
+	RolloutStrategyBlueGreen RolloutStrategy = "BlueGreen"
+
+	// RolloutPhaseProgressing indicates that the new configuration is being analyzed.
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	// RolloutPhasePromoting indicates that the new configuration serves the traffic while the collector Deployment
+	// is updated to it.
+	RolloutPhasePromoting RolloutPhase = "Promoting"
+	// RolloutPhaseSucceeded indicates that the collector runs the latest configuration.
+	RolloutPhaseSucceeded RolloutPhase = "Succeeded"
+	// RolloutPhaseRolledBack indicates that the latest configuration failed its analysis, the collector keeps
+	// running the previous one.
+	RolloutPhaseRolledBack RolloutPhase = "RolledBack"
+)
+
+// RolloutSpec defines how a new configuration of the collector is rolled out.
+type RolloutSpec struct {
+	// Strategy of the rollout, either Canary or BlueGreen.
+	// +required
+	Strategy RolloutStrategy `json:"strategy"`
+	// CanaryReplicas is the number of replicas running the new configuration with the Canary strategy.
+	// +optional
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	CanaryReplicas int32 `json:"canaryReplicas,omitempty"`
+	// Analysis defines when the new configuration is promoted or rolled back.
+	// +optional
+	Analysis RolloutAnalysis `json:"analysis,omitempty"`
+}
+
+// RolloutAnalysis defines the health checks of a new configuration.
+type RolloutAnalysis struct {
+	// Duration the new configuration has to stay healthy before it's promoted.
+	// +optional
+	// +kubebuilder:default:="5m"
+	Duration metav1.Duration `json:"duration,omitempty"`
+	// MaxRestarts is the number of restarts of the collector containers running the new configuration above which
+	// the configuration is rolled back.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRestarts int32 `json:"maxRestarts,omitempty"`
+	// MaxExportFailurePercentage is the percentage of the spans, metric points and log records the exporters of the
+	// new configuration fail to send above which the configuration is rolled back, according to the collector
+	// telemetry metrics.
+	// +optional
+	// +kubebuilder:default:=5
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxExportFailurePercentage int32 `json:"maxExportFailurePercentage,omitempty"`
+}
+
+// RolloutStatus reports the progress of the rollout of the collector configuration.
+type RolloutStatus struct {
+	// Phase of the rollout.
+	// +optional
+	Phase RolloutPhase `json:"phase,omitempty"`
+	// StableConfigVersion is the hash of the configuration run by the collector Deployment.
+	// +optional
+	StableConfigVersion string `json:"stableConfigVersion,omitempty"`
+	// CandidateConfigVersion is the hash of the configuration being rolled out.
+	// +optional
+	CandidateConfigVersion string `json:"candidateConfigVersion,omitempty"`
+	// StartTime is when the rollout of the candidate configuration started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Message describes why the candidate configuration was promoted or rolled back.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
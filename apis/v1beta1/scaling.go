@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScalingReason is why the replicas of the collector are overridden.
+type ScalingReason string
+
+const (
+	// ScalingReasonSchedule indicates that a schedule window is active.
+	ScalingReasonSchedule ScalingReason = "Schedule"
+	// ScalingReasonIdle indicates that the collector didn't receive any item for the idle period.
+	ScalingReasonIdle ScalingReason = "Idle"
+)
+
+// ScalingSpec defines how the collector is scaled on a schedule and when it's idle.
+type ScalingSpec struct {
+	// Schedules are the time windows with a fixed number of replicas. When several windows are active, the
+	// first one applies.
+	// +optional
+	// +listType=atomic
+	Schedules []ScalingSchedule `json:"schedules,omitempty"`
+	// TimeZone of the schedules, as a name of the IANA time zone database, e.g. Europe/Paris. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// IdleScaleDown scales the collector down to zero replicas when it doesn't receive any item.
+	// +optional
+	IdleScaleDown *IdleScaleDown `json:"idleScaleDown,omitempty"`
+}
+
+// ScalingSchedule is a recurring time window with a fixed number of replicas.
+type ScalingSchedule struct {
+	// Name of the schedule, reported in the status while the window is active.
+	// +required
+	Name string `json:"name"`
+	// Start is the cron expression of the start of the window, e.g. "0 8 * * 1-5".
+	// +required
+	Start string `json:"start"`
+	// Duration of the window, e.g. 10h.
+	// +required
+	Duration metav1.Duration `json:"duration"`
+	// Replicas of the collector during the window. When the collector is autoscaled, both bounds of the
+	// autoscaler are set to this number.
+	// +required
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+}
+
+// IdleScaleDown defines when an idle collector is scaled down to zero replicas.
+type IdleScaleDown struct {
+	// After is how long the collector must not receive any span, metric point or log record, according to its
+	// telemetry metrics, before being scaled down, e.g. 30m.
+	// +required
+	After metav1.Duration `json:"after"`
+}
+
+// ScalingStatus reports the replicas the collector is scaled to by the schedules or the idle scale-down.
+type ScalingStatus struct {
+	// Replicas the collector is scaled to. Unset when the replicas of the spec apply.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Reason the replicas are overridden, either Schedule or Idle.
+	// +optional
+	Reason ScalingReason `json:"reason,omitempty"`
+	// Schedule is the name of the active schedule window.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// ReceivedItems is the number of items received by the replicas of the collector, when last observed.
+	// +optional
+	ReceivedItems *int64 `json:"receivedItems,omitempty"`
+	// LastActivityTime is the last time the collector was observed receiving items, woken up by a schedule window
+	// or updated.
+	// +optional
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
+	// ObservedGeneration is the generation of the collector when the scaling was last updated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
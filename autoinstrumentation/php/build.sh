@@ -0,0 +1,46 @@
+#!/bin/sh
+# Builds the opentelemetry extension and installs the SDK for the PHP version of the image.
+# Usage: build.sh <extension version> <workspace>
+#
+# The extension is only binary compatible with PHP builds of the same libc and thread safety: it's built on the
+# Debian based php:<version>-cli images, for glibc and non thread safe (NTS) PHP. It can't be loaded by the PHP of
+# Alpine (musl) images, nor by thread safe (ZTS) builds such as the php:<version>-zts images.
+set -eu
+
+version=$1
+workspace=$2
+
+if [ "$(php -r 'echo PHP_ZTS;')" != "0" ]; then
+  echo "the auto-instrumentation is built for non thread safe PHP, this PHP is thread safe (ZTS)" >&2
+  exit 1
+fi
+if ! ldd --version 2>&1 | grep -qi "glibc\|gnu libc"; then
+  echo "the auto-instrumentation is built for glibc, this image uses another libc" >&2
+  exit 1
+fi
+
+apt-get update && apt-get install -y --no-install-recommends git unzip
+pecl install "opentelemetry-$version"
+
+mkdir -p "$workspace/extensions" "$workspace/conf.d"
+cp "$(php-config --extension-dir)/opentelemetry.so" "$workspace/extensions/"
+cat > "$workspace/conf.d/opentelemetry.ini" <<EOF
+extension=/otel-auto-instrumentation-php/extensions/opentelemetry.so
+auto_prepend_file=/otel-auto-instrumentation-php/prepend.php
+EOF
+# PHP has a single auto_prepend_file, the one of the application is overridden by the ini file above: prepend.php
+# chains it when its path is set in OTEL_PHP_PREPEND_FILE.
+cat > "$workspace/prepend.php" <<'EOF'
+<?php
+require_once __DIR__ . '/vendor/autoload.php';
+$otelPrependFile = getenv('OTEL_PHP_PREPEND_FILE');
+if ($otelPrependFile !== false && $otelPrependFile !== '') {
+    require_once $otelPrependFile;
+}
+unset($otelPrependFile);
+EOF
+
+curl -sS https://getcomposer.org/installer | php -- --install-dir=/usr/local/bin --filename=composer
+cp "$(dirname "$0")/composer.json" "$workspace/"
+composer install --working-dir="$workspace" --no-dev --no-interaction --optimize-autoloader
+rm "$workspace/composer.json" "$workspace/composer.lock"
@@ -0,0 +1,34 @@
+# frozen_string_literal: true
+
+# Required through RUBYOPT by the OpenTelemetry Operator. The gems of the auto-instrumentation are appended to the
+# load path so that the versions bundled with the application take precedence.
+Dir.glob(File.join(__dir__, 'gems', '*', 'lib')).each { |lib| $LOAD_PATH.push(lib) }
+
+module OpenTelemetryAutoInstrumentation
+  def self.configure
+    return if @configured
+
+    @configured = true
+    require 'opentelemetry/sdk'
+    require 'opentelemetry/exporter/otlp'
+    require 'opentelemetry/instrumentation/all'
+    OpenTelemetry::SDK.configure(&:use_all)
+  rescue LoadError, StandardError => e
+    warn "OpenTelemetry auto-instrumentation is disabled: #{e.message}"
+  end
+
+  # The instrumentations are only installed for the libraries loaded at configuration time, applications using
+  # Bundler are configured once their gems are required.
+  module BundlerRuntime
+    def require(*groups)
+      super.tap { OpenTelemetryAutoInstrumentation.configure }
+    end
+  end
+end
+
+if ENV.key?('BUNDLE_GEMFILE') || File.exist?('Gemfile')
+  require 'bundler'
+  Bundler::Runtime.prepend(OpenTelemetryAutoInstrumentation::BundlerRuntime)
+else
+  OpenTelemetryAutoInstrumentation.configure
+end
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
+)
+
+// The capabilities of a cluster the offline auto-detection reports as available.
+const (
+	CapabilityCertManager     = "cert-manager"
+	CapabilityGatewayAPI      = "gateway-api"
+	CapabilityKEDA            = "keda"
+	CapabilityOpenShiftRoutes = "openshift-routes"
+	CapabilityPrometheusCRs   = "prometheus-crs"
+	CapabilityRBAC            = "rbac"
+)
+
+var capabilities = map[string]bool{
+	CapabilityCertManager:     true,
+	CapabilityGatewayAPI:      true,
+	CapabilityKEDA:            true,
+	CapabilityOpenShiftRoutes: true,
+	CapabilityPrometheusCRs:   true,
+	CapabilityRBAC:            true,
+}
+
+var _ autodetect.AutoDetect = (*offlineAutoDetect)(nil)
+
+// offlineAutoDetect reports the capabilities it's given instead of discovering them from a cluster.
+type offlineAutoDetect struct {
+	available map[string]bool
+}
+
+// NewAutoDetect returns an auto-detection reporting the given capabilities as available, and every other one as not
+// available.
+func NewAutoDetect(available []string) (autodetect.AutoDetect, error) {
+	a := &offlineAutoDetect{available: map[string]bool{}}
+	for _, capability := range available {
+		if !capabilities[capability] {
+			names := make([]string, 0, len(capabilities))
+			for name := range capabilities {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown capability %q, should be one of %s", capability, strings.Join(names, ", "))
+		}
+		a.available[capability] = true
+	}
+	return a, nil
+}
+
+func (a *offlineAutoDetect) OpenShiftRoutesAvailability() (openshift.RoutesAvailability, error) {
+	if a.available[CapabilityOpenShiftRoutes] {
+		return openshift.RoutesAvailable, nil
+	}
+	return openshift.RoutesNotAvailable, nil
+}
+
+func (a *offlineAutoDetect) PrometheusCRsAvailability() (prometheus.Availability, error) {
+	if a.available[CapabilityPrometheusCRs] {
+		return prometheus.Available, nil
+	}
+	return prometheus.NotAvailable, nil
+}
+
+func (a *offlineAutoDetect) RBACPermissions(_ context.Context) (autoRBAC.Availability, error) {
+	if a.available[CapabilityRBAC] {
+		return autoRBAC.Available, nil
+	}
+	return autoRBAC.NotAvailable, nil
+}
+
+func (a *offlineAutoDetect) CertManagerAvailability(_ context.Context) (certmanager.Availability, error) {
+	if a.available[CapabilityCertManager] {
+		return certmanager.Available, nil
+	}
+	return certmanager.NotAvailable, nil
+}
+
+// FIPSEnabled always reports FIPS as disabled, no collector component is denied offline.
+func (a *offlineAutoDetect) FIPSEnabled(_ context.Context) bool {
+	return false
+}
+
+func (a *offlineAutoDetect) GatewayAPIAvailability() (gatewayapi.Availability, error) {
+	if a.available[CapabilityGatewayAPI] {
+		return gatewayapi.Available, nil
+	}
+	return gatewayapi.NotAvailable, nil
+}
+
+func (a *offlineAutoDetect) KEDAAvailability() (keda.Availability, error) {
+	if a.available[CapabilityKEDA] {
+		return keda.Available, nil
+	}
+	return keda.NotAvailable, nil
+}
@@ -0,0 +1,307 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package render renders the Kubernetes objects the operator manages for its custom resources, without a cluster.
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	authv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	kubetesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/controllers"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	kedav1alpha1 "github.com/open-telemetry/opentelemetry-operator/internal/keda/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
+)
+
+// NewScheme returns a scheme with the custom resources of the operator and every kind of object it manages.
+func NewScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	utilruntime.Must(monitoringv1.AddToScheme(scheme))
+	utilruntime.Must(routev1.Install(scheme))
+	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.Install(scheme))
+	utilruntime.Must(cmv1.AddToScheme(scheme))
+	return scheme
+}
+
+// Decode reads the objects of the given YAML or JSON documents. The kind of every object must be known to the scheme.
+func Decode(scheme *runtime.Scheme, r io.Reader) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	var objects []client.Object
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		decoded, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		object, ok := decoded.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unsupported object %T", decoded)
+		}
+		objects = append(objects, object)
+	}
+}
+
+// Encode writes the given objects as YAML documents.
+func Encode(scheme *runtime.Scheme, w io.Writer, objects []client.Object) error {
+	for _, object := range objects {
+		if err := setKind(scheme, object); err != nil {
+			return err
+		}
+		out, err := yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+		if _, err = fmt.Fprintf(w, "---\n%s", out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setKind sets the apiVersion and the kind of the given object, which the builders leave empty.
+func setKind(scheme *runtime.Scheme, object client.Object) error {
+	gvk, err := apiutil.GVKForObject(object, scheme)
+	if err != nil {
+		return err
+	}
+	object.GetObjectKind().SetGroupVersionKind(gvk)
+	return nil
+}
+
+// Result holds the rendered objects, and the warnings of the validation of the custom resources.
+type Result struct {
+	Objects  []client.Object
+	Warnings []string
+}
+
+// Renderer defaults and validates the custom resources of the operator like its webhooks, and builds the objects
+// their reconcilers would create.
+type Renderer struct {
+	config    config.Config
+	scheme    *runtime.Scheme
+	log       logr.Logger
+	namespace string
+	reviewer  *rbac.Reviewer
+}
+
+// New returns a renderer using the given operator configuration. The custom resources without a namespace are
+// rendered in the given one.
+func New(cfg config.Config, scheme *runtime.Scheme, logger logr.Logger, namespace string) *Renderer {
+	// the permissions of the service accounts can't be reviewed offline, they're assumed to be granted
+	clientset := kubefake.NewSimpleClientset()
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		review, ok := action.(kubetesting.CreateAction).GetObject().DeepCopyObject().(*authv1.SubjectAccessReview)
+		if !ok {
+			return false, nil, fmt.Errorf("unexpected object %T", action.(kubetesting.CreateAction).GetObject())
+		}
+		review.Status = authv1.SubjectAccessReviewStatus{Allowed: true}
+		return true, review, nil
+	})
+	return &Renderer{
+		config:    cfg,
+		scheme:    scheme,
+		log:       logger,
+		namespace: namespace,
+		reviewer:  rbac.NewReviewer(clientset),
+	}
+}
+
+// Render renders the objects managed for the OpenTelemetryCollectors, TargetAllocators and OpAMPBridges among the
+// given objects, and the defaulted Instrumentations. The other objects, like the ConfigMaps of config fragments, are
+// only looked up by the reconcilers.
+func (r *Renderer) Render(ctx context.Context, objects []client.Object) (Result, error) {
+	result := Result{}
+	var resources []client.Object
+	for _, object := range objects {
+		object = object.DeepCopyObject().(client.Object)
+		if object.GetNamespace() == "" {
+			object.SetNamespace(r.namespace)
+		}
+		if collector, ok := object.(*v1alpha1.OpenTelemetryCollector); ok {
+			converted := &v1beta1.OpenTelemetryCollector{}
+			if err := collector.ConvertTo(converted); err != nil {
+				return result, fmt.Errorf("%s: %w", r.describe(object), err)
+			}
+			object = converted
+		}
+		warnings, err := r.admit(ctx, object)
+		for _, warning := range warnings {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %s", r.describe(object), warning))
+		}
+		if err != nil {
+			return result, fmt.Errorf("%s: %w", r.describe(object), err)
+		}
+		resources = append(resources, object)
+	}
+
+	cli := fake.NewClientBuilder().WithScheme(r.scheme).WithObjects(resources...).Build()
+	recorder := &record.FakeRecorder{}
+	for _, resource := range resources {
+		var built []client.Object
+		var err error
+		switch resource := resource.(type) {
+		case *v1beta1.OpenTelemetryCollector:
+			built, err = r.renderCollector(ctx, cli, recorder, *resource)
+		case *v1alpha1.TargetAllocator:
+			built, err = r.renderTargetAllocator(ctx, cli, recorder, *resource)
+		case *v1alpha1.OpAMPBridge:
+			reconciler := controllers.NewOpAMPBridgeReconciler(controllers.OpAMPBridgeReconcilerParams{
+				Client:   cli,
+				Recorder: recorder,
+				Scheme:   r.scheme,
+				Log:      r.log,
+				Config:   r.config,
+			})
+			built, err = controllers.BuildOpAMPBridge(reconciler.GetParams(*resource))
+		case *v1alpha1.Instrumentation:
+			built = []client.Object{resource}
+		}
+		if err != nil {
+			return result, fmt.Errorf("%s: %w", r.describe(resource), err)
+		}
+		for _, object := range built {
+			if err = setKind(r.scheme, object); err != nil {
+				return result, err
+			}
+		}
+		result.Objects = append(result.Objects, built...)
+	}
+	return result, nil
+}
+
+// admit defaults and validates the given custom resource like the webhooks of the operator.
+func (r *Renderer) admit(ctx context.Context, object client.Object) (admission.Warnings, error) {
+	var defaulter admission.CustomDefaulter
+	var validator admission.CustomValidator
+	switch object.(type) {
+	case *v1beta1.OpenTelemetryCollector:
+		webhook := v1beta1.NewCollectorWebhook(r.log, r.scheme, r.config, r.reviewer, nil, nil, nil)
+		defaulter, validator = webhook, webhook
+	case *v1alpha1.TargetAllocator:
+		webhook := v1alpha1.NewTargetAllocatorWebhook(r.log, r.scheme, r.config, r.reviewer)
+		defaulter, validator = webhook, webhook
+	case *v1alpha1.OpAMPBridge:
+		webhook := v1alpha1.NewOpAMPBridgeWebhook(r.log, r.scheme, r.config)
+		defaulter, validator = webhook, webhook
+	case *v1alpha1.Instrumentation:
+		webhook := v1alpha1.NewInstrumentationWebhook(r.log, r.scheme, r.config)
+		defaulter, validator = webhook, webhook
+	default:
+		return nil, nil
+	}
+	if err := defaulter.Default(ctx, object); err != nil {
+		return nil, err
+	}
+	return validator.ValidateCreate(ctx, object)
+}
+
+func (r *Renderer) renderCollector(ctx context.Context, cli client.Client, recorder record.EventRecorder, otelcol v1beta1.OpenTelemetryCollector) ([]client.Object, error) {
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client:   cli,
+		Recorder: recorder,
+		Scheme:   r.scheme,
+		Log:      r.log,
+		Config:   r.config,
+	})
+	params, err := reconciler.GetParams(ctx, otelcol)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := controllers.BuildCollector(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// the target allocator created for the collector is reconciled in turn
+	var rendered []client.Object
+	for _, object := range objects {
+		rendered = append(rendered, object)
+		targetAllocator, ok := object.(*v1alpha1.TargetAllocator)
+		if !ok {
+			continue
+		}
+		owned := targetAllocator.DeepCopy()
+		owned.OwnerReferences = append(owned.OwnerReferences, metav1.OwnerReference{
+			APIVersion: v1beta1.GroupVersion.String(),
+			Kind:       "OpenTelemetryCollector",
+			Name:       otelcol.Name,
+		})
+		taObjects, err := r.renderTargetAllocator(ctx, cli, recorder, *owned)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, taObjects...)
+	}
+	return rendered, nil
+}
+
+func (r *Renderer) renderTargetAllocator(ctx context.Context, cli client.Client, recorder record.EventRecorder, targetAllocator v1alpha1.TargetAllocator) ([]client.Object, error) {
+	reconciler := controllers.NewTargetAllocatorReconciler(cli, r.scheme, recorder, r.config, r.log)
+	params, err := reconciler.GetParams(ctx, targetAllocator)
+	if err != nil {
+		return nil, err
+	}
+	return controllers.BuildTargetAllocator(params)
+}
+
+// describe returns the kind and the name of the given object, for the errors and the warnings.
+func (r *Renderer) describe(object client.Object) string {
+	kind := fmt.Sprintf("%T", object)
+	if gvk, err := apiutil.GVKForObject(object, r.scheme); err == nil {
+		kind = gvk.Kind
+	}
+	return fmt.Sprintf("%s %s/%s", kind, object.GetNamespace(), object.GetName())
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func newRenderer(t *testing.T, available ...string) *Renderer {
+	autoDetect, err := NewAutoDetect(available)
+	require.NoError(t, err)
+	cfg := config.New(
+		config.WithAutoDetect(autoDetect),
+		config.WithCollectorImage("collector:v0.0.0"),
+		config.WithTargetAllocatorImage("ta:v0.0.0"),
+		config.WithAutoInstrumentationJavaImage("java:v0.0.0"),
+	)
+	require.NoError(t, cfg.AutoDetect())
+	return New(cfg, NewScheme(), logr.Discard(), "default")
+}
+
+func decodeFile(t *testing.T, filename string) []client.Object {
+	file, err := os.Open(filename)
+	require.NoError(t, err)
+	defer file.Close()
+	objects, err := Decode(NewScheme(), file)
+	require.NoError(t, err)
+	return objects
+}
+
+func TestRender(t *testing.T) {
+	objects := decodeFile(t, "testdata/collector.yaml")
+	require.Len(t, objects, 3)
+
+	result, err := newRenderer(t).Render(context.Background(), objects)
+	require.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+
+	byName := map[string]client.Object{}
+	for _, object := range result.Objects {
+		byName[object.GetObjectKind().GroupVersionKind().Kind+"/"+object.GetName()] = object
+		if _, ok := object.(*v1alpha1.Instrumentation); !ok {
+			assert.Equal(t, "default", object.GetNamespace())
+		}
+	}
+	// the managed objects are rendered, not the referenced ones
+	assert.NotContains(t, byName, "ConfigMap/exporters")
+
+	deployment, ok := byName["Deployment/gateway-collector"].(*appsv1.Deployment)
+	require.True(t, ok, "the collector deployment isn't rendered: %v", byName)
+	assert.Equal(t, "collector:v0.0.0", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+
+	// the config fragment is merged into the collector config
+	configMap, ok := byName["ConfigMap/"+deployment.Spec.Template.Spec.Volumes[0].ConfigMap.Name].(*corev1.ConfigMap)
+	require.True(t, ok)
+	assert.Contains(t, configMap.Data["collector.yaml"], "endpoint: backend:4317")
+
+	instrumentation, ok := byName["Instrumentation/java"].(*v1alpha1.Instrumentation)
+	require.True(t, ok)
+	assert.Equal(t, "apps", instrumentation.Namespace)
+	assert.Equal(t, "java:v0.0.0", instrumentation.Spec.Java.Image)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, Encode(NewScheme(), out, result.Objects))
+	assert.Contains(t, out.String(), "---\napiVersion: apps/v1\nkind: Deployment\n")
+	reread, err := Decode(NewScheme(), out)
+	require.NoError(t, err)
+	assert.Len(t, reread, len(result.Objects))
+}
+
+func TestRenderCapabilities(t *testing.T) {
+	objects, err := Decode(NewScheme(), strings.NewReader(`apiVersion: opentelemetry.io/v1beta1
+kind: OpenTelemetryCollector
+metadata:
+  name: gateway
+spec:
+  observability:
+    metrics:
+      enableMetrics: true
+  config:
+    receivers:
+      otlp:
+        protocols:
+          grpc: {}
+    exporters:
+      debug: {}
+    service:
+      pipelines:
+        traces:
+          receivers: [otlp]
+          exporters: [debug]
+`))
+	require.NoError(t, err)
+
+	kinds := func(result Result) []string {
+		var kinds []string
+		for _, object := range result.Objects {
+			kinds = append(kinds, object.GetObjectKind().GroupVersionKind().Kind)
+		}
+		return kinds
+	}
+
+	result, err := newRenderer(t).Render(context.Background(), objects)
+	require.NoError(t, err)
+	assert.NotContains(t, kinds(result), "ServiceMonitor")
+
+	result, err = newRenderer(t, CapabilityPrometheusCRs).Render(context.Background(), objects)
+	require.NoError(t, err)
+	assert.Contains(t, kinds(result), "ServiceMonitor")
+}
+
+func TestRenderInvalid(t *testing.T) {
+	objects, err := Decode(NewScheme(), strings.NewReader(`apiVersion: opentelemetry.io/v1beta1
+kind: OpenTelemetryCollector
+metadata:
+  name: gateway
+  namespace: observability
+spec:
+  mode: deployment
+  volumeClaimTemplates:
+    - metadata:
+        name: data
+  config:
+    receivers:
+      otlp: {}
+    exporters:
+      debug: {}
+    service:
+      pipelines:
+        traces:
+          receivers: [otlp]
+          exporters: [debug]
+`))
+	require.NoError(t, err)
+
+	_, err = newRenderer(t).Render(context.Background(), objects)
+	assert.ErrorContains(t, err, "OpenTelemetryCollector observability/gateway: the OpenTelemetry Collector mode is set to deployment, which does not support the attribute 'volumeClaimTemplates'")
+}
+
+func TestNewAutoDetect(t *testing.T) {
+	_, err := NewAutoDetect([]string{CapabilityKEDA, "prometheus"})
+	assert.ErrorContains(t, err, `unknown capability "prometheus"`)
+}
@@ -1191,7 +1191,7 @@ endpoint: ws://opamp-server:4320/v1/opamp
 				Log:    logr.Discard(),
 				Config: cfg,
 			})
-			params := reconciler.getParams(tt.args.instance)
+			params := reconciler.GetParams(tt.args.instance)
 			got, err := BuildOpAMPBridge(params)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("BuildAll() error = %v, wantErr %v", err, tt.wantErr)
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	instrumentationStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/instrumentation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation/rollout"
+)
+
+// InstrumentationReconciler reports the workloads injected from an Instrumentation in its status, and restarts the
+// ones running an outdated agent image according to its rollout policy.
+type InstrumentationReconciler struct {
+	client.Client
+	log     logr.Logger
+	rollout *rollout.Rollout
+}
+
+// InstrumentationReconcilerParams is the set of options to build a new InstrumentationReconciler.
+type InstrumentationReconcilerParams struct {
+	client.Client
+	Recorder record.EventRecorder
+	Log      logr.Logger
+}
+
+func NewInstrumentationReconciler(params InstrumentationReconcilerParams) *InstrumentationReconciler {
+	return &InstrumentationReconciler{
+		Client:  params.Client,
+		log:     params.Log,
+		rollout: rollout.New(params.Client, params.Log.WithName("rollout"), params.Recorder),
+	}
+}
+
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+
+// Reconcile updates the status of the Instrumentation from the pods it was injected into, and rolls out the workloads
+// running an outdated agent image.
+func (r *InstrumentationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("instrumentation", req.NamespacedName)
+
+	var instance v1alpha1.Instrumentation
+	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch Instrumentation")
+		}
+		// we'll ignore not-found errors, since they can't be fixed by an immediate
+		// requeue (we'll need to wait for a new notification), and we can get them
+		// on deleted requests.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// the injected pods may run in any namespace
+	var pods corev1.PodList
+	if err := r.Client.List(ctx, &pods, client.MatchingLabels{instrumentation.LabelInjected: "true"}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list the injected pods: %w", err)
+	}
+
+	status := instrumentationStatus.Status(log, instance, pods.Items)
+	lastRollout, requeueAfter, rolloutErr := r.rollout.Restart(ctx, instance, status.Workloads)
+	if rolloutErr != nil {
+		log.Error(rolloutErr, "failed to roll out the workloads running an outdated agent image")
+	}
+	status.LastRolloutTime = lastRollout
+
+	if !reflect.DeepEqual(status, instance.Status) {
+		changed := instance.DeepCopy()
+		changed.Status = status
+		if err := r.Client.Status().Patch(ctx, changed, client.MergeFrom(&instance)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the Instrumentation: %w", err)
+		}
+	}
+	if rolloutErr != nil {
+		return ctrl.Result{}, rolloutErr
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// SetupWithManager tells the manager what our controller is interested in. The cache of the manager is expected to
+// only hold the pods labeled as injected.
+func (r *InstrumentationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.Instrumentation{}).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.podToInstrumentations)).
+		Complete(r)
+}
+
+func (r *InstrumentationReconciler) podToInstrumentations(_ context.Context, obj client.Object) []reconcile.Request {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil
+	}
+	injections, err := instrumentation.GetInjections(*pod)
+	if err != nil {
+		r.log.V(1).Info("ignoring pod with invalid injections", "namespace", pod.Namespace, "name", pod.Name, "error", err.Error())
+		return nil
+	}
+	seen := map[string]bool{}
+	var requests []reconcile.Request
+	for _, injection := range injections {
+		if seen[injection.Instrumentation] {
+			continue
+		}
+		seen[injection.Instrumentation] = true
+		namespace, name, _ := strings.Cut(injection.Instrumentation, "/")
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}})
+	}
+	return requests
+}
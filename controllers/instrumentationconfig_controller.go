@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation/sdkconfig"
+)
+
+// InstrumentationConfigReconciler maintains the ConfigMap with the declarative configuration file of the SDKs of the
+// Instrumentations enabling it.
+type InstrumentationConfigReconciler struct {
+	client.Client
+	log      logr.Logger
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// InstrumentationConfigReconcilerParams is the set of options to build a new InstrumentationConfigReconciler.
+type InstrumentationConfigReconcilerParams struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Log      logr.Logger
+}
+
+func NewInstrumentationConfigReconciler(params InstrumentationConfigReconcilerParams) *InstrumentationConfigReconciler {
+	return &InstrumentationConfigReconciler{
+		Client:   params.Client,
+		log:      params.Log,
+		scheme:   params.Scheme,
+		recorder: params.Recorder,
+	}
+}
+
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile creates or updates the ConfigMap with the configuration file of the Instrumentation, or deletes it once
+// the Instrumentation no longer enables it. The ConfigMap is owned by the Instrumentation, and removed along with it.
+func (r *InstrumentationConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("instrumentation", req.NamespacedName)
+
+	var instance v1alpha1.Instrumentation
+	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch Instrumentation")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !instance.Spec.ConfigFile.Enabled {
+		return ctrl.Result{}, r.deleteConfigMap(ctx, instance)
+	}
+
+	desired, err := sdkconfig.ConfigMap(instance)
+	if err != nil {
+		r.recorder.Event(&instance, corev1.EventTypeWarning, "ConfigFile", err.Error())
+		return ctrl.Result{}, fmt.Errorf("failed to render the SDK configuration file: %w", err)
+	}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: desired.Namespace, Name: desired.Name}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		if !configMap.CreationTimestamp.IsZero() && !metav1.IsControlledBy(configMap, &instance) {
+			return fmt.Errorf("the ConfigMap %s isn't owned by the Instrumentation", configMap.Name)
+		}
+		if configMap.Labels == nil {
+			configMap.Labels = map[string]string{}
+		}
+		for k, v := range desired.Labels {
+			configMap.Labels[k] = v
+		}
+		configMap.Data = desired.Data
+		return controllerutil.SetControllerReference(&instance, configMap, r.scheme)
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to apply the SDK configuration file: %w", err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.V(2).Info("applied the SDK configuration file", "configmap", configMap.Name, "operation", op)
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *InstrumentationConfigReconciler) deleteConfigMap(ctx context.Context, instance v1alpha1.Instrumentation) error {
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: instance.Namespace, Name: sdkconfig.ConfigMapName(instance)}
+	if err := r.Client.Get(ctx, key, configMap); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(configMap, &instance) {
+		return nil
+	}
+	if err := r.Client.Delete(ctx, configMap); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the SDK configuration file: %w", err)
+	}
+	return nil
+}
+
+// SetupWithManager tells the manager what our controller is interested in.
+func (r *InstrumentationConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("instrumentation-config").
+		For(&v1alpha1.Instrumentation{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
@@ -50,7 +50,7 @@ type OpAMPBridgeReconcilerParams struct {
 	Config   config.Config
 }
 
-func (r *OpAMPBridgeReconciler) getParams(instance v1alpha1.OpAMPBridge) manifests.Params {
+func (r *OpAMPBridgeReconciler) GetParams(instance v1alpha1.OpAMPBridge) manifests.Params {
 	return manifests.Params{
 		Config:      r.config,
 		Client:      r.Client,
@@ -97,11 +97,11 @@ func (r *OpAMPBridgeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
-	params := r.getParams(instance)
+	params := r.GetParams(instance)
 
 	desiredObjects, buildErr := BuildOpAMPBridge(params)
 	if buildErr != nil {
-		return ctrl.Result{}, buildErr
+		return opampbridgeStatus.HandleReconcileStatus(ctx, log, params, buildErr)
 	}
 	err := reconcileDesiredObjects(ctx, r.Client, log, &params.OpAMPBridge, params.Scheme, desiredObjects, nil)
 	return opampbridgeStatus.HandleReconcileStatus(ctx, log, params, err)
@@ -18,6 +18,7 @@ package controllers
 import (
 	"context"
 	"sort"
+	"time"
 
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
@@ -35,26 +36,39 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	kedav1alpha1 "github.com/open-telemetry/opentelemetry-operator/internal/keda/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	internalRbac "github.com/open-telemetry/opentelemetry-operator/internal/rbac"
 	collectorStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collector"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
-const resourceOwnerKey = ".metadata.owner"
+const (
+	resourceOwnerKey  = ".metadata.owner"
+	configFragmentKey = ".spec.configFragments"
+)
 
 var (
 	ownedClusterObjectTypes = []client.Object{
@@ -86,6 +100,7 @@ type Params struct {
 func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Context, params manifests.Params) (map[types.UID]client.Object, error) {
 	ownedObjects := map[types.UID]client.Object{}
 	collectorConfigMaps := []*corev1.ConfigMap{}
+	nodePoolConfigMaps := []*corev1.ConfigMap{}
 	ownedObjectTypes := r.GetOwnedResourceTypes()
 	listOpts := []client.ListOption{
 		client.InNamespace(params.OtelCol.Namespace),
@@ -108,6 +123,11 @@ func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Cont
 					continue
 				}
 				configMap := object.(*corev1.ConfigMap)
+				if _, ok := object.GetLabels()[constants.LabelNodePool]; ok {
+					// the ConfigMaps of the node pools are kept per pool
+					nodePoolConfigMaps = append(nodePoolConfigMaps, configMap)
+					continue
+				}
 				collectorConfigMaps = append(collectorConfigMaps, configMap)
 			}
 		default:
@@ -121,6 +141,18 @@ func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Cont
 	for _, configMap := range configMapsToKeep {
 		delete(ownedObjects, configMap.GetUID())
 	}
+	for _, configMap := range getNodePoolConfigMapsToKeep(configVersionsToKeep, params.OtelCol.Spec.NodePools, nodePoolConfigMaps) {
+		delete(ownedObjects, configMap.GetUID())
+	}
+	// the collector Deployment keeps running the stable config while a new one is rolled out, however old it is
+	if rollout := params.OtelCol.Status.Rollout; rollout != nil && rollout.StableConfigVersion != "" {
+		stableConfigMap := naming.ConfigMap(params.OtelCol.Name, rollout.StableConfigVersion)
+		for _, configMap := range collectorConfigMaps {
+			if configMap.Name == stableConfigMap {
+				delete(ownedObjects, configMap.GetUID())
+			}
+		}
+	}
 
 	return ownedObjects, nil
 }
@@ -163,6 +195,21 @@ func getCollectorConfigMapsToKeep(configVersionsToKeep int, configMaps []*corev1
 	return configMaps[:configMapsToKeep]
 }
 
+// getNodePoolConfigMapsToKeep returns the configVersionsToKeep most recent ConfigMaps of each node pool of the
+// collector. The ConfigMaps of the pools that were removed aren't kept.
+func getNodePoolConfigMapsToKeep(configVersionsToKeep int, pools []v1beta1.NodePool, configMaps []*corev1.ConfigMap) []*corev1.ConfigMap {
+	byPool := map[string][]*corev1.ConfigMap{}
+	for _, configMap := range configMaps {
+		pool := configMap.GetLabels()[constants.LabelNodePool]
+		byPool[pool] = append(byPool[pool], configMap)
+	}
+	var configMapsToKeep []*corev1.ConfigMap
+	for _, pool := range pools {
+		configMapsToKeep = append(configMapsToKeep, getCollectorConfigMapsToKeep(configVersionsToKeep, byPool[pool.Name])...)
+	}
+	return configMapsToKeep
+}
+
 func (r *OpenTelemetryCollectorReconciler) GetParams(ctx context.Context, instance v1beta1.OpenTelemetryCollector) (manifests.Params, error) {
 	p := manifests.Params{
 		Config:   r.config,
@@ -174,6 +221,26 @@ func (r *OpenTelemetryCollectorReconciler) GetParams(ctx context.Context, instan
 		Reviewer: r.reviewer,
 	}
 
+	// merge the referenced config fragments before anything else looks at the collector config, a collector being
+	// deleted doesn't need them and shouldn't be blocked by a fragment that has been removed already
+	if instance.GetDeletionTimestamp() == nil {
+		appliedFragments, err := collector.ApplyConfigFragments(ctx, r.Client, &p.OtelCol)
+		if err != nil {
+			return p, collectorStatus.NewConfigError(err)
+		}
+		p.OtelCol.Status.ConfigFragments = appliedFragments
+	}
+	if featuregate.EnableHealthCheckInjection.IsEnabled() {
+		p.OtelCol.Spec.Config.InjectHealthCheck()
+	}
+
+	// track the version of the config being rolled out, the collector Deployment keeps the stable one meanwhile
+	configVersion, err := manifestutils.GetConfigMapSHA(p.OtelCol.Spec.Config)
+	if err != nil {
+		return p, collectorStatus.NewConfigError(err)
+	}
+	p.OtelCol.Status.Rollout = collectorStatus.Rollout(p.OtelCol, configVersion, time.Now())
+
 	// generate the target allocator CR from the collector CR
 	targetAllocator, err := r.getTargetAllocator(ctx, p)
 	if err != nil {
@@ -219,6 +286,8 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures;infrastructures/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/finalizers,verbs=get;update;patch
@@ -243,6 +312,9 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 	params, err := r.GetParams(ctx, instance)
 	if err != nil {
 		log.Error(err, "Failed to create manifest.Params")
+		if instance.GetDeletionTimestamp() == nil && instance.Spec.ManagementState != v1beta1.ManagementStateUnmanaged {
+			return collectorStatus.HandleReconcileStatus(ctx, log, params, instance, err)
+		}
 		return ctrl.Result{}, err
 	}
 
@@ -286,7 +358,7 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 
 	desiredObjects, buildErr := BuildCollector(params)
 	if buildErr != nil {
-		return ctrl.Result{}, buildErr
+		return collectorStatus.HandleReconcileStatus(ctx, log, params, instance, collectorStatus.NewConfigError(buildErr))
 	}
 
 	ownedObjects, err := r.findOtelOwnedObjects(ctx, params)
@@ -313,9 +385,82 @@ func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) er
 		builder.Owns(resource)
 	}
 
+	// watch the ConfigMaps holding config fragments, changes to them need to be rolled out to every collector
+	// referencing them
+	fragmentSelector := metav1.LabelSelector{
+		MatchLabels: map[string]string{constants.LabelConfigFragment: "true"},
+	}
+	fragmentPredicate, err := predicate.LabelSelectorPredicate(fragmentSelector)
+	if err != nil {
+		return err
+	}
+	builder.Watches(
+		&corev1.ConfigMap{},
+		handler.EnqueueRequestsFromMapFunc(r.getCollectorsForConfigFragment),
+		ctrlbuilder.WithPredicates(fragmentPredicate),
+	)
+
+	// watch the pods the sidecars are injected into, to report the ones running an outdated configuration
+	if featuregate.EnableSidecarConfigMap.IsEnabled() {
+		sidecarSelector := metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: constants.LabelSidecarInjected, Operator: metav1.LabelSelectorOpExists}},
+		}
+		sidecarPredicate, err := predicate.LabelSelectorPredicate(sidecarSelector)
+		if err != nil {
+			return err
+		}
+		builder.Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.getCollectorForSidecarPod),
+			ctrlbuilder.WithPredicates(sidecarPredicate, predicate.Funcs{
+				// the pods only need to be counted again once they are created or deleted
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return e.ObjectOld.GetDeletionTimestamp() == nil && e.ObjectNew.GetDeletionTimestamp() != nil
+				},
+			}),
+		)
+	}
+
 	return builder.Complete(r)
 }
 
+// getCollectorForSidecarPod returns the collector whose sidecar is injected into the given pod. The sidecars are
+// injected from collectors in the namespace of the pod, the label value may be truncated for long names.
+func (r *OpenTelemetryCollectorReconciler) getCollectorForSidecarPod(ctx context.Context, pod client.Object) []reconcile.Request {
+	injected := pod.GetLabels()[constants.LabelSidecarInjected]
+	if injected == "" {
+		return nil
+	}
+	collectors := &v1beta1.OpenTelemetryCollectorList{}
+	if err := r.List(ctx, collectors, client.InNamespace(pod.GetNamespace())); err != nil {
+		r.log.Error(err, "failed to list collectors for sidecar pod", "pod", client.ObjectKeyFromObject(pod))
+		return nil
+	}
+	for _, otelcol := range collectors.Items {
+		if naming.Truncate("%s.%s", 63, otelcol.Namespace, otelcol.Name) == injected {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: otelcol.Namespace, Name: otelcol.Name}}}
+		}
+	}
+	return nil
+}
+
+// getCollectorsForConfigFragment returns the collectors referencing the given config fragment.
+func (r *OpenTelemetryCollectorReconciler) getCollectorsForConfigFragment(ctx context.Context, configMap client.Object) []reconcile.Request {
+	collectors := &v1beta1.OpenTelemetryCollectorList{}
+	fragment := types.NamespacedName{Namespace: configMap.GetNamespace(), Name: configMap.GetName()}
+	if err := r.List(ctx, collectors, client.MatchingFields{configFragmentKey: fragment.String()}); err != nil {
+		r.log.Error(err, "failed to list collectors referencing config fragment", "configmap", fragment)
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(collectors.Items))
+	for _, otelcol := range collectors.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: otelcol.Namespace, Name: otelcol.Name},
+		})
+	}
+	return requests
+}
+
 // SetupCaches sets up caching and indexing for our controller.
 func (r *OpenTelemetryCollectorReconciler) SetupCaches(cluster cluster.Cluster) error {
 	ownedResources := r.GetOwnedResourceTypes()
@@ -335,7 +480,18 @@ func (r *OpenTelemetryCollectorReconciler) SetupCaches(cluster cluster.Cluster)
 			return err
 		}
 	}
-	return nil
+	return cluster.GetCache().IndexField(context.Background(), &v1beta1.OpenTelemetryCollector{}, configFragmentKey, func(rawObj client.Object) []string {
+		otelcol := rawObj.(*v1beta1.OpenTelemetryCollector)
+		var fragments []string
+		for _, ref := range otelcol.Spec.ConfigFragments {
+			namespace := ref.Namespace
+			if namespace == "" {
+				namespace = otelcol.Namespace
+			}
+			fragments = append(fragments, types.NamespacedName{Namespace: namespace, Name: ref.Name}.String())
+		}
+		return fragments
+	})
 }
 
 // GetOwnedResourceTypes returns all the resource types the controller can own. Even though this method returns an array
@@ -367,6 +523,15 @@ func (r *OpenTelemetryCollectorReconciler) GetOwnedResourceTypes() []client.Obje
 		ownedResources = append(ownedResources, &routev1.Route{})
 	}
 
+	if r.config.KEDAAvailability() == keda.Available {
+		ownedResources = append(ownedResources, &kedav1alpha1.ScaledObject{})
+	}
+
+	if r.config.GatewayAPIAvailability() == gatewayapi.Available {
+		ownedResources = append(ownedResources, &gatewayv1.HTTPRoute{})
+		ownedResources = append(ownedResources, &gatewayv1.GRPCRoute{})
+	}
+
 	return ownedResources
 }
 
@@ -21,6 +21,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 )
 
 func TestGetCollectorConfigMapsToKeep(t *testing.T) {
@@ -76,3 +79,29 @@ func TestGetCollectorConfigMapsToKeep(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNodePoolConfigMapsToKeep(t *testing.T) {
+	now := time.Now()
+	configMap := func(pool string, age time.Duration) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:              pool + "-" + age.String(),
+			Labels:            map[string]string{constants.LabelNodePool: pool},
+			CreationTimestamp: metav1.Time{Time: now.Add(-age)},
+		}}
+	}
+	input := []*corev1.ConfigMap{
+		configMap("gpu", time.Hour),
+		configMap("gpu", time.Minute),
+		configMap("gpu", time.Second),
+		configMap("arm", time.Hour),
+		configMap("removed", time.Second),
+	}
+	pools := []v1beta1.NodePool{{Name: "gpu"}, {Name: "arm"}}
+
+	actualOutput := getNodePoolConfigMapsToKeep(2, pools, input)
+	assert.Equal(t, []*corev1.ConfigMap{
+		configMap("gpu", time.Second),
+		configMap("gpu", time.Minute),
+		configMap("arm", time.Hour),
+	}, actualOutput)
+}
@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	collectorStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collector"
+)
+
+const (
+	defaultRolloutInterval = 30 * time.Second
+)
+
+// RolloutReconciler analyzes the configurations rolled out to the canary deployment of the collectors, and promotes
+// or rolls them back.
+type RolloutReconciler struct {
+	client.Client
+	apiReader  client.Reader
+	log        logr.Logger
+	recorder   record.EventRecorder
+	interval   time.Duration
+	httpClient *http.Client
+}
+
+// RolloutReconcilerParams is the set of options to build a new RolloutReconciler.
+type RolloutReconcilerParams struct {
+	client.Client
+	// APIReader reads the pods of the collectors, which the cache of the manager may not hold.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	Log       logr.Logger
+	// Interval between two analyses of a rollout, defaults to 30 seconds.
+	Interval time.Duration
+}
+
+func NewRolloutReconciler(params RolloutReconcilerParams) *RolloutReconciler {
+	interval := params.Interval
+	if interval == 0 {
+		interval = defaultRolloutInterval
+	}
+	return &RolloutReconciler{
+		Client:     params.Client,
+		apiReader:  params.APIReader,
+		log:        params.Log,
+		recorder:   params.Recorder,
+		interval:   interval,
+		httpClient: &http.Client{Timeout: scrapeTimeout},
+	}
+}
+
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
+
+// Reconcile analyzes the rollout of the collector configuration, and checks again after the configured interval
+// while the rollout is in progress. The collector reconciler then applies the promotion or the rollback.
+func (r *RolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("opentelemetrycollector", req.NamespacedName)
+
+	var instance v1beta1.OpenTelemetryCollector
+	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch OpenTelemetryCollector")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	rollout := instance.Status.Rollout
+	if instance.Spec.Rollout == nil || rollout == nil {
+		return ctrl.Result{}, nil
+	}
+
+	var next *v1beta1.RolloutStatus
+	switch rollout.Phase {
+	case v1beta1.RolloutPhaseProgressing:
+		health, err := r.candidateHealth(ctx, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if health == nil {
+			// the collector reconciler didn't create the canary deployment yet
+			return ctrl.Result{RequeueAfter: r.interval}, nil
+		}
+		next = collectorStatus.AnalyzeRollout(instance, *health, time.Now())
+	case v1beta1.RolloutPhasePromoting:
+		stable, err := r.deployment(ctx, instance.Namespace, naming.Collector(instance.Name))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if stable == nil || !rolledOut(stable, rollout.StableConfigVersion) {
+			return ctrl.Result{RequeueAfter: r.interval}, nil
+		}
+		next = rollout.DeepCopy()
+		next.Phase = v1beta1.RolloutPhaseSucceeded
+		next.CandidateConfigVersion = ""
+	default:
+		return ctrl.Result{}, nil
+	}
+
+	if !reflect.DeepEqual(next, rollout) {
+		changed := instance.DeepCopy()
+		changed.Status.Rollout = next
+		if err := r.Client.Status().Patch(ctx, changed, client.MergeFrom(&instance)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to apply the rollout status: %w", err)
+		}
+		if next.Phase != rollout.Phase {
+			eventType := corev1.EventTypeNormal
+			if next.Phase == v1beta1.RolloutPhaseRolledBack {
+				eventType = corev1.EventTypeWarning
+			}
+			r.recorder.Event(&instance, eventType, "Rollout", fmt.Sprintf("%s: %s", next.Phase, next.Message))
+		}
+		if next.Phase != v1beta1.RolloutPhaseProgressing && next.Phase != v1beta1.RolloutPhasePromoting {
+			return ctrl.Result{}, nil
+		}
+	}
+	return ctrl.Result{RequeueAfter: r.interval}, nil
+}
+
+// candidateHealth returns the health of the canary deployment running the candidate configuration, or nil when the
+// deployment doesn't exist yet.
+func (r *RolloutReconciler) candidateHealth(ctx context.Context, instance v1beta1.OpenTelemetryCollector) (*collectorStatus.RolloutHealth, error) {
+	version := instance.Status.Rollout.CandidateConfigVersion
+	canary, err := r.deployment(ctx, instance.Namespace, naming.CollectorCanary(instance.Name))
+	if err != nil || canary == nil {
+		return nil, err
+	}
+	health := &collectorStatus.RolloutHealth{Ready: rolledOut(canary, version)}
+	for _, condition := range canary.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			health.Failure = fmt.Sprintf("the canary deployment exceeded its progress deadline: %s", condition.Message)
+		}
+	}
+
+	_, port, err := instance.Spec.Config.Service.MetricsEndpoint(r.log)
+	if err != nil {
+		return nil, err
+	}
+	var pods corev1.PodList
+	// the cache of the manager may be restricted to the instrumented or sidecar pods, the canary pods are read from the
+	// API server
+	if err := r.apiReader.List(ctx, &pods, client.InNamespace(instance.Namespace), client.MatchingLabels(canary.Spec.Selector.MatchLabels)); err != nil {
+		return nil, fmt.Errorf("failed to list the pods of the canary deployment: %w", err)
+	}
+	for _, pod := range pods.Items {
+		// the pods of a previous candidate may still be terminating
+		if pod.Annotations[manifestutils.ConfigHashAnnotation] != version {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == naming.Container() {
+				health.Restarts += status.RestartCount
+			}
+		}
+		if !scrapable(pod) {
+			continue
+		}
+		if err := scrapeTelemetry(ctx, r.httpClient, pod, port, func(metrics io.Reader) error {
+			sent, failed, err := collectorStatus.ExportedItems(metrics)
+			health.SentItems += sent
+			health.FailedItems += failed
+			return err
+		}); err != nil {
+			// the other checks still apply without the export metrics
+			r.recorder.Event(&instance, corev1.EventTypeWarning, "Rollout", fmt.Sprintf("failed to read the items exported by the canary collector: %s", err))
+		}
+	}
+	return health, nil
+}
+
+func (r *RolloutReconciler) deployment(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	var deployment appsv1.Deployment
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get the deployment %s: %w", name, err)
+	}
+	return &deployment, nil
+}
+
+// rolledOut tells whether all the replicas of the deployment run the given configuration version and are available.
+func rolledOut(deployment *appsv1.Deployment, version string) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation] == version &&
+		deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas &&
+		deployment.Status.Replicas == replicas
+}
+
+// SetupWithManager tells the manager what our controller is interested in. The collector reconciler updates the
+// status when a rollout starts, so the status updates trigger new analyses, which are then refreshed periodically.
+func (r *RolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("rollout").
+		For(&v1beta1.OpenTelemetryCollector{}).
+		Complete(r)
+}
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	collectorStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collector"
+)
+
+const defaultScalingInterval = time.Minute
+
+// ScalingReconciler periodically computes the replicas of the collectors from their schedules and from the items
+// they receive, as reported by their telemetry metrics.
+type ScalingReconciler struct {
+	client.Client
+	apiReader  client.Reader
+	log        logr.Logger
+	recorder   record.EventRecorder
+	interval   time.Duration
+	httpClient *http.Client
+}
+
+// ScalingReconcilerParams is the set of options to build a new ScalingReconciler.
+type ScalingReconcilerParams struct {
+	client.Client
+	// APIReader reads the pods of the collectors, which the cache of the manager may not hold.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	Log       logr.Logger
+	// Interval between two evaluations of the scaling, defaults to 1 minute.
+	Interval time.Duration
+}
+
+func NewScalingReconciler(params ScalingReconcilerParams) *ScalingReconciler {
+	interval := params.Interval
+	if interval == 0 {
+		interval = defaultScalingInterval
+	}
+	return &ScalingReconciler{
+		Client:     params.Client,
+		apiReader:  params.APIReader,
+		log:        params.Log,
+		recorder:   params.Recorder,
+		interval:   interval,
+		httpClient: &http.Client{Timeout: scrapeTimeout},
+	}
+}
+
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+
+// Reconcile updates the scaling status of the collector, and checks again after the configured interval. The
+// collector reconciler then applies the replicas from the status to the workload of the collector.
+func (r *ScalingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("opentelemetrycollector", req.NamespacedName)
+
+	var instance v1beta1.OpenTelemetryCollector
+	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch OpenTelemetryCollector")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var status *v1beta1.ScalingStatus
+	if instance.Spec.Scaling != nil {
+		var receivedItems *int64
+		if instance.Spec.Scaling.IdleScaleDown != nil {
+			items, err := r.receivedItems(ctx, instance)
+			if err != nil {
+				r.recorder.Event(&instance, corev1.EventTypeWarning, "Scaling", fmt.Sprintf("failed to read the items received by the collector: %s", err))
+				return ctrl.Result{RequeueAfter: r.interval}, nil
+			}
+			receivedItems = items
+		}
+		var err error
+		if status, err = collectorStatus.Scaling(instance, time.Now(), receivedItems); err != nil {
+			r.recorder.Event(&instance, corev1.EventTypeWarning, "Scaling", err.Error())
+			return ctrl.Result{}, nil
+		}
+	}
+
+	if !reflect.DeepEqual(status, instance.Status.Scaling) {
+		changed := instance.DeepCopy()
+		changed.Status.Scaling = status
+		if err := r.Client.Status().Patch(ctx, changed, client.MergeFrom(&instance)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to apply the scaling status: %w", err)
+		}
+		if scalingChanged(instance.Status.Scaling, status) {
+			r.recorder.Event(&instance, corev1.EventTypeNormal, "Scaling", scalingMessage(status))
+		}
+	}
+	if instance.Spec.Scaling == nil {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: r.interval}, nil
+}
+
+// receivedItems returns the items received by all the running replicas of the collector, or nil when the collector
+// has no running replica.
+func (r *ScalingReconciler) receivedItems(ctx context.Context, instance v1beta1.OpenTelemetryCollector) (*int64, error) {
+	_, port, err := instance.Spec.Config.Service.MetricsEndpoint(r.log)
+	if err != nil {
+		return nil, err
+	}
+	var pods corev1.PodList
+	selector := manifestutils.SelectorLabels(instance.ObjectMeta, collector.ComponentOpenTelemetryCollector)
+	// the cache of the manager may be restricted to the instrumented or sidecar pods, the collector pods are read from
+	// the API server
+	if err := r.apiReader.List(ctx, &pods, client.InNamespace(instance.Namespace), client.MatchingLabels(selector)); err != nil {
+		return nil, fmt.Errorf("failed to list the pods of the collector: %w", err)
+	}
+
+	var total *int64
+	for _, pod := range pods.Items {
+		if !scrapable(pod) {
+			continue
+		}
+		var items int64
+		if err := scrapeTelemetry(ctx, r.httpClient, pod, port, func(metrics io.Reader) (err error) {
+			items, err = collectorStatus.ReceivedItems(metrics)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		if total == nil {
+			total = new(int64)
+		}
+		*total += items
+	}
+	return total, nil
+}
+
+// scalingChanged tells whether the replicas applied to the collector changed.
+func scalingChanged(previous, current *v1beta1.ScalingStatus) bool {
+	if previous == nil || current == nil {
+		return previous != current
+	}
+	return previous.Reason != current.Reason || previous.Schedule != current.Schedule ||
+		!reflect.DeepEqual(previous.Replicas, current.Replicas)
+}
+
+func scalingMessage(status *v1beta1.ScalingStatus) string {
+	switch {
+	case status == nil || status.Replicas == nil:
+		return "the collector is scaled from its spec"
+	case status.Reason == v1beta1.ScalingReasonSchedule:
+		return fmt.Sprintf("the collector is scaled to %d replicas by the %s schedule", *status.Replicas, status.Schedule)
+	default:
+		return fmt.Sprintf("the collector is scaled to %d replicas: %s", *status.Replicas, status.Reason)
+	}
+}
+
+// SetupWithManager tells the manager what our controller is interested in. The status updates of the collectors
+// don't trigger new evaluations, which are only refreshed periodically.
+func (r *ScalingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("scaling").
+		For(&v1beta1.OpenTelemetryCollector{}, ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
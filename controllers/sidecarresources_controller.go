@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	collectorStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+const defaultSidecarResourcesInterval = 5 * time.Minute
+
+var podMetricsListGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetricsList"}
+
+// podMetrics holds the fields of the metrics.k8s.io PodMetrics the recommendations are computed from.
+type podMetrics struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Containers        []struct {
+		Name  string              `json:"name"`
+		Usage corev1.ResourceList `json:"usage"`
+	} `json:"containers"`
+}
+
+// SidecarResourcesReconciler periodically recommends the resources of the sidecar collectors from the usage of the
+// pods they are injected into.
+type SidecarResourcesReconciler struct {
+	client.Client
+	log      logr.Logger
+	recorder record.EventRecorder
+	interval time.Duration
+}
+
+// SidecarResourcesReconcilerParams is the set of options to build a new SidecarResourcesReconciler.
+type SidecarResourcesReconcilerParams struct {
+	client.Client
+	Recorder record.EventRecorder
+	Log      logr.Logger
+	// Interval between two recommendations, defaults to 5 minutes.
+	Interval time.Duration
+}
+
+func NewSidecarResourcesReconciler(params SidecarResourcesReconcilerParams) *SidecarResourcesReconciler {
+	interval := params.Interval
+	if interval == 0 {
+		interval = defaultSidecarResourcesInterval
+	}
+	return &SidecarResourcesReconciler{
+		Client:   params.Client,
+		log:      params.Log,
+		recorder: params.Recorder,
+		interval: interval,
+	}
+}
+
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+
+// Reconcile updates the sidecar resource recommendations in the status of the collector, and checks again after the
+// configured interval.
+func (r *SidecarResourcesReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("opentelemetrycollector", req.NamespacedName)
+
+	var instance v1beta1.OpenTelemetryCollector
+	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch OpenTelemetryCollector")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var recommendations []v1beta1.SidecarResourcesRecommendation
+	if instance.Spec.Mode == v1beta1.ModeSidecar && instance.Spec.SidecarResources != nil {
+		// the sidecars may be injected into pods of other namespaces from a sidecar template
+		selector := client.MatchingLabels{constants.LabelSidecarInjected: naming.Truncate("%s.%s", 63, instance.Namespace, instance.Name)}
+		var pods corev1.PodList
+		if err := r.Client.List(ctx, &pods, selector); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to list the pods the sidecar is injected into: %w", err)
+		}
+		usage, err := r.sidecarUsage(ctx, selector)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				r.recorder.Event(&instance, corev1.EventTypeWarning, "SidecarResources", "the metrics.k8s.io API isn't available, the sidecar resources can't be recommended")
+				return ctrl.Result{RequeueAfter: r.interval}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to get the usage of the sidecars: %w", err)
+		}
+		recommendations = collectorStatus.SidecarResourceRecommendations(instance, pods.Items, usage)
+	}
+
+	if !reflect.DeepEqual(recommendations, instance.Status.SidecarResources) {
+		changed := instance.DeepCopy()
+		changed.Status.SidecarResources = recommendations
+		if err := r.Client.Status().Patch(ctx, changed, client.MergeFrom(&instance)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to apply the sidecar resource recommendations: %w", err)
+		}
+	}
+	if instance.Spec.SidecarResources == nil {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: r.interval}, nil
+}
+
+// sidecarUsage returns the usage of the sidecar container of the selected pods, from the metrics.k8s.io API.
+func (r *SidecarResourcesReconciler) sidecarUsage(ctx context.Context, selector client.MatchingLabels) (map[types.NamespacedName]corev1.ResourceList, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(podMetricsListGVK)
+	if err := r.Client.List(ctx, list, selector); err != nil {
+		return nil, err
+	}
+
+	usage := map[types.NamespacedName]corev1.ResourceList{}
+	for _, item := range list.Items {
+		var metrics podMetrics
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &metrics); err != nil {
+			return nil, fmt.Errorf("failed to read the metrics of pod %s/%s: %w", item.GetNamespace(), item.GetName(), err)
+		}
+		for _, container := range metrics.Containers {
+			if container.Name == naming.Container() {
+				usage[types.NamespacedName{Namespace: metrics.Namespace, Name: metrics.Name}] = container.Usage
+			}
+		}
+	}
+	return usage, nil
+}
+
+// SetupWithManager tells the manager what our controller is interested in. The status updates of the collectors
+// don't trigger new recommendations, which are only refreshed periodically.
+func (r *SidecarResourcesReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("sidecar-resources").
+		For(&v1beta1.OpenTelemetryCollector{}, ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
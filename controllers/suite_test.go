@@ -57,6 +57,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
@@ -101,6 +103,8 @@ type mockAutoDetect struct {
 	PrometheusCRsAvailabilityFunc   func() (prometheus.Availability, error)
 	RBACPermissionsFunc             func(ctx context.Context) (autoRBAC.Availability, error)
 	CertManagerAvailabilityFunc     func(ctx context.Context) (certmanager.Availability, error)
+	GatewayAPIAvailabilityFunc      func() (gatewayapi.Availability, error)
+	KEDAAvailabilityFunc            func() (keda.Availability, error)
 }
 
 func (m *mockAutoDetect) FIPSEnabled(ctx context.Context) bool {
@@ -135,6 +139,20 @@ func (m *mockAutoDetect) CertManagerAvailability(ctx context.Context) (certmanag
 	return certmanager.NotAvailable, nil
 }
 
+func (m *mockAutoDetect) GatewayAPIAvailability() (gatewayapi.Availability, error) {
+	if m.GatewayAPIAvailabilityFunc != nil {
+		return m.GatewayAPIAvailabilityFunc()
+	}
+	return gatewayapi.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) KEDAAvailability() (keda.Availability, error) {
+	if m.KEDAAvailabilityFunc != nil {
+		return m.KEDAAvailabilityFunc()
+	}
+	return keda.NotAvailable, nil
+}
+
 func TestMain(m *testing.M) {
 	var err error
 	ctx, cancel = context.WithCancel(context.TODO())
@@ -64,7 +64,7 @@ type TargetAllocatorReconcilerParams struct {
 	Config   config.Config
 }
 
-func (r *TargetAllocatorReconciler) getParams(ctx context.Context, instance v1alpha1.TargetAllocator) (targetallocator.Params, error) {
+func (r *TargetAllocatorReconciler) GetParams(ctx context.Context, instance v1alpha1.TargetAllocator) (targetallocator.Params, error) {
 	collector, err := r.getCollector(ctx, instance)
 	if err != nil {
 		return targetallocator.Params{}, err
@@ -171,13 +171,13 @@ func (r *TargetAllocatorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, nil
 	}
 
-	params, err := r.getParams(ctx, instance)
+	params, err := r.GetParams(ctx, instance)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 	desiredObjects, buildErr := BuildTargetAllocator(params)
 	if buildErr != nil {
-		return ctrl.Result{}, buildErr
+		return taStatus.HandleReconcileStatus(ctx, log, params, buildErr)
 	}
 
 	err = reconcileDesiredObjects(ctx, r.Client, log, &params.TargetAllocator, params.Scheme, desiredObjects, nil)
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation/workload"
+)
+
+// annotationPrefix is the prefix of the annotations requesting the auto-instrumentation.
+const annotationPrefix = "instrumentation.opentelemetry.io/"
+
+// WorkloadInstrumentationReconciler injects the auto-instrumentation into the pod templates of the workloads of one
+// kind, instead of the pod mutation webhook injecting it into their pods.
+type WorkloadInstrumentationReconciler struct {
+	client.Client
+	log      logr.Logger
+	kind     workload.Kind
+	injector *workload.Injector
+}
+
+// WorkloadInstrumentationReconcilerParams is the set of options to build new WorkloadInstrumentationReconcilers.
+type WorkloadInstrumentationReconcilerParams struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Log      logr.Logger
+	Config   config.Config
+}
+
+// NewWorkloadInstrumentationReconcilers creates a reconciler for each kind of workload.
+func NewWorkloadInstrumentationReconcilers(params WorkloadInstrumentationReconcilerParams) []*WorkloadInstrumentationReconciler {
+	injector := workload.NewInjector(params.Client, params.Scheme, params.Log, params.Recorder, params.Config)
+	var reconcilers []*WorkloadInstrumentationReconciler
+	for _, kind := range workload.Kinds {
+		reconcilers = append(reconcilers, &WorkloadInstrumentationReconciler{
+			Client:   params.Client,
+			log:      params.Log.WithValues("kind", kind.Name),
+			kind:     kind,
+			injector: injector,
+		})
+	}
+	return reconcilers
+}
+
+//+kubebuilder:rbac:groups=apps,resources=daemonsets;deployments;statefulsets,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations,verbs=get;list;watch
+
+// Reconcile injects the auto-instrumentation requested by the pod template of the workload, or reverts it.
+func (r *WorkloadInstrumentationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	obj := r.kind.NewObject()
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		// the injection is removed along with the workload
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if err := r.injector.Inject(ctx, obj); err != nil {
+		r.log.Error(err, "failed to inject the auto-instrumentation into the pod template", "workload", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager tells the manager what our controller is interested in. The workloads are reconciled again when
+// their namespace changes, or when the spec of an Instrumentation that may apply to them changes.
+func (r *WorkloadInstrumentationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("instrumentation-"+strings.ToLower(r.kind.Name)).
+		For(r.kind.NewObject()).
+		Watches(&v1alpha1.Instrumentation{}, handler.EnqueueRequestsFromMapFunc(r.instrumentationToWorkloads), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.namespaceToWorkloads)).
+		Complete(r)
+}
+
+// instrumentationToWorkloads requests the reconciliation of the workloads the Instrumentation may apply to: the ones
+// of its namespace, of the namespaces its selector may select, and the ones referencing it as <namespace>/<name> from
+// their pod template or namespace annotations. On updates, it's called for both the old and new Instrumentation, so
+// the workloads it no longer applies to are reconciled too.
+func (r *WorkloadInstrumentationReconciler) instrumentationToWorkloads(ctx context.Context, obj client.Object) []reconcile.Request {
+	inst, ok := obj.(*v1alpha1.Instrumentation)
+	if !ok {
+		return nil
+	}
+	var namespaces corev1.NamespaceList
+	if err := r.Client.List(ctx, &namespaces); err != nil {
+		r.log.Error(err, "failed to list the namespaces to reconcile")
+		return nil
+	}
+	reference := inst.Namespace + "/" + inst.Name
+	affected := map[string]bool{inst.Namespace: true}
+	for _, ns := range namespaces.Items {
+		if instrumentation.SelectsNamespace(*inst, ns) || referencesInstrumentation(ns.Annotations, reference) {
+			affected[ns.Name] = true
+		}
+	}
+	return r.workloadsIn(ctx, "", func(obj client.Object) bool {
+		if affected[obj.GetNamespace()] {
+			return true
+		}
+		template, err := workload.PodTemplate(obj)
+		return err == nil && referencesInstrumentation(template.Annotations, reference)
+	})
+}
+
+func (r *WorkloadInstrumentationReconciler) namespaceToWorkloads(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.workloadsIn(ctx, obj.GetName(), nil)
+}
+
+// workloadsIn requests the reconciliation of the workloads in the namespace, or in all namespaces when it's empty,
+// that match the filter, if any.
+func (r *WorkloadInstrumentationReconciler) workloadsIn(ctx context.Context, namespace string, filter func(client.Object) bool) []reconcile.Request {
+	list := r.kind.NewList()
+	if err := r.Client.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		r.log.Error(err, "failed to list the workloads to reconcile")
+		return nil
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		r.log.Error(err, "failed to list the workloads to reconcile")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(items))
+	for _, item := range items {
+		if obj, ok := item.(client.Object); ok && (filter == nil || filter(obj)) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+		}
+	}
+	return requests
+}
+
+// referencesInstrumentation returns whether one of the auto-instrumentation annotations references the Instrumentation
+// <namespace>/<name>. Values containing it as a part, e.g. container mappings, are considered references too.
+func referencesInstrumentation(annotations map[string]string, reference string) bool {
+	for key, value := range annotations {
+		if strings.HasPrefix(key, annotationPrefix) && strings.Contains(value, reference) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation/workload"
+)
+
+func TestInstrumentationToWorkloads(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, v1alpha1.AddToScheme(s))
+
+	namespace := func(name string, labels, annotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations}}
+	}
+	deployment := func(namespace, name string, annotations map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}},
+			},
+		}
+	}
+	objects := []client.Object{
+		namespace("observability", nil, nil),
+		namespace("shop", map[string]string{"team": "shop"}, map[string]string{instrumentation.AnnotationAllowedInstrumentations: "observability/java"}),
+		namespace("search", map[string]string{"team": "search"}, nil),
+		namespace("billing", nil, map[string]string{"instrumentation.opentelemetry.io/inject-java": "observability/java"}),
+		namespace("other", nil, nil),
+		deployment("observability", "local", nil),
+		deployment("shop", "cart", nil),
+		deployment("search", "index", nil),
+		deployment("billing", "invoices", nil),
+		deployment("other", "referencing", map[string]string{"instrumentation.opentelemetry.io/inject-java": "observability/java"}),
+		deployment("other", "unrelated", map[string]string{"instrumentation.opentelemetry.io/inject-java": "true"}),
+	}
+	r := &WorkloadInstrumentationReconciler{
+		Client: fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build(),
+		log:    logr.Discard(),
+		kind:   workload.Kinds[0],
+	}
+
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "observability", Name: "java"},
+		Spec: v1alpha1.InstrumentationSpec{
+			Selector: &v1alpha1.InstrumentationSelector{
+				// search is selected but didn't opt in to the Instrumentation
+				NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: metav1.LabelSelectorOpExists},
+				}},
+				Languages: []v1alpha1.InstrumentationLanguage{v1alpha1.LanguageJava},
+			},
+		},
+	}
+
+	var reconciled []string
+	for _, req := range r.instrumentationToWorkloads(context.Background(), inst) {
+		reconciled = append(reconciled, req.String())
+	}
+	assert.ElementsMatch(t, []string{"observability/local", "shop/cart", "billing/invoices", "other/referencing"}, reconciled)
+}
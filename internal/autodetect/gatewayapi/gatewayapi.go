@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gatewayapi holds the auto-detected availability of the Gateway API.
+package gatewayapi
+
+// Availability represents that the Gateway API HTTPRoute and GRPCRoute resources are available.
+type Availability int
+
+const (
+	// NotAvailable represents the gateway.networking.k8s.io HTTPRoute and GRPCRoute resources are not available.
+	NotAvailable Availability = iota
+
+	// Available represents the gateway.networking.k8s.io HTTPRoute and GRPCRoute resources are available.
+	Available
+)
+
+func (a Availability) String() string {
+	return [...]string{"NotAvailable", "Available"}[a]
+}
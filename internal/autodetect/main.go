@@ -24,6 +24,8 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/fips"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
@@ -39,6 +41,8 @@ type AutoDetect interface {
 	RBACPermissions(ctx context.Context) (autoRBAC.Availability, error)
 	CertManagerAvailability(ctx context.Context) (certmanager.Availability, error)
 	FIPSEnabled(ctx context.Context) bool
+	GatewayAPIAvailability() (gatewayapi.Availability, error)
+	KEDAAvailability() (keda.Availability, error)
 }
 
 type autoDetect struct {
@@ -115,6 +119,76 @@ func (a *autoDetect) OpenShiftRoutesAvailability() (openshift.RoutesAvailability
 	return openshift.RoutesNotAvailable, nil
 }
 
+// GatewayAPIAvailability checks if the Gateway API HTTPRoute and GRPCRoute resources are available.
+func (a *autoDetect) GatewayAPIAvailability() (gatewayapi.Availability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return gatewayapi.NotAvailable, err
+	}
+
+	foundHTTPRoute := false
+	foundGRPCRoute := false
+	apiGroups := apiList.Groups
+	for i := 0; i < len(apiGroups); i++ {
+		if apiGroups[i].Name == "gateway.networking.k8s.io" {
+			for _, version := range apiGroups[i].Versions {
+				if version.Version != "v1" {
+					continue
+				}
+				resources, err := a.dcl.ServerResourcesForGroupVersion(version.GroupVersion)
+				if err != nil {
+					return gatewayapi.NotAvailable, err
+				}
+
+				for _, resource := range resources.APIResources {
+					if resource.Kind == "HTTPRoute" {
+						foundHTTPRoute = true
+					} else if resource.Kind == "GRPCRoute" {
+						foundGRPCRoute = true
+					}
+				}
+			}
+		}
+	}
+
+	if foundHTTPRoute && foundGRPCRoute {
+		return gatewayapi.Available, nil
+	}
+
+	return gatewayapi.NotAvailable, nil
+}
+
+// KEDAAvailability checks if the KEDA ScaledObject resource is available.
+func (a *autoDetect) KEDAAvailability() (keda.Availability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return keda.NotAvailable, err
+	}
+
+	apiGroups := apiList.Groups
+	for i := 0; i < len(apiGroups); i++ {
+		if apiGroups[i].Name == "keda.sh" {
+			for _, version := range apiGroups[i].Versions {
+				if version.Version != "v1alpha1" {
+					continue
+				}
+				resources, err := a.dcl.ServerResourcesForGroupVersion(version.GroupVersion)
+				if err != nil {
+					return keda.NotAvailable, err
+				}
+
+				for _, resource := range resources.APIResources {
+					if resource.Kind == "ScaledObject" {
+						return keda.Available, nil
+					}
+				}
+			}
+		}
+	}
+
+	return keda.NotAvailable, nil
+}
+
 func (a *autoDetect) RBACPermissions(ctx context.Context) (autoRBAC.Availability, error) {
 	w, err := autoRBAC.CheckRBACPermissions(ctx, a.reviewer)
 	if err != nil {
@@ -35,6 +35,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/autodetectutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
@@ -167,6 +169,128 @@ func TestDetectPlatformBasedOnAvailableAPIGroupsPrometheus(t *testing.T) {
 	}
 }
 
+func TestDetectGatewayAPIAvailability(t *testing.T) {
+	gatewayGroup := &metav1.APIGroupList{
+		Groups: []metav1.APIGroup{
+			{
+				Name: "gateway.networking.k8s.io",
+				Versions: []metav1.GroupVersionForDiscovery{
+					{GroupVersion: "gateway.networking.k8s.io/v1", Version: "v1"},
+				},
+			},
+		},
+	}
+	for _, tt := range []struct {
+		apiGroupList *metav1.APIGroupList
+		resources    *metav1.APIResourceList
+		expected     gatewayapi.Availability
+	}{
+		{
+			&metav1.APIGroupList{},
+			&metav1.APIResourceList{},
+			gatewayapi.NotAvailable,
+		},
+		{
+			gatewayGroup,
+			&metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "Gateway"}, {Kind: "HTTPRoute"}},
+			},
+			gatewayapi.NotAvailable,
+		},
+		{
+			gatewayGroup,
+			&metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "Gateway"}, {Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+			},
+			gatewayapi.Available,
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var output []byte
+			var err error
+			if req.URL.Path == "/apis" {
+				output, err = json.Marshal(tt.apiGroupList)
+			} else {
+				output, err = json.Marshal(tt.resources)
+			}
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(output)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+		require.NoError(t, err)
+
+		// test
+		availability, err := autoDetect.GatewayAPIAvailability()
+
+		// verify
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, availability)
+	}
+}
+
+func TestDetectKEDAAvailability(t *testing.T) {
+	for _, tt := range []struct {
+		apiGroupList *metav1.APIGroupList
+		resources    *metav1.APIResourceList
+		expected     keda.Availability
+	}{
+		{
+			&metav1.APIGroupList{},
+			&metav1.APIResourceList{},
+			keda.NotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name: "keda.sh",
+						Versions: []metav1.GroupVersionForDiscovery{
+							{GroupVersion: "keda.sh/v1alpha1", Version: "v1alpha1"},
+						},
+					},
+				},
+			},
+			&metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "ScaledJob"}, {Kind: "ScaledObject"}},
+			},
+			keda.Available,
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var output []byte
+			var err error
+			if req.URL.Path == "/apis" {
+				output, err = json.Marshal(tt.apiGroupList)
+			} else {
+				output, err = json.Marshal(tt.resources)
+			}
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(output)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+		require.NoError(t, err)
+
+		// test
+		availability, err := autoDetect.KEDAAvailability()
+
+		// verify
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, availability)
+	}
+}
+
 type fakeClientGenerator func() kubernetes.Interface
 
 const (
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/mitchellh/mapstructure"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	DefaultHealthcheckV2HTTPPort   = 13133
+	DefaultHealthcheckV2GRPCPort   = 13132
+	DefaultHealthcheckV2StatusPath = "/status"
+)
+
+type healthcheckV2Config struct {
+	// the legacy settings are the ones of the healthcheck v1 extension, they're used unless use_v2 is set
+	components.SingleEndpointConfig `mapstructure:",squash"`
+	Path                            string                   `mapstructure:"path"`
+	UseV2                           bool                     `mapstructure:"use_v2"`
+	HTTP                            *healthcheckV2HTTPConfig `mapstructure:"http"`
+	GRPC                            *healthcheckV2GRPCConfig `mapstructure:"grpc"`
+}
+
+type healthcheckV2HTTPConfig struct {
+	components.SingleEndpointConfig `mapstructure:",squash"`
+	Status                          healthcheckV2PathConfig `mapstructure:"status"`
+}
+
+type healthcheckV2PathConfig struct {
+	Enabled *bool  `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"`
+}
+
+type healthcheckV2GRPCConfig struct {
+	components.SingleEndpointConfig `mapstructure:",squash"`
+}
+
+// HealthCheckV2Probe returns the probe configuration for the healthcheck v2 extension. The HTTP status endpoint is
+// preferred over the gRPC health service, the probe can be narrowed down to a single pipeline with
+// ApplyProbePipeline. In legacy mode, the probe is the one of the healthcheck v1 extension.
+// Right now no TLS config is parsed.
+func HealthCheckV2Probe(logger logr.Logger, config healthcheckV2Config) (*corev1.Probe, error) {
+	if !config.UseV2 {
+		return HealthCheckV1Probe(logger, healthcheckV1Config{SingleEndpointConfig: config.SingleEndpointConfig, Path: config.Path})
+	}
+	if config.HTTP != nil && (config.HTTP.Status.Enabled == nil || *config.HTTP.Status.Enabled) {
+		path := config.HTTP.Status.Path
+		if len(path) == 0 {
+			path = DefaultHealthcheckV2StatusPath
+		}
+		return &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: path,
+					Port: intstr.FromInt32(config.HTTP.GetPortNumOrDefault(logger, DefaultHealthcheckV2HTTPPort)),
+				},
+			},
+		}, nil
+	}
+	if config.GRPC != nil {
+		return &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				GRPC: &corev1.GRPCAction{
+					Port: config.GRPC.GetPortNumOrDefault(logger, DefaultHealthcheckV2GRPCPort),
+				},
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
+// ApplyProbePipeline narrows down a probe generated by the healthcheck v2 extension to the health of the given
+// pipeline.
+func ApplyProbePipeline(probe *corev1.Probe, pipeline string) {
+	if probe == nil || len(pipeline) == 0 {
+		return
+	}
+	if probe.HTTPGet != nil {
+		probe.HTTPGet.Path = fmt.Sprintf("%s?pipeline=%s", probe.HTTPGet.Path, pipeline)
+	}
+	if probe.GRPC != nil {
+		probe.GRPC.Service = &pipeline
+	}
+}
+
+// SupportsProbePipeline returns whether the probe generated for the extension with the given name and configuration
+// can be narrowed down to a pipeline with ApplyProbePipeline. Only the healthcheck v2 extension, outside of its legacy
+// mode, reports the health of single pipelines.
+func SupportsProbePipeline(name string, config interface{}) bool {
+	if components.ComponentType(name) != "healthcheckv2" {
+		return false
+	}
+	parsed := healthcheckV2Config{}
+	if err := mapstructure.Decode(config, &parsed); err != nil {
+		return false
+	}
+	return parsed.UseV2
+}
+
+func healthCheckV2Ports(logger logr.Logger, name string, defaultPort *corev1.ServicePort, config healthcheckV2Config) ([]corev1.ServicePort, error) {
+	if !config.UseV2 {
+		return components.ParseSingleEndpointSilent(logger, name, defaultPort, &config.SingleEndpointConfig)
+	}
+	var ports []corev1.ServicePort
+	if config.HTTP != nil {
+		port := config.HTTP.GetPortNumOrDefault(logger, DefaultHealthcheckV2HTTPPort)
+		ports = append(ports, corev1.ServicePort{
+			Name: naming.PortName(fmt.Sprintf("%s-http", name), port),
+			Port: port,
+		})
+	}
+	if config.GRPC != nil {
+		port := config.GRPC.GetPortNumOrDefault(logger, DefaultHealthcheckV2GRPCPort)
+		ports = append(ports, corev1.ServicePort{
+			Name: naming.PortName(fmt.Sprintf("%s-grpc", name), port),
+			Port: port,
+		})
+	}
+	return ports, nil
+}
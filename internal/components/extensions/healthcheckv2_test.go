@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/extensions"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+func TestHealthCheckV2Probe(t *testing.T) {
+	tests := []struct {
+		name   string
+		config interface{}
+		want   *corev1.Probe
+	}{
+		{
+			name: "legacy mode",
+			config: map[string]interface{}{
+				"endpoint": "0.0.0.0:8080",
+				"path":     "/healthz",
+			},
+			want: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{
+						Path: "/healthz",
+						Port: intstr.FromInt32(8080),
+					},
+				},
+			},
+		},
+		{
+			name: "http status with defaults",
+			config: map[string]interface{}{
+				"use_v2": true,
+				"http":   map[string]interface{}{},
+			},
+			want: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{
+						Path: extensions.DefaultHealthcheckV2StatusPath,
+						Port: intstr.FromInt32(extensions.DefaultHealthcheckV2HTTPPort),
+					},
+				},
+			},
+		},
+		{
+			name: "http status with custom path and port",
+			config: map[string]interface{}{
+				"use_v2": true,
+				"http": map[string]interface{}{
+					"endpoint": "0.0.0.0:9090",
+					"status": map[string]interface{}{
+						"path": "/health/status",
+					},
+				},
+				"grpc": map[string]interface{}{},
+			},
+			want: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{
+						Path: "/health/status",
+						Port: intstr.FromInt32(9090),
+					},
+				},
+			},
+		},
+		{
+			name: "grpc when http status is disabled",
+			config: map[string]interface{}{
+				"use_v2": true,
+				"http": map[string]interface{}{
+					"status": map[string]interface{}{
+						"enabled": false,
+					},
+				},
+				"grpc": map[string]interface{}{
+					"endpoint": "0.0.0.0:4444",
+				},
+			},
+			want: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					GRPC: &corev1.GRPCAction{
+						Port: 4444,
+					},
+				},
+			},
+		},
+		{
+			name: "no endpoint",
+			config: map[string]interface{}{
+				"use_v2": true,
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := extensions.ParserFor("healthcheckv2")
+			got, err := parser.GetReadinessProbe(logr.Discard(), tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyProbePipeline(t *testing.T) {
+	httpProbe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{Path: "/status"},
+		},
+	}
+	extensions.ApplyProbePipeline(httpProbe, "traces")
+	assert.Equal(t, "/status?pipeline=traces", httpProbe.HTTPGet.Path)
+
+	grpcProbe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			GRPC: &corev1.GRPCAction{Port: 13132},
+		},
+	}
+	extensions.ApplyProbePipeline(grpcProbe, "traces")
+	require.NotNil(t, grpcProbe.GRPC.Service)
+	assert.Equal(t, "traces", *grpcProbe.GRPC.Service)
+
+	unchanged := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{Path: "/status"},
+		},
+	}
+	extensions.ApplyProbePipeline(unchanged, "")
+	assert.Equal(t, "/status", unchanged.HTTPGet.Path)
+}
+
+func TestSupportsProbePipeline(t *testing.T) {
+	assert.True(t, extensions.SupportsProbePipeline("healthcheckv2/internal", map[string]interface{}{"use_v2": true}))
+	assert.False(t, extensions.SupportsProbePipeline("healthcheckv2", map[string]interface{}{"endpoint": "0.0.0.0:13133"}))
+	assert.False(t, extensions.SupportsProbePipeline("health_check", map[string]interface{}{"use_v2": true}))
+}
+
+func TestHealthCheckV2Ports(t *testing.T) {
+	parser := extensions.ParserFor("healthcheckv2")
+	ports, err := parser.Ports(logr.Discard(), "healthcheckv2", map[string]interface{}{
+		"use_v2": true,
+		"http": map[string]interface{}{
+			"endpoint": "0.0.0.0:8080",
+		},
+		"grpc": map[string]interface{}{},
+	})
+	require.NoError(t, err)
+	require.Len(t, ports, 2)
+	assert.EqualValues(t, 8080, ports[0].Port)
+	assert.Equal(t, naming.PortName("healthcheckv2-http", 8080), ports[0].Name)
+	assert.EqualValues(t, extensions.DefaultHealthcheckV2GRPCPort, ports[1].Port)
+	assert.Equal(t, naming.PortName("healthcheckv2-grpc", extensions.DefaultHealthcheckV2GRPCPort), ports[1].Name)
+}
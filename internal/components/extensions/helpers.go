@@ -55,6 +55,13 @@ var (
 				return components.ParseSingleEndpointSilent(logger, name, defaultPort, &config.SingleEndpointConfig)
 			}).
 			MustBuild(),
+		components.NewBuilder[healthcheckV2Config]().
+			WithName("healthcheckv2").
+			WithPort(DefaultHealthcheckV2HTTPPort).
+			WithReadinessGen(HealthCheckV2Probe).
+			WithLivenessGen(HealthCheckV2Probe).
+			WithPortParser(healthCheckV2Ports).
+			MustBuild(),
 		components.NewSinglePortParserBuilder("jaeger_query", 16686).
 			WithTargetPort(16686).
 			MustBuild(),
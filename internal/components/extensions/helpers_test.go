@@ -57,6 +57,7 @@ func TestExtensionsComponentParsers(t *testing.T) {
 		defaultPort  int32
 	}{
 		{"health_check", "__health_check", 13133},
+		{"healthcheckv2", "__healthcheckv2", 13133},
 	} {
 		t.Run(tt.exporterName, func(t *testing.T) {
 			t.Run("is registered", func(t *testing.T) {
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components
+
+// knownComponents lists, by kind, the component types of the core and contrib distributions of the collector.
+// Most of them don't need a parser, the operator derives nothing from their configuration.
+var knownComponents = map[string]map[string]struct{}{
+	"receiver": setOf(
+		"active_directory_ds", "aerospike", "apache", "apachespark", "awscloudwatch", "awscontainerinsightreceiver",
+		"awsecscontainermetrics", "awsfirehose", "awss3", "awsxray", "azureblob", "azureeventhub", "azuremonitor",
+		"bigip", "carbon", "chrony", "cloudflare", "cloudfoundry", "collectd", "couchdb", "datadog", "docker_stats",
+		"elasticsearch", "expvar", "filelog", "filestats", "flinkmetrics", "fluentforward", "github",
+		"googlecloudmonitoring", "googlecloudpubsub", "googlecloudspanner", "haproxy", "hostmetrics", "httpcheck",
+		"iis", "influxdb", "jaeger", "jmx", "journald", "k8s_cluster", "k8s_events", "k8sobjects", "kafka",
+		"kafkametrics", "kubeletstats", "loki", "memcached", "mongodb", "mongodbatlas", "mysql", "namedpipe",
+		"netflow", "nginx", "nop", "nsxt", "ntp", "opencensus", "oracledb", "osquery", "otelarrow", "otlp",
+		"otlpjsonfile", "podman_stats", "postgresql", "prometheus", "prometheus_simple", "pulsar", "purefa", "purefb",
+		"rabbitmq", "receiver_creator", "redis", "riak", "saphana", "sapm", "signalfx", "skywalking", "snmp",
+		"snowflake", "solace", "splunk_hec", "splunkenterprise", "sqlquery", "sqlserver", "sshcheck", "statsd",
+		"syslog", "systemd", "tcplog", "tlscheck", "udplog", "vcenter", "wavefront", "webhookevent",
+		"windowseventlog", "windowsperfcounters", "zipkin", "zookeeper",
+	),
+	"processor": setOf(
+		"attributes", "batch", "coralogix", "cumulativetodelta", "deltatocumulative", "deltatorate",
+		"experimental_metricsgeneration", "filter", "geoip", "groupbyattrs", "groupbytrace", "interval",
+		"k8sattributes", "logdedup", "memory_limiter", "metricsgeneration", "metricstransform",
+		"probabilistic_sampler", "redaction", "remotetap", "resource", "resourcedetection", "routing", "schema",
+		"span", "sumologic", "tail_sampling", "transform",
+	),
+	"exporter": setOf(
+		"alertmanager", "alibabacloud_logservice", "awscloudwatchlogs", "awsemf", "awskinesis", "awss3", "awsxray",
+		"azuredataexplorer", "azuremonitor", "carbon", "cassandra", "clickhouse", "coralogix", "datadog", "dataset",
+		"debug", "elasticsearch", "file", "googlecloud", "googlecloudpubsub", "googlemanagedprometheus",
+		"honeycombmarker", "influxdb", "kafka", "kinetica", "loadbalancing", "logging", "logicmonitor", "logzio",
+		"loki", "mezmo", "nop", "opencensus", "opensearch", "otelarrow", "otlp", "otlphttp", "prometheus",
+		"prometheusremotewrite", "pulsar", "rabbitmq", "sapm", "sentry", "signalfx", "splunk_hec", "sumologic",
+		"syslog", "tencentcloud_logservice", "zipkin",
+	),
+	"extension": setOf(
+		"ack", "asapclient", "avro_log_encoding", "awsproxy", "basicauth", "bearertokenauth", "cgroupruntime",
+		"db_storage", "docker_observer", "ecs_observer", "ecs_task_observer", "file_storage", "googleclientauth",
+		"headers_setter", "health_check", "healthcheckv2", "host_observer", "http_forwarder", "jaeger_encoding",
+		"jaegerremotesampling", "json_log_encoding", "k8s_observer", "memory_ballast", "oauth2client", "oidc",
+		"opamp", "otlp_encoding", "pprof", "sigv4auth", "sumologic", "text_encoding", "zipkin_encoding", "zpages",
+	),
+}
+
+// IsKnown returns whether the component type is part of the core or contrib distributions of the collector, for the
+// given kind: receiver, processor, exporter or extension.
+func IsKnown(kind, componentType string) bool {
+	_, ok := knownComponents[kind][componentType]
+	return ok
+}
+
+func setOf(values ...string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
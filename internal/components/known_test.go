@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+func TestIsKnown(t *testing.T) {
+	for _, tt := range []struct {
+		kind          string
+		componentType string
+		expected      bool
+	}{
+		{kind: "processor", componentType: "batch", expected: true},
+		{kind: "exporter", componentType: "debug", expected: true},
+		{kind: "extension", componentType: "file_storage", expected: true},
+		{kind: "receiver", componentType: "otlp", expected: true},
+		{kind: "receiver", componentType: "batch", expected: false},
+		{kind: "processor", componentType: "bacth", expected: false},
+		{kind: "connector", componentType: "spanmetrics", expected: false},
+	} {
+		t.Run(tt.kind+"/"+tt.componentType, func(t *testing.T) {
+			assert.Equal(t, tt.expected, components.IsKnown(tt.kind, tt.componentType))
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema validates the configuration of collector components against JSON schemas.
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	semver "github.com/Masterminds/semver/v3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+// ValidationMode determines how the webhook reports component configurations that don't match their schema.
+type ValidationMode string
+
+const (
+	// ValidationDisabled skips the schema validation.
+	ValidationDisabled ValidationMode = "disabled"
+
+	// ValidationWarn reports schema mismatches as admission warnings.
+	ValidationWarn ValidationMode = "warn"
+
+	// ValidationEnforce rejects collectors with schema mismatches.
+	ValidationEnforce ValidationMode = "enforce"
+)
+
+// ParseValidationMode returns the ValidationMode for the given string.
+func ParseValidationMode(s string) (ValidationMode, error) {
+	switch mode := ValidationMode(s); mode {
+	case ValidationDisabled, ValidationWarn, ValidationEnforce:
+		return mode, nil
+	case "":
+		return ValidationDisabled, nil
+	default:
+		return "", fmt.Errorf("unknown config schema validation mode %q, must be one of %s, %s or %s", s, ValidationDisabled, ValidationWarn, ValidationEnforce)
+	}
+}
+
+//go:embed schemas/*.json
+var shipped embed.FS
+
+// Bundle holds JSON schemas for component configurations, keyed by component and collector version.
+type Bundle struct {
+	// schemas maps a component, in the form <kind>/<type>, to its schemas keyed by collector version.
+	schemas map[string]map[string]*spec.Schema
+}
+
+// NewBundle returns an empty Bundle.
+func NewBundle() *Bundle {
+	return &Bundle{schemas: map[string]map[string]*spec.Schema{}}
+}
+
+// Shipped returns the bundle of schemas shipped with the operator.
+func Shipped() (*Bundle, error) {
+	b := NewBundle()
+	entries, err := shipped.ReadDir("schemas")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		content, err := shipped.ReadFile(path.Join("schemas", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if err := b.Add(entry.Name(), content); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// Add parses the JSON schema in content and adds it to the bundle. The name identifies the component the schema is
+// for, in the form <collector version>_<kind>_<type>.json, e.g. 0.116.1_receiver_otlp.json. This makes the name usable
+// both as a file name and as a ConfigMap key. Schemas with the same name replace each other.
+func (b *Bundle) Add(name string, content []byte) error {
+	parts := strings.SplitN(strings.TrimSuffix(name, ".json"), "_", 3)
+	if len(parts) != 3 || !strings.HasSuffix(name, ".json") {
+		return fmt.Errorf("invalid schema name %q, expected <version>_<kind>_<type>.json", name)
+	}
+	version, err := semver.NewVersion(parts[0])
+	if err != nil {
+		return fmt.Errorf("invalid collector version in schema name %q: %w", name, err)
+	}
+	s := &spec.Schema{}
+	if err := json.Unmarshal(content, s); err != nil {
+		return fmt.Errorf("failed to parse schema %q: %w", name, err)
+	}
+	component := parts[1] + "/" + parts[2]
+	if b.schemas[component] == nil {
+		b.schemas[component] = map[string]*spec.Schema{}
+	}
+	b.schemas[component][version.String()] = s
+	return nil
+}
+
+// Validate checks the configuration of the component with the given kind and id against its schema for the given
+// collector version. It returns a description of every mismatch, or nothing when the bundle has no schema for the
+// component. When there's no schema for the exact version, the one for the closest older version is used.
+func (b *Bundle) Validate(version, kind, id string, config interface{}) []string {
+	s := b.schemaFor(version, kind+"/"+components.ComponentType(id))
+	if s == nil {
+		return nil
+	}
+	if config == nil {
+		// a component without any setting, e.g. `batch:`, uses the defaults
+		config = map[string]interface{}{}
+	}
+	result := validate.NewSchemaValidator(s, nil, fmt.Sprintf("%ss.%s", kind, id), strfmt.Default).Validate(config)
+	var problems []string
+	for _, err := range result.Errors {
+		problems = append(problems, err.Error())
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// Has returns whether the bundle has a schema to validate the component with the given kind and id for the given
+// collector version.
+func (b *Bundle) Has(version, kind, id string) bool {
+	return b.schemaFor(version, kind+"/"+components.ComponentType(id)) != nil
+}
+
+func (b *Bundle) schemaFor(version, component string) *spec.Schema {
+	if b == nil {
+		return nil
+	}
+	requested, err := semver.NewVersion(version)
+	if err != nil {
+		return nil
+	}
+	var closest *semver.Version
+	for v := range b.schemas[component] {
+		candidate, err := semver.NewVersion(v)
+		if err != nil || candidate.GreaterThan(requested) {
+			continue
+		}
+		if closest == nil || candidate.GreaterThan(closest) {
+			closest = candidate
+		}
+	}
+	if closest == nil {
+		return nil
+	}
+	return b.schemas[component][closest.String()]
+}
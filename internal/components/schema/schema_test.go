@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValidationMode(t *testing.T) {
+	for _, tt := range []struct {
+		in       string
+		expected ValidationMode
+		err      bool
+	}{
+		{in: "", expected: ValidationDisabled},
+		{in: "disabled", expected: ValidationDisabled},
+		{in: "warn", expected: ValidationWarn},
+		{in: "enforce", expected: ValidationEnforce},
+		{in: "strict", err: true},
+	} {
+		t.Run(tt.in, func(t *testing.T) {
+			mode, err := ParseValidationMode(tt.in)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, mode)
+		})
+	}
+}
+
+func TestShipped(t *testing.T) {
+	b, err := Shipped()
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name     string
+		version  string
+		kind     string
+		id       string
+		config   interface{}
+		expected []string
+	}{
+		{
+			name:    "valid config",
+			version: "0.116.1",
+			kind:    "processor",
+			id:      "batch",
+			config:  map[string]interface{}{"timeout": "5s", "send_batch_size": float64(1000)},
+		},
+		{
+			name:    "unknown key",
+			version: "0.116.1",
+			kind:    "receiver",
+			id:      "otlp/internal",
+			config: map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": map[string]interface{}{"endpiont": "0.0.0.0:4317"},
+					"htpp": map[string]interface{}{},
+				},
+			},
+			expected: []string{
+				"receivers.otlp/internal.protocols.grpc.endpiont in body is a forbidden property",
+				"receivers.otlp/internal.protocols.htpp in body is a forbidden property",
+			},
+		},
+		{
+			name:    "type mismatch",
+			version: "0.116.1",
+			kind:    "processor",
+			id:      "memory_limiter",
+			config:  map[string]interface{}{"check_interval": "often", "limit_percentage": "80"},
+			expected: []string{
+				"processors.memory_limiter.check_interval in body must be of type duration: \"often\"",
+				"processors.memory_limiter.limit_percentage in body must be of type integer: \"string\"",
+			},
+		},
+		{
+			name:     "empty component",
+			version:  "0.116.1",
+			kind:     "exporter",
+			id:       "debug",
+			config:   nil,
+			expected: nil,
+		},
+		{
+			name:     "newer collector uses the closest older schema",
+			version:  "0.120.0",
+			kind:     "exporter",
+			id:       "debug",
+			config:   map[string]interface{}{"verbosity": "verbose"},
+			expected: []string{"exporters.debug.verbosity in body should be one of [basic normal detailed]"},
+		},
+		{
+			name:    "older collector has no schema",
+			version: "0.100.0",
+			kind:    "exporter",
+			id:      "debug",
+			config:  map[string]interface{}{"verbosity": "verbose"},
+		},
+		{
+			name:    "unknown component",
+			version: "0.116.1",
+			kind:    "exporter",
+			id:      "otlphttp",
+			config:  map[string]interface{}{"anything": "goes"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, b.Validate(tt.version, tt.kind, tt.id, tt.config))
+		})
+	}
+}
+
+func TestBundleHas(t *testing.T) {
+	b, err := Shipped()
+	require.NoError(t, err)
+
+	assert.True(t, b.Has("0.116.1", "receiver", "otlp/internal"))
+	assert.True(t, b.Has("0.120.0", "exporter", "debug"))
+	assert.False(t, b.Has("0.100.0", "exporter", "debug"))
+	assert.False(t, b.Has("0.116.1", "exporter", "otlphttp"))
+	assert.False(t, NewBundle().Has("0.116.1", "exporter", "debug"))
+}
+
+func TestBundleAdd(t *testing.T) {
+	b := NewBundle()
+	require.NoError(t, b.Add("0.110.0_extension_health_check.json", []byte(`{"type": "object", "additionalProperties": false, "properties": {"endpoint": {"type": "string"}}}`)))
+	assert.Equal(t, []string{"extensions.health_check.path in body is a forbidden property"}, b.Validate("v0.116.1", "extension", "health_check", map[string]interface{}{"path": "/"}))
+
+	assert.ErrorContains(t, b.Add("health_check.json", []byte(`{}`)), "expected <version>_<kind>_<type>.json")
+	assert.ErrorContains(t, b.Add("latest_extension_health_check.json", []byte(`{}`)), "invalid collector version")
+	assert.ErrorContains(t, b.Add("0.110.0_extension_health_check.json", []byte(`{`)), "failed to parse schema")
+}
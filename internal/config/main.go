@@ -24,9 +24,12 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/schema"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
@@ -35,6 +38,7 @@ const (
 	defaultCollectorConfigMapEntry           = "collector.yaml"
 	defaultTargetAllocatorConfigMapEntry     = "targetallocator.yaml"
 	defaultOperatorOpAMPBridgeConfigMapEntry = "remoteconfiguration.yaml"
+	defaultSidecarConfigReloaderProcessName  = "otelcol"
 )
 
 // Config holds the static configuration for this operator.
@@ -46,12 +50,18 @@ type Config struct {
 	autoInstrumentationPythonImage      string
 	collectorImage                      string
 	collectorConfigMapEntry             string
+	sidecarConfigReloaderImage          string
+	sidecarConfigReloaderProcessName    string
+	collectorConfigSchemaValidation     schema.ValidationMode
+	collectorConfigSchemas              *schema.Bundle
 	createRBACPermissions               autoRBAC.Availability
 	enableMultiInstrumentation          bool
 	enableApacheHttpdInstrumentation    bool
 	enableDotNetInstrumentation         bool
 	enableGoInstrumentation             bool
 	enableNginxInstrumentation          bool
+	enableRubyInstrumentation           bool
+	enablePHPInstrumentation            bool
 	enablePythonInstrumentation         bool
 	enableNodeJSInstrumentation         bool
 	enableJavaInstrumentation           bool
@@ -59,12 +69,16 @@ type Config struct {
 	autoInstrumentationGoImage          string
 	autoInstrumentationApacheHttpdImage string
 	autoInstrumentationNginxImage       string
+	autoInstrumentationRubyImage        string
+	autoInstrumentationPHPImage         string
 	targetAllocatorConfigMapEntry       string
 	operatorOpAMPBridgeConfigMapEntry   string
 	autoInstrumentationNodeJSImage      string
 	autoInstrumentationJavaImage        string
 
 	openshiftRoutesAvailability openshift.RoutesAvailability
+	gatewayAPIAvailability      gatewayapi.Availability
+	kedaAvailability            keda.Availability
 	prometheusCRAvailability    prometheus.Availability
 	certManagerAvailability     certmanager.Availability
 	labelsFilter                []string
@@ -77,9 +91,13 @@ func New(opts ...Option) Config {
 	o := options{
 		prometheusCRAvailability:          prometheus.NotAvailable,
 		openshiftRoutesAvailability:       openshift.RoutesNotAvailable,
+		gatewayAPIAvailability:            gatewayapi.NotAvailable,
+		kedaAvailability:                  keda.NotAvailable,
 		createRBACPermissions:             autoRBAC.NotAvailable,
 		certManagerAvailability:           certmanager.NotAvailable,
 		collectorConfigMapEntry:           defaultCollectorConfigMapEntry,
+		sidecarConfigReloaderProcessName:  defaultSidecarConfigReloaderProcessName,
+		collectorConfigSchemaValidation:   schema.ValidationDisabled,
 		targetAllocatorConfigMapEntry:     defaultTargetAllocatorConfigMapEntry,
 		operatorOpAMPBridgeConfigMapEntry: defaultOperatorOpAMPBridgeConfigMapEntry,
 		logger:                            logf.Log.WithName("config"),
@@ -96,11 +114,17 @@ func New(opts ...Option) Config {
 		autoDetect:                          o.autoDetect,
 		collectorImage:                      o.collectorImage,
 		collectorConfigMapEntry:             o.collectorConfigMapEntry,
+		sidecarConfigReloaderImage:          o.sidecarConfigReloaderImage,
+		sidecarConfigReloaderProcessName:    o.sidecarConfigReloaderProcessName,
+		collectorConfigSchemaValidation:     o.collectorConfigSchemaValidation,
+		collectorConfigSchemas:              o.collectorConfigSchemas,
 		enableMultiInstrumentation:          o.enableMultiInstrumentation,
 		enableApacheHttpdInstrumentation:    o.enableApacheHttpdInstrumentation,
 		enableDotNetInstrumentation:         o.enableDotNetInstrumentation,
 		enableGoInstrumentation:             o.enableGoInstrumentation,
 		enableNginxInstrumentation:          o.enableNginxInstrumentation,
+		enableRubyInstrumentation:           o.enableRubyInstrumentation,
+		enablePHPInstrumentation:            o.enablePHPInstrumentation,
 		enablePythonInstrumentation:         o.enablePythonInstrumentation,
 		enableNodeJSInstrumentation:         o.enableNodeJSInstrumentation,
 		enableJavaInstrumentation:           o.enableJavaInstrumentation,
@@ -110,6 +134,8 @@ func New(opts ...Option) Config {
 		operatorOpAMPBridgeConfigMapEntry:   o.operatorOpAMPBridgeConfigMapEntry,
 		logger:                              o.logger,
 		openshiftRoutesAvailability:         o.openshiftRoutesAvailability,
+		gatewayAPIAvailability:              o.gatewayAPIAvailability,
+		kedaAvailability:                    o.kedaAvailability,
 		prometheusCRAvailability:            o.prometheusCRAvailability,
 		certManagerAvailability:             o.certManagerAvailability,
 		autoInstrumentationJavaImage:        o.autoInstrumentationJavaImage,
@@ -119,6 +145,8 @@ func New(opts ...Option) Config {
 		autoInstrumentationGoImage:          o.autoInstrumentationGoImage,
 		autoInstrumentationApacheHttpdImage: o.autoInstrumentationApacheHttpdImage,
 		autoInstrumentationNginxImage:       o.autoInstrumentationNginxImage,
+		autoInstrumentationRubyImage:        o.autoInstrumentationRubyImage,
+		autoInstrumentationPHPImage:         o.autoInstrumentationPHPImage,
 		labelsFilter:                        o.labelsFilter,
 		annotationsFilter:                   o.annotationsFilter,
 		createRBACPermissions:               o.createRBACPermissions,
@@ -136,6 +164,20 @@ func (c *Config) AutoDetect() error {
 	c.openshiftRoutesAvailability = ora
 	c.logger.V(2).Info("openshift routes detected", "availability", ora)
 
+	gwa, err := c.autoDetect.GatewayAPIAvailability()
+	if err != nil {
+		return err
+	}
+	c.gatewayAPIAvailability = gwa
+	c.logger.V(2).Info("gateway api detected", "availability", gwa)
+
+	kda, err := c.autoDetect.KEDAAvailability()
+	if err != nil {
+		return err
+	}
+	c.kedaAvailability = kda
+	c.logger.V(2).Info("keda detected", "availability", kda)
+
 	pcrd, err := c.autoDetect.PrometheusCRsAvailability()
 	if err != nil {
 		return err
@@ -165,6 +207,16 @@ func (c *Config) CollectorImage() string {
 	return c.collectorImage
 }
 
+// SidecarConfigReloaderImage represents the image of the container reloading the configuration of sidecar collectors.
+func (c *Config) SidecarConfigReloaderImage() string {
+	return c.sidecarConfigReloaderImage
+}
+
+// SidecarConfigReloaderProcessName represents the executable name of the sidecar collectors the reloader signals.
+func (c *Config) SidecarConfigReloaderProcessName() string {
+	return c.sidecarConfigReloaderProcessName
+}
+
 // EnableMultiInstrumentation is true when the operator supports multi instrumentation.
 func (c *Config) EnableMultiInstrumentation() bool {
 	return c.enableMultiInstrumentation
@@ -190,6 +242,16 @@ func (c *Config) EnableNginxAutoInstrumentation() bool {
 	return c.enableNginxInstrumentation
 }
 
+// EnableRubyAutoInstrumentation is true when the operator supports ruby auto instrumentation.
+func (c *Config) EnableRubyAutoInstrumentation() bool {
+	return c.enableRubyInstrumentation
+}
+
+// EnablePHPAutoInstrumentation is true when the operator supports php auto instrumentation.
+func (c *Config) EnablePHPAutoInstrumentation() bool {
+	return c.enablePHPInstrumentation
+}
+
 // EnableJavaAutoInstrumentation is true when the operator supports nginx auto instrumentation.
 func (c *Config) EnableJavaAutoInstrumentation() bool {
 	return c.enableJavaInstrumentation
@@ -240,6 +302,16 @@ func (c *Config) OpenShiftRoutesAvailability() openshift.RoutesAvailability {
 	return c.openshiftRoutesAvailability
 }
 
+// GatewayAPIAvailability represents the availability of the Gateway API HTTPRoute and GRPCRoute resources.
+func (c *Config) GatewayAPIAvailability() gatewayapi.Availability {
+	return c.gatewayAPIAvailability
+}
+
+// KEDAAvailability represents the availability of the KEDA ScaledObject resource.
+func (c *Config) KEDAAvailability() keda.Availability {
+	return c.kedaAvailability
+}
+
 // PrometheusCRAvailability represents the availability of the Prometheus Operator CRDs.
 func (c *Config) PrometheusCRAvailability() prometheus.Availability {
 	return c.prometheusCRAvailability
@@ -285,6 +357,26 @@ func (c *Config) AutoInstrumentationNginxImage() string {
 	return c.autoInstrumentationNginxImage
 }
 
+// AutoInstrumentationRubyImage returns OpenTelemetry Ruby auto-instrumentation container image.
+func (c *Config) AutoInstrumentationRubyImage() string {
+	return c.autoInstrumentationRubyImage
+}
+
+// AutoInstrumentationPHPImage returns OpenTelemetry PHP auto-instrumentation container image.
+func (c *Config) AutoInstrumentationPHPImage() string {
+	return c.autoInstrumentationPHPImage
+}
+
+// CollectorConfigSchemaValidation represents how the webhook reports component configurations not matching their schema.
+func (c *Config) CollectorConfigSchemaValidation() schema.ValidationMode {
+	return c.collectorConfigSchemaValidation
+}
+
+// CollectorConfigSchemas represents the bundle of schemas the collector component configurations are validated against.
+func (c *Config) CollectorConfigSchemas() *schema.Bundle {
+	return c.collectorConfigSchemas
+}
+
 // LabelsFilter Returns the filters converted to regex strings used to filter out unwanted labels from propagations.
 func (c *Config) LabelsFilter() []string {
 	return c.labelsFilter
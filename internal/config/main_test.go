@@ -23,6 +23,8 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
@@ -54,6 +56,12 @@ func TestConfigChangesOnAutoDetect(t *testing.T) {
 		PrometheusCRsAvailabilityFunc: func() (prometheus.Availability, error) {
 			return prometheus.Available, nil
 		},
+		GatewayAPIAvailabilityFunc: func() (gatewayapi.Availability, error) {
+			return gatewayapi.Available, nil
+		},
+		KEDAAvailabilityFunc: func() (keda.Availability, error) {
+			return keda.Available, nil
+		},
 		RBACPermissionsFunc: func(ctx context.Context) (rbac.Availability, error) {
 			return rbac.Available, nil
 		},
@@ -68,6 +76,8 @@ func TestConfigChangesOnAutoDetect(t *testing.T) {
 	// sanity check
 	require.Equal(t, openshift.RoutesNotAvailable, cfg.OpenShiftRoutesAvailability())
 	require.Equal(t, prometheus.NotAvailable, cfg.PrometheusCRAvailability())
+	require.Equal(t, gatewayapi.NotAvailable, cfg.GatewayAPIAvailability())
+	require.Equal(t, keda.NotAvailable, cfg.KEDAAvailability())
 
 	// test
 	err := cfg.AutoDetect()
@@ -76,6 +86,8 @@ func TestConfigChangesOnAutoDetect(t *testing.T) {
 	// verify
 	assert.Equal(t, openshift.RoutesAvailable, cfg.OpenShiftRoutesAvailability())
 	require.Equal(t, prometheus.Available, cfg.PrometheusCRAvailability())
+	assert.Equal(t, gatewayapi.Available, cfg.GatewayAPIAvailability())
+	assert.Equal(t, keda.Available, cfg.KEDAAvailability())
 }
 
 var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
@@ -85,6 +97,8 @@ type mockAutoDetect struct {
 	PrometheusCRsAvailabilityFunc   func() (prometheus.Availability, error)
 	RBACPermissionsFunc             func(ctx context.Context) (rbac.Availability, error)
 	CertManagerAvailabilityFunc     func(ctx context.Context) (certmanager.Availability, error)
+	GatewayAPIAvailabilityFunc      func() (gatewayapi.Availability, error)
+	KEDAAvailabilityFunc            func() (keda.Availability, error)
 }
 
 func (m *mockAutoDetect) FIPSEnabled(_ context.Context) bool {
@@ -118,3 +132,17 @@ func (m *mockAutoDetect) CertManagerAvailability(ctx context.Context) (certmanag
 	}
 	return certmanager.NotAvailable, nil
 }
+
+func (m *mockAutoDetect) GatewayAPIAvailability() (gatewayapi.Availability, error) {
+	if m.GatewayAPIAvailabilityFunc != nil {
+		return m.GatewayAPIAvailabilityFunc()
+	}
+	return gatewayapi.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) KEDAAvailability() (keda.Availability, error) {
+	if m.KEDAAvailabilityFunc != nil {
+		return m.KEDAAvailabilityFunc()
+	}
+	return keda.NotAvailable, nil
+}
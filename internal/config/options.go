@@ -20,9 +20,12 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/schema"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
@@ -40,14 +43,22 @@ type options struct {
 	autoInstrumentationPythonImage      string
 	autoInstrumentationApacheHttpdImage string
 	autoInstrumentationNginxImage       string
+	autoInstrumentationRubyImage        string
+	autoInstrumentationPHPImage         string
 	collectorImage                      string
 	collectorConfigMapEntry             string
+	sidecarConfigReloaderImage          string
+	sidecarConfigReloaderProcessName    string
+	collectorConfigSchemaValidation     schema.ValidationMode
+	collectorConfigSchemas              *schema.Bundle
 	createRBACPermissions               autoRBAC.Availability
 	enableMultiInstrumentation          bool
 	enableApacheHttpdInstrumentation    bool
 	enableDotNetInstrumentation         bool
 	enableGoInstrumentation             bool
 	enableNginxInstrumentation          bool
+	enableRubyInstrumentation           bool
+	enablePHPInstrumentation            bool
 	enablePythonInstrumentation         bool
 	enableNodeJSInstrumentation         bool
 	enableJavaInstrumentation           bool
@@ -56,6 +67,8 @@ type options struct {
 	targetAllocatorImage                string
 	operatorOpAMPBridgeImage            string
 	openshiftRoutesAvailability         openshift.RoutesAvailability
+	gatewayAPIAvailability              gatewayapi.Availability
+	kedaAvailability                    keda.Availability
 	prometheusCRAvailability            prometheus.Availability
 	certManagerAvailability             certmanager.Availability
 	labelsFilter                        []string
@@ -87,6 +100,28 @@ func WithCollectorConfigMapEntry(s string) Option {
 		o.collectorConfigMapEntry = s
 	}
 }
+func WithSidecarConfigReloaderImage(s string) Option {
+	return func(o *options) {
+		o.sidecarConfigReloaderImage = s
+	}
+}
+func WithSidecarConfigReloaderProcessName(s string) Option {
+	return func(o *options) {
+		o.sidecarConfigReloaderProcessName = s
+	}
+}
+func WithCollectorConfigSchemaValidation(m schema.ValidationMode) Option {
+	return func(o *options) {
+		o.collectorConfigSchemaValidation = m
+	}
+}
+
+func WithCollectorConfigSchemas(b *schema.Bundle) Option {
+	return func(o *options) {
+		o.collectorConfigSchemas = b
+	}
+}
+
 func WithEnableMultiInstrumentation(s bool) Option {
 	return func(o *options) {
 		o.enableMultiInstrumentation = s
@@ -112,6 +147,16 @@ func WithEnableNginxInstrumentation(s bool) Option {
 		o.enableNginxInstrumentation = s
 	}
 }
+func WithEnableRubyInstrumentation(s bool) Option {
+	return func(o *options) {
+		o.enableRubyInstrumentation = s
+	}
+}
+func WithEnablePHPInstrumentation(s bool) Option {
+	return func(o *options) {
+		o.enablePHPInstrumentation = s
+	}
+}
 func WithEnableJavaInstrumentation(s bool) Option {
 	return func(o *options) {
 		o.enableJavaInstrumentation = s
@@ -190,12 +235,36 @@ func WithAutoInstrumentationNginxImage(s string) Option {
 	}
 }
 
+func WithAutoInstrumentationRubyImage(s string) Option {
+	return func(o *options) {
+		o.autoInstrumentationRubyImage = s
+	}
+}
+
+func WithAutoInstrumentationPHPImage(s string) Option {
+	return func(o *options) {
+		o.autoInstrumentationPHPImage = s
+	}
+}
+
 func WithOpenShiftRoutesAvailability(os openshift.RoutesAvailability) Option {
 	return func(o *options) {
 		o.openshiftRoutesAvailability = os
 	}
 }
 
+func WithGatewayAPIAvailability(gwa gatewayapi.Availability) Option {
+	return func(o *options) {
+		o.gatewayAPIAvailability = gwa
+	}
+}
+
+func WithKEDAAvailability(kda keda.Availability) Option {
+	return func(o *options) {
+		o.kedaAvailability = kda
+	}
+}
+
 func WithPrometheusCRAvailability(pcrd prometheus.Availability) Option {
 	return func(o *options) {
 		o.prometheusCRAvailability = pcrd
@@ -28,6 +28,12 @@ import (
 
 const (
 	ComponentOpenTelemetryCollector = "opentelemetry-collector"
+	// ComponentOpenTelemetryCollectorCanary labels the pods running the configuration being rolled out, which the
+	// selectors of the collector pods, e.g. of its deployment or pod disruption budget, don't match.
+	ComponentOpenTelemetryCollectorCanary = "opentelemetry-collector-canary"
+	// ComponentOpenTelemetryCollectorSidecar labels the ConfigMap of the sidecars, which isn't one of the versioned
+	// collector ConfigMaps.
+	ComponentOpenTelemetryCollectorSidecar = "opentelemetry-collector-sidecar"
 )
 
 // Build creates the manifest for the collector resource.
@@ -37,6 +43,7 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	switch params.OtelCol.Spec.Mode {
 	case v1beta1.ModeDeployment:
 		manifestFactories = append(manifestFactories, manifests.Factory(Deployment))
+		manifestFactories = append(manifestFactories, manifests.Factory(CanaryDeployment))
 		manifestFactories = append(manifestFactories, manifests.Factory(PodDisruptionBudget))
 	case v1beta1.ModeStatefulSet:
 		manifestFactories = append(manifestFactories, manifests.Factory(StatefulSet))
@@ -45,10 +52,12 @@ func Build(params manifests.Params) ([]client.Object, error) {
 		manifestFactories = append(manifestFactories, manifests.Factory(DaemonSet))
 	case v1beta1.ModeSidecar:
 		params.Log.V(5).Info("not building sidecar...")
+		manifestFactories = append(manifestFactories, manifests.Factory(SidecarConfigMap))
 	}
 	manifestFactories = append(manifestFactories, []manifests.K8sManifestFactory[manifests.Params]{
 		manifests.Factory(ConfigMap),
 		manifests.Factory(HorizontalPodAutoscaler),
+		manifests.Factory(ScaledObject),
 		manifests.Factory(ServiceAccount),
 		manifests.Factory(Service),
 		manifests.Factory(HeadlessService),
@@ -106,6 +115,27 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	for _, route := range routes {
 		resourceManifests = append(resourceManifests, route)
 	}
+
+	httpRoutes, err := HTTPRoutes(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range httpRoutes {
+		resourceManifests = append(resourceManifests, route)
+	}
+	grpcRoutes, err := GRPCRoutes(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range grpcRoutes {
+		resourceManifests = append(resourceManifests, route)
+	}
+
+	nodePools, err := NodePools(params)
+	if err != nil {
+		return nil, err
+	}
+	resourceManifests = append(resourceManifests, nodePools...)
 	return resourceManifests, nil
 }
 
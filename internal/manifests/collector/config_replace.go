@@ -43,9 +43,9 @@ type Config struct {
 }
 
 func ReplaceConfig(otelcol v1beta1.OpenTelemetryCollector, targetAllocator *v1alpha1.TargetAllocator, options ...ta.TAOption) (string, error) {
-	collectorSpec := otelcol.Spec
 	taEnabled := targetAllocator != nil
-	cfgStr, err := collectorSpec.Config.Yaml()
+	cfg := persistentConfig(otelcol)
+	cfgStr, err := cfg.Yaml()
 	if err != nil {
 		return "", err
 	}
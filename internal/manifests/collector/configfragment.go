@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+const defaultConfigFragmentKey = "fragment.yaml"
+
+// ApplyConfigFragments fetches the ConfigMaps referenced in the collector's spec.configFragments and merges them
+// into its config. It returns the list of fragments that were applied.
+func ApplyConfigFragments(ctx context.Context, cli client.Reader, otelcol *v1beta1.OpenTelemetryCollector) ([]v1beta1.ConfigFragmentStatus, error) {
+	if len(otelcol.Spec.ConfigFragments) == 0 {
+		return nil, nil
+	}
+
+	var fragments []v1beta1.ConfigFragment
+	var applied []v1beta1.ConfigFragmentStatus
+	for _, ref := range otelcol.Spec.ConfigFragments {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = otelcol.Namespace
+		}
+		key := ref.Key
+		if key == "" {
+			key = defaultConfigFragmentKey
+		}
+		source := fmt.Sprintf("%s/%s", namespace, ref.Name)
+
+		cm := &corev1.ConfigMap{}
+		if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, cm); err != nil {
+			return nil, fmt.Errorf("failed to get config fragment %s: %w", source, err)
+		}
+		if cm.GetLabels()[constants.LabelConfigFragment] != "true" {
+			return nil, fmt.Errorf("the ConfigMap %s is not labeled with %s=true and can't be used as a config fragment", source, constants.LabelConfigFragment)
+		}
+		content, ok := cm.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("the config fragment %s has no %s entry", source, key)
+		}
+
+		object := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(content), &object); err != nil {
+			return nil, fmt.Errorf("failed to parse config fragment %s: %w", source, err)
+		}
+		fragments = append(fragments, v1beta1.ConfigFragment{Source: source, Object: object})
+		applied = append(applied, v1beta1.ConfigFragmentStatus{
+			Name:            ref.Name,
+			Namespace:       namespace,
+			ResourceVersion: cm.ResourceVersion,
+		})
+	}
+
+	if err := otelcol.Spec.Config.MergeFragments(fragments...); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+func TestApplyConfigFragments(t *testing.T) {
+	shared := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "corporate-exporter",
+			Namespace:       "observability",
+			ResourceVersion: "42",
+			Labels:          map[string]string{constants.LabelConfigFragment: "true"},
+		},
+		Data: map[string]string{
+			"fragment.yaml": `
+exporters:
+  otlp/corporate:
+    endpoint: gateway.observability:4317
+service:
+  pipelines:
+    traces:
+      exporters: [otlp/corporate]
+`,
+		},
+	}
+	local := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "processors",
+			Namespace:       "default",
+			ResourceVersion: "7",
+			Labels:          map[string]string{constants.LabelConfigFragment: "true"},
+		},
+		Data: map[string]string{
+			"processors.yaml": `
+processors:
+  batch: {}
+service:
+  pipelines:
+    traces:
+      processors: [batch]
+`,
+		},
+	}
+	unlabeled := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kube-root-ca.crt",
+			Namespace: "observability",
+		},
+		Data: map[string]string{"fragment.yaml": "exporters: {}"},
+	}
+	cli := fake.NewClientBuilder().WithObjects(shared, local, unlabeled).Build()
+
+	newCollector := func(refs ...v1beta1.ConfigFragmentReference) *v1beta1.OpenTelemetryCollector {
+		return &v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				ConfigFragments: refs,
+				Config: v1beta1.Config{
+					Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{}}},
+					Service: v1beta1.Service{
+						Pipelines: map[string]*v1beta1.Pipeline{
+							"traces": {Receivers: []string{"otlp"}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("applies fragments", func(t *testing.T) {
+		otelcol := newCollector(
+			v1beta1.ConfigFragmentReference{Name: "corporate-exporter", Namespace: "observability"},
+			v1beta1.ConfigFragmentReference{Name: "processors", Key: "processors.yaml"},
+		)
+		applied, err := ApplyConfigFragments(context.Background(), cli, otelcol)
+		require.NoError(t, err)
+
+		assert.Equal(t, []v1beta1.ConfigFragmentStatus{
+			{Name: "corporate-exporter", Namespace: "observability", ResourceVersion: "42"},
+			{Name: "processors", Namespace: "default", ResourceVersion: "7"},
+		}, applied)
+		assert.Equal(t, &v1beta1.Pipeline{
+			Receivers:  []string{"otlp"},
+			Processors: []string{"batch"},
+			Exporters:  []string{"otlp/corporate"},
+		}, otelcol.Spec.Config.Service.Pipelines["traces"])
+	})
+
+	t.Run("no fragments", func(t *testing.T) {
+		otelcol := newCollector()
+		expected := otelcol.DeepCopy()
+		applied, err := ApplyConfigFragments(context.Background(), cli, otelcol)
+		require.NoError(t, err)
+		assert.Nil(t, applied)
+		assert.Equal(t, expected, otelcol)
+	})
+
+	t.Run("unlabeled ConfigMap", func(t *testing.T) {
+		otelcol := newCollector(v1beta1.ConfigFragmentReference{Name: "kube-root-ca.crt", Namespace: "observability"})
+		_, err := ApplyConfigFragments(context.Background(), cli, otelcol)
+		assert.ErrorContains(t, err, "is not labeled with opentelemetry.io/config-fragment=true")
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		otelcol := newCollector(v1beta1.ConfigFragmentReference{Name: "processors"})
+		_, err := ApplyConfigFragments(context.Background(), cli, otelcol)
+		assert.EqualError(t, err, "the config fragment default/processors has no fragment.yaml entry")
+	})
+
+	t.Run("missing ConfigMap", func(t *testing.T) {
+		otelcol := newCollector(v1beta1.ConfigFragmentReference{Name: "missing"})
+		_, err := ApplyConfigFragments(context.Background(), cli, otelcol)
+		assert.ErrorContains(t, err, "failed to get config fragment default/missing")
+	})
+}
@@ -20,6 +20,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
@@ -73,3 +74,36 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 		},
 	}, nil
 }
+
+// SidecarConfigMap builds the ConfigMap the sidecar collectors read their configuration from. Its name doesn't depend
+// on the configuration, for the kubelet to update the file mounted in the running sidecars.
+func SidecarConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
+	if params.OtelCol.Spec.Mode != v1beta1.ModeSidecar || !featuregate.EnableSidecarConfigMap.IsEnabled() {
+		return nil, nil
+	}
+	collectorName := naming.Collector(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, collectorName, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollectorSidecar, []string{})
+
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	// the sidecars don't use the target allocator
+	replacedConf, err := ReplaceConfig(params.OtelCol, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.SidecarConfigMap(params.OtelCol.Name),
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Data: map[string]string{
+			params.Config.CollectorConfigMapEntry(): replacedConf,
+		},
+	}, nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 	colfg "go.opentelemetry.io/collector/featuregate"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
@@ -183,3 +184,35 @@ service:
 
 	})
 }
+
+func TestDesiredSidecarConfigMap(t *testing.T) {
+	t.Run("should not return a config map when the feature gate is disabled", func(t *testing.T) {
+		actual, err := SidecarConfigMap(paramsWithMode(v1beta1.ModeSidecar))
+		assert.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableSidecarConfigMap.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableSidecarConfigMap.ID(), false))
+	})
+
+	t.Run("should return the sidecar config map with a stable name", func(t *testing.T) {
+		param := paramsWithMode(v1beta1.ModeSidecar)
+		expectedConf, err := ReplaceConfig(param.OtelCol, nil)
+		require.NoError(t, err)
+
+		actual, err := SidecarConfigMap(param)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test-collector-sidecar", actual.Name)
+		assert.Equal(t, "opentelemetry-collector-sidecar", actual.Labels["app.kubernetes.io/component"])
+		assert.Equal(t, map[string]string{"collector.yaml": expectedConf}, actual.Data)
+	})
+
+	t.Run("should not return a config map for other modes", func(t *testing.T) {
+		actual, err := SidecarConfigMap(deploymentParams())
+		assert.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/extensions"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
@@ -107,6 +108,8 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 		volumeMounts = append(volumeMounts, otelcol.Spec.VolumeMounts...)
 	}
 
+	volumeMounts = append(volumeMounts, persistenceVolumeMounts(otelcol)...)
+
 	var envVars = otelcol.Spec.Env
 	if otelcol.Spec.Env == nil {
 		envVars = []corev1.EnvVar{}
@@ -146,13 +149,21 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 	if livenessProbeErr != nil {
 		logger.Error(livenessProbeErr, "cannot create liveness probe.")
 	} else {
-		defaultProbeSettings(livenessProbe, otelcol.Spec.LivenessProbe)
+		supportsPipeline := livenessProbe != nil && otelcol.Spec.Config.ProbesSupportPipeline(logger)
+		if livenessProbe == nil && featuregate.EnableProbeFallback.IsEnabled() {
+			livenessProbe = otelcol.Spec.Config.GetFallbackProbe()
+		}
+		defaultProbeSettings(livenessProbe, otelcol.Spec.LivenessProbe, supportsPipeline)
 	}
 	readinessProbe, readinessProbeErr := otelcol.Spec.Config.GetReadinessProbe(logger)
 	if readinessProbeErr != nil {
 		logger.Error(readinessProbeErr, "cannot create readiness probe.")
 	} else {
-		defaultProbeSettings(readinessProbe, otelcol.Spec.ReadinessProbe)
+		supportsPipeline := readinessProbe != nil && otelcol.Spec.Config.ProbesSupportPipeline(logger)
+		if readinessProbe == nil && featuregate.EnableProbeFallback.IsEnabled() {
+			readinessProbe = otelcol.Spec.Config.GetFallbackProbe()
+		}
+		defaultProbeSettings(readinessProbe, otelcol.Spec.ReadinessProbe, supportsPipeline)
 	}
 
 	if featuregate.SetGolangFlags.IsEnabled() {
@@ -255,7 +266,7 @@ func portMapToList(portMap map[string]corev1.ContainerPort) []corev1.ContainerPo
 	return ports
 }
 
-func defaultProbeSettings(probe *corev1.Probe, probeConfig *v1beta1.Probe) {
+func defaultProbeSettings(probe *corev1.Probe, probeConfig *v1beta1.Probe, supportsPipeline bool) {
 	if probe != nil && probeConfig != nil {
 		if probeConfig.InitialDelaySeconds != nil {
 			probe.InitialDelaySeconds = *probeConfig.InitialDelaySeconds
@@ -273,5 +284,8 @@ func defaultProbeSettings(probe *corev1.Probe, probeConfig *v1beta1.Probe) {
 			probe.TimeoutSeconds = *probeConfig.TimeoutSeconds
 		}
 		probe.TerminationGracePeriodSeconds = probeConfig.TerminationGracePeriodSeconds
+		if supportsPipeline {
+			extensions.ApplyProbePipeline(probe, probeConfig.Pipeline)
+		}
 	}
 }
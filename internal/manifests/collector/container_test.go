@@ -15,6 +15,7 @@
 package collector_test
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -824,6 +825,103 @@ service:
 	assert.Equal(t, "", c.LivenessProbe.HTTPGet.Host)
 }
 
+func TestContainerProbeFallback(t *testing.T) {
+	// prepare
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: mustUnmarshalToConfig(t, `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]`),
+			ReadinessProbe: &v1beta1.Probe{
+				Pipeline: "traces",
+			},
+		},
+	}
+	cfg := config.New()
+
+	// the fallback is disabled by default
+	c := Container(cfg, logger, otelcol, true)
+	assert.Nil(t, c.LivenessProbe)
+	assert.Nil(t, c.ReadinessProbe)
+
+	require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableProbeFallback.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableProbeFallback.ID(), false))
+	})
+
+	// test
+	c = Container(cfg, logger, otelcol, true)
+
+	// verify
+	require.NotNil(t, c.LivenessProbe)
+	assert.Equal(t, int32(4317), c.LivenessProbe.TCPSocket.Port.IntVal)
+	require.NotNil(t, c.ReadinessProbe)
+	assert.Equal(t, int32(4317), c.ReadinessProbe.TCPSocket.Port.IntVal)
+}
+
+func TestContainerProbePipeline(t *testing.T) {
+	// prepare
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: mustUnmarshalToConfig(t, `extensions:
+  healthcheckv2:
+    use_v2: true
+    http:
+      endpoint: 0.0.0.0:13133
+service:
+  extensions: [healthcheckv2]`),
+			ReadinessProbe: &v1beta1.Probe{
+				Pipeline: "traces",
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol, true)
+
+	// verify
+	assert.Equal(t, "/status", c.LivenessProbe.HTTPGet.Path)
+	assert.Equal(t, "/status?pipeline=traces", c.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, int32(13133), c.ReadinessProbe.HTTPGet.Port.IntVal)
+}
+
+func TestContainerProbePipelineLegacyHealthCheck(t *testing.T) {
+	for _, extension := range []string{"health_check", "healthcheckv2"} {
+		t.Run(extension, func(t *testing.T) {
+			// prepare
+			otelcol := v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Config: mustUnmarshalToConfig(t, fmt.Sprintf(`extensions:
+  %[1]s:
+    endpoint: 0.0.0.0:13133
+service:
+  extensions: [%[1]s]`, extension)),
+					ReadinessProbe: &v1beta1.Probe{
+						Pipeline: "traces",
+					},
+				},
+			}
+			cfg := config.New()
+
+			// test
+			c := Container(cfg, logger, otelcol, true)
+
+			// verify
+			assert.Equal(t, "/", c.ReadinessProbe.HTTPGet.Path)
+		})
+	}
+}
+
 func TestContainerLifecycle(t *testing.T) {
 	// prepare
 	otelcol := v1beta1.OpenTelemetryCollector{
@@ -66,9 +66,9 @@ func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
 					ShareProcessNamespace: &params.OtelCol.Spec.ShareProcessNamespace,
 					DNSPolicy:             manifestutils.GetDNSPolicy(params.OtelCol.Spec.HostNetwork, params.OtelCol.Spec.PodDNSConfig),
 					DNSConfig:             &params.OtelCol.Spec.PodDNSConfig,
-					SecurityContext:       params.OtelCol.Spec.PodSecurityContext,
+					SecurityContext:       podSecurityContext(params.OtelCol),
 					PriorityClassName:     params.OtelCol.Spec.PriorityClassName,
-					Affinity:              params.OtelCol.Spec.Affinity,
+					Affinity:              nodePoolsAffinity(params.OtelCol),
 				},
 			},
 			UpdateStrategy: params.OtelCol.Spec.DaemonSetUpdateStrategy,
@@ -24,10 +24,22 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
-// Deployment builds the deployment for the given instance.
+// Deployment builds the deployment for the given instance. While a new configuration is rolled out, it keeps running
+// the stable one.
 func Deployment(params manifests.Params) (*appsv1.Deployment, error) {
+	deployment, err := deployment(params, ComponentOpenTelemetryCollector)
+	if err != nil || !rolloutEnabled(params.OtelCol) {
+		return deployment, err
+	}
+	if version := stableConfigVersion(params.OtelCol); version != "" {
+		pinConfigVersion(params.OtelCol, &deployment.Spec.Template, version)
+	}
+	return deployment, nil
+}
+
+func deployment(params manifests.Params, component string) (*appsv1.Deployment, error) {
 	name := naming.Collector(params.OtelCol.Name)
-	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, component, params.Config.LabelsFilter())
 	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter())
 	if err != nil {
 		return nil, err
@@ -46,9 +58,9 @@ func Deployment(params manifests.Params) (*appsv1.Deployment, error) {
 			Annotations: annotations,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: params.OtelCol.Spec.Replicas,
+			Replicas: replicas(params.OtelCol),
 			Selector: &metav1.LabelSelector{
-				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, component),
 			},
 			Strategy: params.OtelCol.Spec.DeploymentUpdateStrategy,
 			Template: corev1.PodTemplateSpec{
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// HTTPRoutes returns a Gateway API HTTPRoute for each receiver port of the collector that isn't a gRPC port.
+func HTTPRoutes(params manifests.Params) ([]*gatewayv1.HTTPRoute, error) {
+	ports, err := gatewayRoutePorts(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var routes []*gatewayv1.HTTPRoute
+	for _, p := range ports {
+		if isGRPCPort(p) || p.Protocol == corev1.ProtocolUDP {
+			continue
+		}
+		portName := naming.PortName(p.Name, p.Port)
+		path := "/"
+		var filters []gatewayv1.HTTPRouteFilter
+		if params.OtelCol.Spec.Ingress.RuleType != v1beta1.IngressRuleTypeSubdomain {
+			// the receivers share the same hostname, the path of the port is removed before forwarding the requests
+			path = "/" + p.Name
+			filters = []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterURLRewrite,
+				URLRewrite: &gatewayv1.HTTPURLRewriteFilter{
+					Path: &gatewayv1.HTTPPathModifier{
+						Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+						ReplacePrefixMatch: ptr.To("/"),
+					},
+				},
+			}}
+		}
+		pathType := gatewayv1.PathMatchPathPrefix
+
+		name := naming.Route(params.OtelCol.Name, p.Name)
+		routes = append(routes, &gatewayv1.HTTPRoute{
+			ObjectMeta: gatewayRouteObjectMeta(params, name),
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: gatewayParentRefs(params.OtelCol)},
+				Hostnames:       gatewayHostnames(params.OtelCol, portName),
+				Rules: []gatewayv1.HTTPRouteRule{{
+					Matches: []gatewayv1.HTTPRouteMatch{{
+						Path: &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &path},
+					}},
+					Filters: filters,
+					BackendRefs: []gatewayv1.HTTPBackendRef{{
+						BackendRef: gatewayBackendRef(params.OtelCol, p),
+					}},
+				}},
+			},
+		})
+	}
+	return routes, nil
+}
+
+// GRPCRoutes returns a Gateway API GRPCRoute for each gRPC receiver port of the collector. The routes only match the
+// subdomain of their port, they're only created with the subdomain rule type.
+func GRPCRoutes(params manifests.Params) ([]*gatewayv1.GRPCRoute, error) {
+	ports, err := gatewayRoutePorts(params)
+	if err != nil {
+		return nil, err
+	}
+	if params.OtelCol.Spec.Ingress.RuleType != v1beta1.IngressRuleTypeSubdomain {
+		if slices.ContainsFunc(ports, isGRPCPort) {
+			params.Log.V(1).Info("the gRPC receivers can only be exposed through the gateway with the subdomain rule type, skipping their routes",
+				"instance.name", params.OtelCol.Name,
+				"instance.namespace", params.OtelCol.Namespace,
+			)
+		}
+		return nil, nil
+	}
+
+	var routes []*gatewayv1.GRPCRoute
+	for _, p := range ports {
+		if !isGRPCPort(p) {
+			continue
+		}
+		name := naming.Route(params.OtelCol.Name, p.Name)
+		routes = append(routes, &gatewayv1.GRPCRoute{
+			ObjectMeta: gatewayRouteObjectMeta(params, name),
+			Spec: gatewayv1.GRPCRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: gatewayParentRefs(params.OtelCol)},
+				Hostnames:       gatewayHostnames(params.OtelCol, naming.PortName(p.Name, p.Port)),
+				Rules: []gatewayv1.GRPCRouteRule{{
+					BackendRefs: []gatewayv1.GRPCBackendRef{{
+						BackendRef: gatewayBackendRef(params.OtelCol, p),
+					}},
+				}},
+			},
+		})
+	}
+	return routes, nil
+}
+
+// gatewayRoutePorts returns the receiver ports to expose through the Gateway, or nothing when the collector isn't
+// exposed through the Gateway API.
+func gatewayRoutePorts(params manifests.Params) ([]corev1.ServicePort, error) {
+	if params.OtelCol.Spec.Ingress.Type != v1beta1.IngressTypeGateway || params.Config.GatewayAPIAvailability() != gatewayapi.Available {
+		return nil, nil
+	}
+
+	if params.OtelCol.Spec.Mode == v1beta1.ModeSidecar {
+		params.Log.V(3).Info("ingress settings are not supported in sidecar mode")
+		return nil, nil
+	}
+
+	ports, err := servicePortsFromCfg(params.Log, params.OtelCol)
+
+	// if we have no ports, we don't need any route
+	if len(ports) == 0 || err != nil {
+		params.Log.V(1).Info(
+			"the instance's configuration didn't yield any ports to open, skipping gateway routes",
+			"instance.name", params.OtelCol.Name,
+			"instance.namespace", params.OtelCol.Namespace,
+		)
+		return nil, err
+	}
+	return ports, nil
+}
+
+func isGRPCPort(port corev1.ServicePort) bool {
+	return port.AppProtocol != nil && strings.EqualFold(*port.AppProtocol, "grpc")
+}
+
+func gatewayRouteObjectMeta(params manifests.Params, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   params.OtelCol.Namespace,
+		Annotations: params.OtelCol.Spec.Ingress.Annotations,
+		Labels:      manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter()),
+	}
+}
+
+func gatewayParentRefs(otelcol v1beta1.OpenTelemetryCollector) []gatewayv1.ParentReference {
+	parentRef := otelcol.Spec.Ingress.Gateway.ParentRef
+	ref := gatewayv1.ParentReference{Name: gatewayv1.ObjectName(parentRef.Name)}
+	if parentRef.Namespace != "" {
+		ref.Namespace = ptr.To(gatewayv1.Namespace(parentRef.Namespace))
+	}
+	if parentRef.SectionName != "" {
+		ref.SectionName = ptr.To(gatewayv1.SectionName(parentRef.SectionName))
+	}
+	return []gatewayv1.ParentReference{ref}
+}
+
+// gatewayHostnames returns the hostname of the route, the port name being used as a subdomain with the subdomain rule
+// type. Without hostname, the route matches the hostnames of the Gateway listener.
+func gatewayHostnames(otelcol v1beta1.OpenTelemetryCollector, portName string) []gatewayv1.Hostname {
+	hostname := otelcol.Spec.Ingress.Hostname
+	if hostname == "" || hostname == "*" {
+		return nil
+	}
+	if otelcol.Spec.Ingress.RuleType == v1beta1.IngressRuleTypeSubdomain {
+		hostname = fmt.Sprintf("%s.%s", portName, hostname)
+	}
+	return []gatewayv1.Hostname{gatewayv1.Hostname(hostname)}
+}
+
+func gatewayBackendRef(otelcol v1beta1.OpenTelemetryCollector, port corev1.ServicePort) gatewayv1.BackendRef {
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Name: gatewayv1.ObjectName(naming.Service(otelcol.Name)),
+			Port: ptr.To(gatewayv1.PortNumber(port.Port)),
+		},
+	}
+}
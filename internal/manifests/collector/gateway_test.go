@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func gatewayParams(ingress v1beta1.Ingress) manifests.Params {
+	return manifests.Params{
+		Config: config.New(config.WithGatewayAPIAvailability(gatewayapi.Available)),
+		Log:    logger,
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "otlp", Namespace: "observability"},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: v1beta1.ModeDeployment,
+				Config: v1beta1.Config{
+					Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+						"otlp": map[string]interface{}{
+							"protocols": map[string]interface{}{
+								"grpc": map[string]interface{}{},
+								"http": map[string]interface{}{},
+							},
+						},
+					}},
+					Service: v1beta1.Service{
+						Pipelines: map[string]*v1beta1.Pipeline{
+							"traces": {
+								Receivers: []string{"otlp"},
+								Exporters: []string{"debug"},
+							},
+						},
+					},
+				},
+				Ingress: ingress,
+			},
+		},
+	}
+}
+
+func TestDesiredGatewayRoutes(t *testing.T) {
+	gatewayIngress := v1beta1.Ingress{
+		Type:        v1beta1.IngressTypeGateway,
+		Hostname:    "otel.example.com",
+		Annotations: map[string]string{"some.key": "some.value"},
+		Gateway: v1beta1.GatewayRoutes{
+			ParentRef: v1beta1.GatewayParentReference{Name: "public", Namespace: "gateways", SectionName: "https"},
+		},
+	}
+	expectedParentRefs := []gatewayv1.ParentReference{{
+		Name:        "public",
+		Namespace:   ptr.To(gatewayv1.Namespace("gateways")),
+		SectionName: ptr.To(gatewayv1.SectionName("https")),
+	}}
+
+	t.Run("should return nil for another ingress type", func(t *testing.T) {
+		params := gatewayParams(v1beta1.Ingress{Type: v1beta1.IngressTypeIngress})
+
+		httpRoutes, err := HTTPRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, httpRoutes)
+		grpcRoutes, err := GRPCRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, grpcRoutes)
+	})
+
+	t.Run("should return nil when the gateway api isn't available", func(t *testing.T) {
+		params := gatewayParams(gatewayIngress)
+		params.Config = config.New()
+
+		httpRoutes, err := HTTPRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, httpRoutes)
+		grpcRoutes, err := GRPCRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, grpcRoutes)
+	})
+
+	t.Run("should return nil in sidecar mode", func(t *testing.T) {
+		params := gatewayParams(gatewayIngress)
+		params.OtelCol.Spec.Mode = v1beta1.ModeSidecar
+
+		httpRoutes, err := HTTPRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, httpRoutes)
+	})
+
+	t.Run("should route the receivers by path", func(t *testing.T) {
+		params := gatewayParams(gatewayIngress)
+
+		httpRoutes, err := HTTPRoutes(params)
+		require.NoError(t, err)
+		require.Len(t, httpRoutes, 1)
+		httpRoute := httpRoutes[0]
+		assert.Equal(t, "otlp-http-otlp-route", httpRoute.Name)
+		assert.Equal(t, "observability", httpRoute.Namespace)
+		assert.Equal(t, map[string]string{"some.key": "some.value"}, httpRoute.Annotations)
+		assert.Equal(t, "opentelemetry-operator", httpRoute.Labels["app.kubernetes.io/managed-by"])
+		assert.Equal(t, gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: expectedParentRefs},
+			Hostnames:       []gatewayv1.Hostname{"otel.example.com"},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches: []gatewayv1.HTTPRouteMatch{{
+					Path: &gatewayv1.HTTPPathMatch{
+						Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+						Value: ptr.To("/otlp-http"),
+					},
+				}},
+				Filters: []gatewayv1.HTTPRouteFilter{{
+					Type: gatewayv1.HTTPRouteFilterURLRewrite,
+					URLRewrite: &gatewayv1.HTTPURLRewriteFilter{
+						Path: &gatewayv1.HTTPPathModifier{
+							Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+							ReplacePrefixMatch: ptr.To("/"),
+						},
+					},
+				}},
+				BackendRefs: []gatewayv1.HTTPBackendRef{{
+					BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{
+							Name: "otlp-collector",
+							Port: ptr.To(gatewayv1.PortNumber(4318)),
+						},
+					},
+				}},
+			}},
+		}, httpRoute.Spec)
+
+		// the gRPC receivers can't be told apart without subdomain
+		grpcRoutes, err := GRPCRoutes(params)
+		require.NoError(t, err)
+		assert.Empty(t, grpcRoutes)
+	})
+
+	t.Run("should route the receivers by subdomain", func(t *testing.T) {
+		ingress := gatewayIngress
+		ingress.RuleType = v1beta1.IngressRuleTypeSubdomain
+		params := gatewayParams(ingress)
+
+		httpRoutes, err := HTTPRoutes(params)
+		require.NoError(t, err)
+		require.Len(t, httpRoutes, 1)
+		assert.Equal(t, []gatewayv1.Hostname{"otlp-http.otel.example.com"}, httpRoutes[0].Spec.Hostnames)
+		assert.Equal(t, ptr.To("/"), httpRoutes[0].Spec.Rules[0].Matches[0].Path.Value)
+		assert.Empty(t, httpRoutes[0].Spec.Rules[0].Filters)
+
+		grpcRoutes, err := GRPCRoutes(params)
+		require.NoError(t, err)
+		require.Len(t, grpcRoutes, 1)
+		grpcRoute := grpcRoutes[0]
+		assert.Equal(t, "otlp-grpc-otlp-route", grpcRoute.Name)
+		assert.Equal(t, gatewayv1.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: expectedParentRefs},
+			Hostnames:       []gatewayv1.Hostname{"otlp-grpc.otel.example.com"},
+			Rules: []gatewayv1.GRPCRouteRule{{
+				BackendRefs: []gatewayv1.GRPCBackendRef{{
+					BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{
+							Name: "otlp-collector",
+							Port: ptr.To(gatewayv1.PortNumber(4317)),
+						},
+					},
+				}},
+			}},
+		}, grpcRoute.Spec)
+	})
+}
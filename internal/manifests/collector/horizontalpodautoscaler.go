@@ -48,6 +48,11 @@ func HorizontalPodAutoscaler(params manifests.Params) (*autoscalingv2.Horizontal
 		return nil, nil
 	}
 
+	// KEDA manages the HPA of its ScaledObject.
+	if usesKEDA(params) {
+		return nil, nil
+	}
+
 	metrics := []autoscalingv2.MetricSpec{}
 
 	if params.OtelCol.Spec.Autoscaler.TargetMemoryUtilization != nil {
@@ -96,6 +101,14 @@ func HorizontalPodAutoscaler(params manifests.Params) (*autoscalingv2.Horizontal
 			Metrics: metrics,
 		},
 	}
+	// the replicas set by the schedules or the idle scale down of the collector take precedence over the autoscaler.
+	if scaled := scaledReplicas(params.OtelCol); scaled != nil {
+		if *scaled == 0 {
+			return nil, nil
+		}
+		autoscaler.Spec.MinReplicas = scaled
+		autoscaler.Spec.MaxReplicas = *scaled
+	}
 	if params.OtelCol.Spec.Autoscaler.Behavior != nil {
 		autoscaler.Spec.Behavior = params.OtelCol.Spec.Autoscaler.Behavior
 	}
@@ -110,6 +123,26 @@ func HorizontalPodAutoscaler(params manifests.Params) (*autoscalingv2.Horizontal
 			autoscaler.Spec.Metrics = append(autoscaler.Spec.Metrics, v2metric)
 		} // pod metrics
 	}
+
+	// the telemetry metrics of the collector, served by an external metrics adapter.
+	for _, trigger := range params.OtelCol.Spec.Autoscaler.Triggers {
+		target := trigger.TargetAverageValue.DeepCopy()
+		autoscaler.Spec.Metrics = append(autoscaler.Spec.Metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{
+					Name: trigger.Metric,
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"service": naming.MonitoringService(params.OtelCol.Name)},
+					},
+				},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: &target,
+				},
+			},
+		})
+	}
 	result = &autoscaler
 
 	return result, nil
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+// NodePools builds the daemonset and the config map of the collector of each node pool.
+func NodePools(params manifests.Params) ([]client.Object, error) {
+	if params.OtelCol.Spec.Mode != v1beta1.ModeDaemonSet {
+		return nil, nil
+	}
+	var objects []client.Object
+	for i, pool := range params.OtelCol.Spec.NodePools {
+		poolParams := params
+		var err error
+		if poolParams.OtelCol, err = NodePoolCollector(params.OtelCol, i); err != nil {
+			return nil, err
+		}
+		hash, err := manifestutils.GetConfigMapSHA(poolParams.OtelCol.Spec.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		configMap, err := ConfigMap(poolParams)
+		if err != nil {
+			return nil, err
+		}
+		configMap.Name = naming.NodePoolConfigMap(params.OtelCol.Name, pool.Name, hash)
+		configMap.Labels[constants.LabelNodePool] = pool.Name
+
+		daemonSet, err := DaemonSet(poolParams)
+		if err != nil {
+			return nil, err
+		}
+		daemonSet.Name = naming.NodePoolCollector(params.OtelCol.Name, pool.Name)
+		// the labels are shared by the daemonset and its pods
+		daemonSet.Labels[constants.LabelNodePool] = pool.Name
+		daemonSet.Spec.Selector.MatchLabels[constants.LabelNodePool] = pool.Name
+		useConfigMap(&daemonSet.Spec.Template, configMap.Name)
+
+		objects = append(objects, configMap, daemonSet)
+	}
+	return objects, nil
+}
+
+// NodePoolCollector returns the collector running on the nodes of the i-th pool, with the configuration, resources and
+// node selector of the pool. Its pools are the previous ones, which take precedence over it.
+func NodePoolCollector(otelcol v1beta1.OpenTelemetryCollector, i int) (v1beta1.OpenTelemetryCollector, error) {
+	poolCollector := *otelcol.DeepCopy()
+	pool := poolCollector.Spec.NodePools[i]
+	if pool.Config != nil {
+		if err := poolCollector.Spec.Config.ApplyPatch(pool.Config.Object); err != nil {
+			return poolCollector, fmt.Errorf("failed to apply the config of the %s node pool: %w", pool.Name, err)
+		}
+	}
+	if pool.Resources != nil {
+		poolCollector.Spec.Resources = *pool.Resources
+	}
+	nodeSelector := map[string]string{}
+	for key, value := range poolCollector.Spec.NodeSelector {
+		nodeSelector[key] = value
+	}
+	for key, value := range pool.NodeSelector {
+		nodeSelector[key] = value
+	}
+	poolCollector.Spec.NodeSelector = nodeSelector
+	poolCollector.Spec.NodePools = poolCollector.Spec.NodePools[:i]
+	return poolCollector, nil
+}
+
+// nodePoolsAffinity returns the affinity of the collector, keeping it off the nodes of its pools.
+func nodePoolsAffinity(otelcol v1beta1.OpenTelemetryCollector) *corev1.Affinity {
+	if len(otelcol.Spec.NodePools) == 0 {
+		return otelcol.Spec.Affinity
+	}
+	var excluded []corev1.NodeSelectorTerm
+	for _, pool := range otelcol.Spec.NodePools {
+		// a node is out of the pool when one of the labels of its selector doesn't match
+		keys := make([]string, 0, len(pool.NodeSelector))
+		for key := range pool.NodeSelector {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var outOfPool []corev1.NodeSelectorTerm
+		for _, key := range keys {
+			outOfPool = append(outOfPool, corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      key,
+					Operator: corev1.NodeSelectorOpNotIn,
+					Values:   []string{pool.NodeSelector[key]},
+				}},
+			})
+		}
+		excluded = andNodeSelectorTerms(excluded, outOfPool)
+	}
+
+	affinity := &corev1.Affinity{}
+	if otelcol.Spec.Affinity != nil {
+		affinity = otelcol.Spec.Affinity.DeepCopy()
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	required.NodeSelectorTerms = andNodeSelectorTerms(required.NodeSelectorTerms, excluded)
+	return affinity
+}
+
+// andNodeSelectorTerms returns the terms matching the nodes matched by both lists of terms, each list matching the
+// nodes matched by any of its terms. An empty list doesn't restrict the nodes.
+func andNodeSelectorTerms(a, b []corev1.NodeSelectorTerm) []corev1.NodeSelectorTerm {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+	terms := make([]corev1.NodeSelectorTerm, 0, len(a)*len(b))
+	for _, x := range a {
+		for _, y := range b {
+			terms = append(terms, corev1.NodeSelectorTerm{
+				MatchExpressions: slices.Concat(x.MatchExpressions, y.MatchExpressions),
+				MatchFields:      slices.Concat(x.MatchFields, y.MatchFields),
+			})
+		}
+	}
+	return terms
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func nodePoolsParams() manifests.Params {
+	params := paramsWithMode(v1beta1.ModeDaemonSet)
+	params.OtelCol.Spec.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+	params.OtelCol.Spec.NodePools = []v1beta1.NodePool{
+		{
+			Name:         "gpu",
+			NodeSelector: map[string]string{"accelerator": "nvidia"},
+			Config: &v1beta1.AnyConfig{Object: map[string]interface{}{
+				"exporters": map[string]interface{}{
+					"debug": map[string]interface{}{"verbosity": "detailed"},
+				},
+			}},
+			Resources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			},
+		},
+		{
+			Name:         "edge",
+			NodeSelector: map[string]string{"node-role": "edge", "zone": "far"},
+		},
+	}
+	return params
+}
+
+func TestNodePools(t *testing.T) {
+	params := nodePoolsParams()
+
+	objects, err := NodePools(params)
+	require.NoError(t, err)
+	require.Len(t, objects, 4)
+
+	configMap := objects[0].(*corev1.ConfigMap)
+	assert.Regexp(t, "^test-collector-gpu-[0-9a-f]{8}$", configMap.Name)
+	assert.Equal(t, "gpu", configMap.Labels["opentelemetry.io/node-pool"])
+	assert.Contains(t, configMap.Data["collector.yaml"], "verbosity: detailed")
+
+	gpu := objects[1].(*appsv1.DaemonSet)
+	assert.Equal(t, "test-collector-gpu", gpu.Name)
+	assert.Equal(t, "gpu", gpu.Labels["opentelemetry.io/node-pool"])
+	assert.Equal(t, "gpu", gpu.Spec.Selector.MatchLabels["opentelemetry.io/node-pool"])
+	assert.Equal(t, "gpu", gpu.Spec.Template.Labels["opentelemetry.io/node-pool"])
+	assert.Equal(t, configMap.Name, gpu.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+	assert.Equal(t, map[string]string{"kubernetes.io/os": "linux", "accelerator": "nvidia"}, gpu.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, resource.MustParse("2Gi"), gpu.Spec.Template.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory])
+	// the first pool takes precedence over the others
+	assert.Nil(t, gpu.Spec.Template.Spec.Affinity)
+
+	edgeConfigMap := objects[2].(*corev1.ConfigMap)
+	assert.Regexp(t, "^test-collector-edge-[0-9a-f]{8}$", edgeConfigMap.Name)
+	assert.NotContains(t, edgeConfigMap.Data["collector.yaml"], "verbosity: detailed")
+
+	edge := objects[3].(*appsv1.DaemonSet)
+	assert.Equal(t, "test-collector-edge", edge.Name)
+	assert.Empty(t, edge.Spec.Template.Spec.Containers[0].Resources.Limits)
+	assert.Equal(t, []corev1.NodeSelectorTerm{
+		{MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: "accelerator", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"nvidia"}},
+		}},
+	}, edge.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+}
+
+func TestNodePoolsExcludedFromDaemonSet(t *testing.T) {
+	params := nodePoolsParams()
+	params.OtelCol.Spec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "kubernetes.io/arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"amd64"}},
+					}},
+				},
+			},
+		},
+	}
+
+	daemonSet, err := DaemonSet(params)
+	require.NoError(t, err)
+	assert.Equal(t, "test-collector", daemonSet.Name)
+	assert.NotContains(t, daemonSet.Spec.Selector.MatchLabels, "opentelemetry.io/node-pool")
+
+	arch := corev1.NodeSelectorRequirement{Key: "kubernetes.io/arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"amd64"}}
+	notGPU := corev1.NodeSelectorRequirement{Key: "accelerator", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"nvidia"}}
+	notEdge := corev1.NodeSelectorRequirement{Key: "node-role", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"edge"}}
+	notFar := corev1.NodeSelectorRequirement{Key: "zone", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"far"}}
+	assert.Equal(t, []corev1.NodeSelectorTerm{
+		{MatchExpressions: []corev1.NodeSelectorRequirement{arch, notGPU, notEdge}},
+		{MatchExpressions: []corev1.NodeSelectorRequirement{arch, notGPU, notFar}},
+	}, daemonSet.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+	// the affinity of the spec is left untouched
+	assert.Len(t, params.OtelCol.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms, 1)
+}
+
+func TestNodePoolsOnlyInDaemonSetMode(t *testing.T) {
+	params := nodePoolsParams()
+	params.OtelCol.Spec.Mode = v1beta1.ModeDeployment
+
+	objects, err := NodePools(params)
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}
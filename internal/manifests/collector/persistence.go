@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	defaultPersistenceDirectory = "/var/lib/otelcol/file_storage"
+	defaultPersistenceFSGroup   = int64(10001)
+)
+
+var defaultPersistenceSize = resource.MustParse("1Gi")
+
+// persistent returns whether the exporters of the collector persist their sending queues.
+func persistent(otelcol v1beta1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Persistence != nil && (otelcol.Spec.Mode == v1beta1.ModeStatefulSet || otelcol.Spec.Mode == v1beta1.ModeDaemonSet)
+}
+
+func persistenceDirectory(otelcol v1beta1.OpenTelemetryCollector) string {
+	if otelcol.Spec.Persistence.Directory == "" {
+		return defaultPersistenceDirectory
+	}
+	return otelcol.Spec.Persistence.Directory
+}
+
+func persistenceSize(otelcol v1beta1.OpenTelemetryCollector) resource.Quantity {
+	if otelcol.Spec.Persistence.Size.IsZero() {
+		return defaultPersistenceSize
+	}
+	return otelcol.Spec.Persistence.Size
+}
+
+// persistentConfig returns the configuration of the collector, with the sending queues of its exporters persisted.
+func persistentConfig(otelcol v1beta1.OpenTelemetryCollector) v1beta1.Config {
+	cfg := *otelcol.Spec.Config.DeepCopy()
+	if persistent(otelcol) {
+		cfg.InjectFileStorage(persistenceDirectory(otelcol))
+	}
+	return cfg
+}
+
+// persistenceVolumes returns the volume of the sending queues of a daemonset collector: a directory of the node, or
+// an emptyDir volume.
+func persistenceVolumes(otelcol v1beta1.OpenTelemetryCollector) []corev1.Volume {
+	if !persistent(otelcol) || otelcol.Spec.Mode != v1beta1.ModeDaemonSet {
+		return nil
+	}
+	volume := corev1.Volume{Name: naming.PersistenceVolume()}
+	if otelcol.Spec.Persistence.HostPath != "" {
+		hostPathType := corev1.HostPathDirectoryOrCreate
+		volume.HostPath = &corev1.HostPathVolumeSource{
+			Path: filepath.Join(otelcol.Spec.Persistence.HostPath, otelcol.Namespace, otelcol.Name),
+			Type: &hostPathType,
+		}
+	} else {
+		size := persistenceSize(otelcol)
+		volume.EmptyDir = &corev1.EmptyDirVolumeSource{SizeLimit: &size}
+	}
+	return []corev1.Volume{volume}
+}
+
+// persistenceVolumeClaimTemplates returns the volume claimed by each replica of a statefulset collector for its
+// sending queues.
+func persistenceVolumeClaimTemplates(otelcol v1beta1.OpenTelemetryCollector) []corev1.PersistentVolumeClaim {
+	if !persistent(otelcol) || otelcol.Spec.Mode != v1beta1.ModeStatefulSet {
+		return nil
+	}
+	return []corev1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{Name: naming.PersistenceVolume()},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: otelcol.Spec.Persistence.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: persistenceSize(otelcol)},
+			},
+		},
+	}}
+}
+
+func persistenceVolumeMounts(otelcol v1beta1.OpenTelemetryCollector) []corev1.VolumeMount {
+	if !persistent(otelcol) {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		Name:      naming.PersistenceVolume(),
+		MountPath: persistenceDirectory(otelcol),
+	}}
+}
+
+// podSecurityContext returns the security context of the collector pods, letting the collector write to the volume of
+// its sending queues.
+func podSecurityContext(otelcol v1beta1.OpenTelemetryCollector) *corev1.PodSecurityContext {
+	if !persistent(otelcol) || (otelcol.Spec.PodSecurityContext != nil && otelcol.Spec.PodSecurityContext.FSGroup != nil) {
+		return otelcol.Spec.PodSecurityContext
+	}
+	securityContext := &corev1.PodSecurityContext{}
+	if otelcol.Spec.PodSecurityContext != nil {
+		securityContext = otelcol.Spec.PodSecurityContext.DeepCopy()
+	}
+	fsGroup := defaultPersistenceFSGroup
+	if otelcol.Spec.Persistence.FSGroup != nil {
+		fsGroup = *otelcol.Spec.Persistence.FSGroup
+	}
+	securityContext.FSGroup = &fsGroup
+	return securityContext
+}
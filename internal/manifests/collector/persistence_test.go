@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+func persistenceParams(mode v1beta1.Mode, persistence v1beta1.PersistenceSpec) manifests.Params {
+	return manifests.Params{
+		Config: config.New(),
+		Log:    logger,
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "observability"},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:        mode,
+				Persistence: &persistence,
+				Config: v1beta1.Config{
+					Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{}}},
+					Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{"endpoint": "gateway:4317"}}},
+					Service: v1beta1.Service{
+						Pipelines: map[string]*v1beta1.Pipeline{
+							"traces": {Receivers: []string{"otlp"}, Exporters: []string{"otlp"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPersistenceStatefulSet(t *testing.T) {
+	storageClass := "fast"
+	params := persistenceParams(v1beta1.ModeStatefulSet, v1beta1.PersistenceSpec{
+		Size:             resource.MustParse("5Gi"),
+		StorageClassName: &storageClass,
+	})
+
+	statefulSet, err := StatefulSet(params)
+	require.NoError(t, err)
+	require.Len(t, statefulSet.Spec.VolumeClaimTemplates, 1)
+	claim := statefulSet.Spec.VolumeClaimTemplates[0]
+	assert.Equal(t, "otc-persistence", claim.Name)
+	assert.Equal(t, &storageClass, claim.Spec.StorageClassName)
+	assert.Equal(t, resource.MustParse("5Gi"), claim.Spec.Resources.Requests[corev1.ResourceStorage])
+	assert.Contains(t, statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "otc-persistence",
+		MountPath: "/var/lib/otelcol/file_storage",
+	})
+	assert.Equal(t, int64(10001), *statefulSet.Spec.Template.Spec.SecurityContext.FSGroup)
+
+	configMap, err := ConfigMap(params)
+	require.NoError(t, err)
+	assert.Contains(t, configMap.Data["collector.yaml"], "file_storage/persistence:\n    create_directory: true\n    directory: /var/lib/otelcol/file_storage")
+	assert.Contains(t, configMap.Data["collector.yaml"], "sending_queue:\n      storage: file_storage/persistence")
+	// the collector spec is left untouched
+	assert.Nil(t, params.OtelCol.Spec.Config.Extensions)
+	assert.NotContains(t, params.OtelCol.Spec.Config.Exporters.Object["otlp"], "sending_queue")
+}
+
+func TestPersistenceDaemonSet(t *testing.T) {
+	fsGroup := int64(2000)
+	params := persistenceParams(v1beta1.ModeDaemonSet, v1beta1.PersistenceSpec{
+		Directory: "/queues",
+		Size:      resource.MustParse("2Gi"),
+	})
+	params.OtelCol.Spec.PodSecurityContext = &corev1.PodSecurityContext{FSGroup: &fsGroup}
+
+	daemonSet, err := DaemonSet(params)
+	require.NoError(t, err)
+	sizeLimit := resource.MustParse("2Gi")
+	assert.Contains(t, daemonSet.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         "otc-persistence",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &sizeLimit}},
+	})
+	assert.Contains(t, daemonSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "otc-persistence",
+		MountPath: "/queues",
+	})
+	// the fsGroup of the spec takes precedence
+	assert.Equal(t, &fsGroup, daemonSet.Spec.Template.Spec.SecurityContext.FSGroup)
+
+	params.OtelCol.Spec.Persistence.HostPath = "/var/lib/otelcol"
+	daemonSet, err = DaemonSet(params)
+	require.NoError(t, err)
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	assert.Contains(t, daemonSet.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "otc-persistence",
+		VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{
+			Path: "/var/lib/otelcol/observability/agent",
+			Type: &hostPathType,
+		}},
+	})
+}
+
+func TestPersistenceDeployment(t *testing.T) {
+	params := persistenceParams(v1beta1.ModeDeployment, v1beta1.PersistenceSpec{})
+
+	deployment, err := Deployment(params)
+	require.NoError(t, err)
+	assert.Nil(t, deployment.Spec.Template.Spec.SecurityContext)
+	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, "otc-persistence", volume.Name)
+	}
+
+	configMap, err := ConfigMap(params)
+	require.NoError(t, err)
+	assert.NotContains(t, configMap.Data["collector.yaml"], "file_storage")
+}
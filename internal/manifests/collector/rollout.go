@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// rolloutEnabled returns whether the configuration changes of the collector are rolled out progressively. The rollout
+// is ignored without the feature gate, its status isn't updated anymore.
+func rolloutEnabled(otelcol v1beta1.OpenTelemetryCollector) bool {
+	return featuregate.EnableCollectorRollout.IsEnabled() && otelcol.Spec.Rollout != nil
+}
+
+// CanaryDeployment builds the deployment running the configuration being rolled out, next to the collector
+// deployment running the stable one.
+func CanaryDeployment(params manifests.Params) (*appsv1.Deployment, error) {
+	if !rolloutEnabled(params.OtelCol) || !candidateRunning(params.OtelCol) {
+		return nil, nil
+	}
+	// the canary pods have a component of their own, for the selectors of the collector pods not to match them
+	canary, err := deployment(params, ComponentOpenTelemetryCollectorCanary)
+	if err != nil {
+		return nil, err
+	}
+	canary.Name = naming.CollectorCanary(params.OtelCol.Name)
+	if params.OtelCol.Spec.Rollout.Strategy == v1beta1.RolloutStrategyCanary {
+		canaryReplicas := max(params.OtelCol.Spec.Rollout.CanaryReplicas, 1)
+		canary.Spec.Replicas = &canaryReplicas
+	}
+	return canary, nil
+}
+
+// stableConfigVersion returns the version of the configuration the collector deployment keeps running while a new
+// one is rolled out or after it's rolled back, or an empty string when it runs the current configuration.
+func stableConfigVersion(otelcol v1beta1.OpenTelemetryCollector) string {
+	rollout := otelcol.Status.Rollout
+	if !rolloutEnabled(otelcol) || rollout == nil {
+		return ""
+	}
+	if rollout.Phase == v1beta1.RolloutPhaseProgressing || rollout.Phase == v1beta1.RolloutPhaseRolledBack {
+		return rollout.StableConfigVersion
+	}
+	return ""
+}
+
+// candidateRunning tells whether the configuration being rolled out runs in the canary deployment.
+func candidateRunning(otelcol v1beta1.OpenTelemetryCollector) bool {
+	rollout := otelcol.Status.Rollout
+	return rollout != nil && rollout.CandidateConfigVersion != "" &&
+		(rollout.Phase == v1beta1.RolloutPhaseProgressing || rollout.Phase == v1beta1.RolloutPhasePromoting)
+}
+
+// pinConfigVersion makes the pods run the given version of the configuration, from the ConfigMap kept for it.
+func pinConfigVersion(otelcol v1beta1.OpenTelemetryCollector, template *corev1.PodTemplateSpec, version string) {
+	template.Annotations[manifestutils.ConfigHashAnnotation] = version
+	useConfigMap(template, naming.ConfigMap(otelcol.Name, version))
+}
+
+// serviceSelector returns the labels of the pods the services of the collector send the traffic to. With the canary
+// strategy, both the collector and the canary pods receive the traffic: they're selected by the name label they share.
+// With the blue/green strategy, only one of them receives the traffic: the canary pods once they're promoted, until
+// the collector deployment runs their configuration.
+func serviceSelector(params manifests.Params) map[string]string {
+	otelcol := params.OtelCol
+	selector := manifestutils.SelectorLabels(otelcol.ObjectMeta, ComponentOpenTelemetryCollector)
+	if otelcol.Spec.Mode != v1beta1.ModeDeployment || !rolloutEnabled(otelcol) {
+		return selector
+	}
+	if otelcol.Spec.Rollout.Strategy == v1beta1.RolloutStrategyBlueGreen {
+		if candidateRunning(otelcol) && otelcol.Status.Rollout.Phase == v1beta1.RolloutPhasePromoting {
+			return manifestutils.SelectorLabels(otelcol.ObjectMeta, ComponentOpenTelemetryCollectorCanary)
+		}
+		return selector
+	}
+	labels := manifestutils.Labels(otelcol.ObjectMeta, naming.Collector(otelcol.Name), otelcol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
+	delete(selector, "app.kubernetes.io/component")
+	selector["app.kubernetes.io/name"] = labels["app.kubernetes.io/name"]
+	return selector
+}
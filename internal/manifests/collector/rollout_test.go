@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfg "go.opentelemetry.io/collector/featuregate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func enableRollout(t *testing.T) {
+	require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableCollectorRollout.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableCollectorRollout.ID(), false))
+	})
+}
+
+const stableVersion = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func rolloutParams(strategy v1beta1.RolloutStrategy, phase v1beta1.RolloutPhase) manifests.Params {
+	replicas := int32(4)
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "observability"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Replicas: &replicas,
+			},
+			Rollout: &v1beta1.RolloutSpec{Strategy: strategy, CanaryReplicas: 1},
+		},
+	}
+	candidateVersion, _ := manifestutils.GetConfigMapSHA(otelcol.Spec.Config)
+	otelcol.Status.Rollout = &v1beta1.RolloutStatus{
+		Phase:                  phase,
+		StableConfigVersion:    stableVersion,
+		CandidateConfigVersion: candidateVersion,
+	}
+	if phase == v1beta1.RolloutPhasePromoting {
+		otelcol.Status.Rollout.StableConfigVersion = candidateVersion
+	}
+	return manifests.Params{
+		Config:  config.New(),
+		Log:     logger,
+		OtelCol: otelcol,
+	}
+}
+
+func TestCanaryRollout(t *testing.T) {
+	enableRollout(t)
+	params := rolloutParams(v1beta1.RolloutStrategyCanary, v1beta1.RolloutPhaseProgressing)
+	candidateVersion := params.OtelCol.Status.Rollout.CandidateConfigVersion
+
+	stable, err := Deployment(params)
+	require.NoError(t, err)
+	assert.Equal(t, ComponentOpenTelemetryCollector, stable.Spec.Template.Labels["app.kubernetes.io/component"])
+	assert.Equal(t, stableVersion, stable.Spec.Template.Annotations["opentelemetry-operator-config/sha256"])
+	assert.Equal(t, naming.ConfigMap("gateway", stableVersion), stable.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+	assert.Equal(t, manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector), stable.Spec.Selector.MatchLabels)
+
+	canary, err := CanaryDeployment(params)
+	require.NoError(t, err)
+	assert.Equal(t, "gateway-collector-canary", canary.Name)
+	assert.Equal(t, int32(1), *canary.Spec.Replicas)
+	assert.Equal(t, manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollectorCanary), canary.Spec.Selector.MatchLabels)
+	assert.Equal(t, ComponentOpenTelemetryCollectorCanary, canary.Spec.Template.Labels["app.kubernetes.io/component"])
+	// the selectors of the collector pods, e.g. of the pod disruption budget or the scaler, don't match the canary pods
+	assert.False(t, labels.SelectorFromSet(stable.Spec.Selector.MatchLabels).Matches(labels.Set(canary.Spec.Template.Labels)))
+	assert.Equal(t, candidateVersion, canary.Spec.Template.Annotations["opentelemetry-operator-config/sha256"])
+	assert.Equal(t, naming.ConfigMap("gateway", candidateVersion), canary.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+
+	// the canary pods receive their share of the traffic
+	params.OtelCol.Spec.Ports = []v1beta1.PortsSpec{{ServicePort: corev1.ServicePort{Name: "otlp", Port: 4317}}}
+	service, err := Service(params)
+	require.NoError(t, err)
+	assert.True(t, labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(stable.Spec.Template.Labels)))
+	assert.True(t, labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(canary.Spec.Template.Labels)))
+	headless, err := HeadlessService(params)
+	require.NoError(t, err)
+	assert.Equal(t, service.Spec.Selector, headless.Spec.Selector)
+	monitoring, err := MonitoringService(params)
+	require.NoError(t, err)
+	assert.False(t, labels.SelectorFromSet(monitoring.Spec.Selector).Matches(labels.Set(canary.Spec.Template.Labels)))
+}
+
+func TestRolledBackRollout(t *testing.T) {
+	enableRollout(t)
+	params := rolloutParams(v1beta1.RolloutStrategyCanary, v1beta1.RolloutPhaseRolledBack)
+
+	stable, err := Deployment(params)
+	require.NoError(t, err)
+	assert.Equal(t, stableVersion, stable.Spec.Template.Annotations["opentelemetry-operator-config/sha256"])
+
+	canary, err := CanaryDeployment(params)
+	require.NoError(t, err)
+	assert.Nil(t, canary)
+}
+
+func TestBlueGreenRollout(t *testing.T) {
+	enableRollout(t)
+	params := rolloutParams(v1beta1.RolloutStrategyBlueGreen, v1beta1.RolloutPhaseProgressing)
+
+	green, err := CanaryDeployment(params)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), *green.Spec.Replicas)
+
+	selector := func(params manifests.Params) map[string]string {
+		params.OtelCol.Spec.Ports = []v1beta1.PortsSpec{{ServicePort: corev1.ServicePort{Name: "otlp", Port: 4317}}}
+		service, err := Service(params)
+		require.NoError(t, err)
+		require.NotNil(t, service)
+		return service.Spec.Selector
+	}
+	// the new configuration doesn't receive any traffic until it's promoted
+	assert.Equal(t, ComponentOpenTelemetryCollector, selector(params)["app.kubernetes.io/component"])
+
+	promoting := rolloutParams(v1beta1.RolloutStrategyBlueGreen, v1beta1.RolloutPhasePromoting)
+	assert.Equal(t, ComponentOpenTelemetryCollectorCanary, selector(promoting)["app.kubernetes.io/component"])
+	stable, err := Deployment(promoting)
+	require.NoError(t, err)
+	assert.Equal(t, promoting.OtelCol.Status.Rollout.StableConfigVersion, stable.Spec.Template.Annotations["opentelemetry-operator-config/sha256"])
+
+	succeeded := rolloutParams(v1beta1.RolloutStrategyBlueGreen, v1beta1.RolloutPhaseSucceeded)
+	assert.Equal(t, ComponentOpenTelemetryCollector, selector(succeeded)["app.kubernetes.io/component"])
+	green, err = CanaryDeployment(succeeded)
+	require.NoError(t, err)
+	assert.Nil(t, green)
+}
+
+func TestRolloutWithoutFeatureGate(t *testing.T) {
+	params := rolloutParams(v1beta1.RolloutStrategyCanary, v1beta1.RolloutPhaseProgressing)
+
+	deployment, err := Deployment(params)
+	require.NoError(t, err)
+	assert.Equal(t, ComponentOpenTelemetryCollector, deployment.Spec.Template.Labels["app.kubernetes.io/component"])
+	assert.NotEqual(t, stableVersion, deployment.Spec.Template.Annotations["opentelemetry-operator-config/sha256"])
+
+	canary, err := CanaryDeployment(params)
+	require.NoError(t, err)
+	assert.Nil(t, canary)
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strconv"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	kedav1alpha1 "github.com/open-telemetry/opentelemetry-operator/internal/keda/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// ScaledObject returns the KEDA ScaledObject scaling the collector on the metrics of its own telemetry, queried from
+// the Prometheus server scraping them, in addition to its cpu and memory utilization.
+func ScaledObject(params manifests.Params) (*kedav1alpha1.ScaledObject, error) {
+	if params.OtelCol.Spec.Autoscaler == nil || !usesKEDA(params) {
+		return nil, nil
+	}
+
+	name := naming.ScaledObject(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	autoscaler := params.OtelCol.Spec.Autoscaler
+	var triggers []kedav1alpha1.ScaleTriggers
+	if autoscaler.TargetMemoryUtilization != nil {
+		triggers = append(triggers, kedav1alpha1.ScaleTriggers{
+			Type:       "memory",
+			MetricType: autoscalingv2.UtilizationMetricType,
+			Metadata:   map[string]string{"value": strconv.Itoa(int(*autoscaler.TargetMemoryUtilization))},
+		})
+	}
+	if autoscaler.TargetCPUUtilization != nil {
+		triggers = append(triggers, kedav1alpha1.ScaleTriggers{
+			Type:       "cpu",
+			MetricType: autoscalingv2.UtilizationMetricType,
+			Metadata:   map[string]string{"value": strconv.Itoa(int(*autoscaler.TargetCPUUtilization))},
+		})
+	}
+	for _, trigger := range autoscaler.Triggers {
+		triggers = append(triggers, kedav1alpha1.ScaleTriggers{
+			Type:       "prometheus",
+			MetricType: autoscalingv2.AverageValueMetricType,
+			Metadata: map[string]string{
+				"serverAddress": autoscaler.PrometheusServerAddress,
+				"query":         telemetryQuery(params.OtelCol, trigger.Metric),
+				"threshold":     strconv.FormatFloat(trigger.TargetAverageValue.AsApproximateFloat64(), 'f', -1, 64),
+			},
+		})
+	}
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				APIVersion: v1beta1.GroupVersion.String(),
+				Kind:       "OpenTelemetryCollector",
+				Name:       naming.OpenTelemetryCollector(params.OtelCol.Name),
+			},
+			MinReplicaCount: autoscaler.MinReplicas,
+			MaxReplicaCount: autoscaler.MaxReplicas,
+			Triggers:        triggers,
+		},
+	}
+	if scaled := scaledReplicas(params.OtelCol); scaled != nil {
+		if *scaled == 0 {
+			return nil, nil
+		}
+		scaledObject.Spec.MinReplicaCount = scaled
+		scaledObject.Spec.MaxReplicaCount = scaled
+	}
+	if autoscaler.Behavior != nil {
+		scaledObject.Spec.Advanced = &kedav1alpha1.AdvancedConfig{
+			HorizontalPodAutoscalerConfig: &kedav1alpha1.HorizontalPodAutoscalerConfig{Behavior: autoscaler.Behavior},
+		}
+	}
+	return scaledObject, nil
+}
+
+// usesKEDA is true when the collector is scaled on its telemetry through KEDA rather than an external metrics adapter.
+func usesKEDA(params manifests.Params) bool {
+	return params.OtelCol.Spec.Autoscaler != nil && len(params.OtelCol.Spec.Autoscaler.Triggers) > 0 &&
+		params.Config.KEDAAvailability() == keda.Available
+}
+
+// telemetryQuery returns the query summing the telemetry metric of all the replicas of the collector, scraped through
+// its monitoring service.
+func telemetryQuery(otelcol v1beta1.OpenTelemetryCollector, metric string) string {
+	return fmt.Sprintf(`sum(%s{namespace="%s",service="%s"})`, metric, otelcol.Namespace, naming.MonitoringService(otelcol.Name))
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	kedav1alpha1 "github.com/open-telemetry/opentelemetry-operator/internal/keda/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+func telemetryAutoscalerParams(kedaAvailability keda.Availability) manifests.Params {
+	minReplicas := int32(2)
+	maxReplicas := int32(10)
+	cpuUtilization := int32(80)
+	stabilizationWindow := int32(300)
+	return manifests.Params{
+		Config: config.New(config.WithKEDAAvailability(kedaAvailability)),
+		Log:    logger,
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "observability"},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Autoscaler: &v1beta1.AutoscalerSpec{
+					MinReplicas:          &minReplicas,
+					MaxReplicas:          &maxReplicas,
+					TargetCPUUtilization: &cpuUtilization,
+					Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+						ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: &stabilizationWindow},
+					},
+					Triggers: []v1beta1.AutoscalerTrigger{
+						{Metric: "otelcol_exporter_queue_size", TargetAverageValue: resource.MustParse("1500m")},
+					},
+					PrometheusServerAddress: "http://prometheus.monitoring:9090",
+				},
+			},
+		},
+	}
+}
+
+func TestHPAWithTelemetryTriggers(t *testing.T) {
+	params := telemetryAutoscalerParams(keda.NotAvailable)
+
+	hpa, err := HorizontalPodAutoscaler(params)
+	require.NoError(t, err)
+	require.Len(t, hpa.Spec.Metrics, 2)
+	target := resource.MustParse("1500m")
+	assert.Equal(t, autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: "otelcol_exporter_queue_size",
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"service": "gateway-collector-monitoring"},
+				},
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: &target,
+			},
+		},
+	}, hpa.Spec.Metrics[1])
+
+	scaledObject, err := ScaledObject(params)
+	require.NoError(t, err)
+	assert.Nil(t, scaledObject)
+}
+
+func TestScaledObject(t *testing.T) {
+	params := telemetryAutoscalerParams(keda.Available)
+
+	hpa, err := HorizontalPodAutoscaler(params)
+	require.NoError(t, err)
+	assert.Nil(t, hpa)
+
+	scaledObject, err := ScaledObject(params)
+	require.NoError(t, err)
+	assert.Equal(t, "gateway-collector", scaledObject.Name)
+	assert.Equal(t, "observability", scaledObject.Namespace)
+	assert.Equal(t, "gateway-collector", scaledObject.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, kedav1alpha1.ScaledObjectSpec{
+		ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+			APIVersion: "opentelemetry.io/v1beta1",
+			Kind:       "OpenTelemetryCollector",
+			Name:       "gateway",
+		},
+		MinReplicaCount: params.OtelCol.Spec.Autoscaler.MinReplicas,
+		MaxReplicaCount: params.OtelCol.Spec.Autoscaler.MaxReplicas,
+		Advanced: &kedav1alpha1.AdvancedConfig{
+			HorizontalPodAutoscalerConfig: &kedav1alpha1.HorizontalPodAutoscalerConfig{
+				Behavior: params.OtelCol.Spec.Autoscaler.Behavior,
+			},
+		},
+		Triggers: []kedav1alpha1.ScaleTriggers{
+			{
+				Type:       "cpu",
+				MetricType: autoscalingv2.UtilizationMetricType,
+				Metadata:   map[string]string{"value": "80"},
+			},
+			{
+				Type:       "prometheus",
+				MetricType: autoscalingv2.AverageValueMetricType,
+				Metadata: map[string]string{
+					"serverAddress": "http://prometheus.monitoring:9090",
+					"query":         `sum(otelcol_exporter_queue_size{namespace="observability",service="gateway-collector-monitoring"})`,
+					"threshold":     "1.5",
+				},
+			},
+		},
+	}, scaledObject.Spec)
+
+	// without triggers, the collector is scaled by its HPA even when KEDA is available
+	params.OtelCol.Spec.Autoscaler.Triggers = nil
+	scaledObject, err = ScaledObject(params)
+	require.NoError(t, err)
+	assert.Nil(t, scaledObject)
+	hpa, err = HorizontalPodAutoscaler(params)
+	require.NoError(t, err)
+	assert.NotNil(t, hpa)
+}
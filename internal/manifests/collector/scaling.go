@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// scaledReplicas returns the replicas of the collector set by its schedules or by its idle scale down, as computed in
+// its status, or nil when the collector is scaled from its spec. The status isn't updated anymore once the scaling is
+// disabled, and is then ignored.
+func scaledReplicas(otelcol v1beta1.OpenTelemetryCollector) *int32 {
+	if !featuregate.EnableCollectorScaling.IsEnabled() || otelcol.Spec.Scaling == nil || otelcol.Status.Scaling == nil {
+		return nil
+	}
+	return otelcol.Status.Scaling.Replicas
+}
+
+// replicas returns the replicas of the workload of the collector.
+func replicas(otelcol v1beta1.OpenTelemetryCollector) *int32 {
+	if scaled := scaledReplicas(otelcol); scaled != nil {
+		return scaled
+	}
+	return otelcol.Spec.Replicas
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfg "go.opentelemetry.io/collector/featuregate"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestScaledReplicas(t *testing.T) {
+	require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableCollectorScaling.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableCollectorScaling.ID(), false))
+	})
+	specReplicas := int32(3)
+	scheduled := int32(5)
+	idle := int32(0)
+
+	params := telemetryAutoscalerParams(keda.NotAvailable)
+	params.OtelCol.Spec.Replicas = &specReplicas
+	params.OtelCol.Spec.Scaling = &v1beta1.ScalingSpec{}
+
+	t.Run("scaled from the spec", func(t *testing.T) {
+		deployment, err := Deployment(params)
+		require.NoError(t, err)
+		assert.Equal(t, &specReplicas, deployment.Spec.Replicas)
+
+		hpa, err := HorizontalPodAutoscaler(params)
+		require.NoError(t, err)
+		assert.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+	})
+
+	t.Run("scaled by a schedule", func(t *testing.T) {
+		params := params
+		params.OtelCol.Status.Scaling = &v1beta1.ScalingStatus{Replicas: &scheduled, Reason: v1beta1.ScalingReasonSchedule, Schedule: "peak"}
+
+		deployment, err := Deployment(params)
+		require.NoError(t, err)
+		assert.Equal(t, &scheduled, deployment.Spec.Replicas)
+
+		statefulSet, err := StatefulSet(params)
+		require.NoError(t, err)
+		assert.Equal(t, &scheduled, statefulSet.Spec.Replicas)
+
+		hpa, err := HorizontalPodAutoscaler(params)
+		require.NoError(t, err)
+		assert.Equal(t, &scheduled, hpa.Spec.MinReplicas)
+		assert.Equal(t, scheduled, hpa.Spec.MaxReplicas)
+
+		params.Config = telemetryAutoscalerParams(keda.Available).Config
+		scaledObject, err := ScaledObject(params)
+		require.NoError(t, err)
+		assert.Equal(t, &scheduled, scaledObject.Spec.MinReplicaCount)
+		assert.Equal(t, &scheduled, scaledObject.Spec.MaxReplicaCount)
+	})
+
+	t.Run("scaled down when idle", func(t *testing.T) {
+		params := params
+		params.OtelCol.Status.Scaling = &v1beta1.ScalingStatus{Replicas: &idle, Reason: v1beta1.ScalingReasonIdle}
+
+		deployment, err := Deployment(params)
+		require.NoError(t, err)
+		assert.Equal(t, &idle, deployment.Spec.Replicas)
+
+		hpa, err := HorizontalPodAutoscaler(params)
+		require.NoError(t, err)
+		assert.Nil(t, hpa)
+
+		params.Config = telemetryAutoscalerParams(keda.Available).Config
+		scaledObject, err := ScaledObject(params)
+		require.NoError(t, err)
+		assert.Nil(t, scaledObject)
+	})
+
+	t.Run("status ignored without scaling", func(t *testing.T) {
+		params := params
+		params.OtelCol.Spec.Scaling = nil
+		params.OtelCol.Status.Scaling = &v1beta1.ScalingStatus{Replicas: &idle, Reason: v1beta1.ScalingReasonIdle}
+
+		deployment, err := Deployment(params)
+		require.NoError(t, err)
+		assert.Equal(t, &specReplicas, deployment.Spec.Replicas)
+	})
+	t.Run("status ignored without the feature gate", func(t *testing.T) {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableCollectorScaling.ID(), false))
+		t.Cleanup(func() {
+			require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableCollectorScaling.ID(), true))
+		})
+		params := params
+		params.OtelCol.Status.Scaling = &v1beta1.ScalingStatus{Replicas: &idle, Reason: v1beta1.ScalingReasonIdle}
+
+		deployment, err := Deployment(params)
+		require.NoError(t, err)
+		assert.Equal(t, &specReplicas, deployment.Spec.Replicas)
+	})
+}
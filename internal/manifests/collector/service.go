@@ -136,7 +136,7 @@ func ExtensionService(params manifests.Params) (*corev1.Service, error) {
 		},
 		Spec: corev1.ServiceSpec{
 			Ports:    ports,
-			Selector: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			Selector: serviceSelector(params),
 		},
 	}, nil
 }
@@ -205,7 +205,7 @@ func Service(params manifests.Params) (*corev1.Service, error) {
 		},
 		Spec: corev1.ServiceSpec{
 			InternalTrafficPolicy: &trafficPolicy,
-			Selector:              manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			Selector:              serviceSelector(params),
 			ClusterIP:             "",
 			Ports:                 ports,
 			IPFamilies:            params.OtelCol.Spec.IpFamilies,
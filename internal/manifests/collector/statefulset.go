@@ -67,13 +67,13 @@ func StatefulSet(params manifests.Params) (*appsv1.StatefulSet, error) {
 					ShareProcessNamespace:     &params.OtelCol.Spec.ShareProcessNamespace,
 					Tolerations:               params.OtelCol.Spec.Tolerations,
 					NodeSelector:              params.OtelCol.Spec.NodeSelector,
-					SecurityContext:           params.OtelCol.Spec.PodSecurityContext,
+					SecurityContext:           podSecurityContext(params.OtelCol),
 					PriorityClassName:         params.OtelCol.Spec.PriorityClassName,
 					Affinity:                  params.OtelCol.Spec.Affinity,
 					TopologySpreadConstraints: params.OtelCol.Spec.TopologySpreadConstraints,
 				},
 			},
-			Replicas:                             params.OtelCol.Spec.Replicas,
+			Replicas:                             replicas(params.OtelCol),
 			PodManagementPolicy:                  "Parallel",
 			VolumeClaimTemplates:                 VolumeClaimTemplates(params.OtelCol),
 			PersistentVolumeClaimRetentionPolicy: params.OtelCol.Spec.PersistentVolumeClaimRetentionPolicy,
@@ -58,6 +58,8 @@ func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) []corev1
 		volumes = append(volumes, otelcol.Spec.Volumes...)
 	}
 
+	volumes = append(volumes, persistenceVolumes(otelcol)...)
+
 	if len(otelcol.Spec.ConfigMaps) > 0 {
 		for keyCfgMap := range otelcol.Spec.ConfigMaps {
 			volumes = append(volumes, corev1.Volume{
@@ -75,3 +77,12 @@ func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) []corev1
 
 	return volumes
 }
+
+// useConfigMap makes the pods read the collector configuration from the given config map.
+func useConfigMap(template *corev1.PodTemplateSpec, configMap string) {
+	for i, volume := range template.Spec.Volumes {
+		if volume.Name == naming.ConfigMapVolume() && volume.ConfigMap != nil {
+			template.Spec.Volumes[i].ConfigMap.Name = configMap
+		}
+	}
+}
@@ -16,6 +16,8 @@
 package collector
 
 import (
+	"slices"
+
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
@@ -29,5 +31,5 @@ func VolumeClaimTemplates(otelcol v1beta1.OpenTelemetryCollector) []corev1.Persi
 	}
 
 	// Add all user specified claims.
-	return otelcol.Spec.VolumeClaimTemplates
+	return slices.Concat(otelcol.Spec.VolumeClaimTemplates, persistenceVolumeClaimTemplates(otelcol))
 }
@@ -22,6 +22,9 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 )
 
+// ConfigHashAnnotation is the pod annotation holding the hash of the collector configuration the pod runs.
+const ConfigHashAnnotation = "opentelemetry-operator-config/sha256"
+
 // Annotations return the annotations for OpenTelemetryCollector resources.
 func Annotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations []string) (map[string]string, error) {
 	// new map every time, so that we don't touch the instance's annotations
@@ -84,7 +87,7 @@ func PodAnnotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations [
 	}
 
 	// Adding the ConfigMap Hash only to PodAnnotations
-	podAnnotations["opentelemetry-operator-config/sha256"] = hash
+	podAnnotations[ConfigHashAnnotation] = hash
 
 	return podAnnotations, nil
 }
@@ -31,8 +31,10 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	kedav1alpha1 "github.com/open-telemetry/opentelemetry-operator/internal/keda/v1alpha1"
 )
 
 type ImmutableFieldChangeErr struct {
@@ -64,6 +66,9 @@ var (
 // - Ingress
 // - HorizontalPodAutoscaler
 // - Route
+// - HTTPRoute
+// - GRPCRoute
+// - ScaledObject
 // - Secret
 // - TargetAllocator
 // In order for the operator to reconcile other types, they must be added here.
@@ -172,6 +177,21 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantRt := desired.(*routev1.Route)
 			mutateRoute(rt, wantRt)
 
+		case *kedav1alpha1.ScaledObject:
+			so := existing.(*kedav1alpha1.ScaledObject)
+			wantSo := desired.(*kedav1alpha1.ScaledObject)
+			mutateScaledObject(so, wantSo)
+
+		case *gatewayv1.HTTPRoute:
+			rt := existing.(*gatewayv1.HTTPRoute)
+			wantRt := desired.(*gatewayv1.HTTPRoute)
+			mutateHTTPRoute(rt, wantRt)
+
+		case *gatewayv1.GRPCRoute:
+			rt := existing.(*gatewayv1.GRPCRoute)
+			wantRt := desired.(*gatewayv1.GRPCRoute)
+			mutateGRPCRoute(rt, wantRt)
+
 		case *corev1.Secret:
 			pr := existing.(*corev1.Secret)
 			wantPr := desired.(*corev1.Secret)
@@ -269,6 +289,24 @@ func mutateRoute(existing, desired *routev1.Route) {
 	existing.Spec = desired.Spec
 }
 
+func mutateScaledObject(existing, desired *kedav1alpha1.ScaledObject) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
+func mutateHTTPRoute(existing, desired *gatewayv1.HTTPRoute) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
+func mutateGRPCRoute(existing, desired *gatewayv1.GRPCRoute) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
 func mutateServiceMonitor(existing, desired *monitoringv1.ServiceMonitor) {
 	existing.Annotations = desired.Annotations
 	existing.Labels = desired.Labels
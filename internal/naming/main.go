@@ -21,6 +21,18 @@ func ConfigMap(otelcol, configHash string) string {
 	return DNSName(Truncate("%s-collector-%s", 63, otelcol, configHash[:8]))
 }
 
+// NodePoolConfigMap builds the name for the config map used in the OpenTelemetryCollector containers of a node pool.
+// The configHash should be calculated using manifestutils.GetConfigMapSHA.
+func NodePoolConfigMap(otelcol, pool, configHash string) string {
+	return DNSName(Truncate("%s-collector-%s-%s", 63, otelcol, pool, configHash[:8]))
+}
+
+// SidecarConfigMap builds the name for the config map used in the sidecar OpenTelemetryCollector containers. Unlike
+// the versioned config map, it's updated in place for the running sidecars to pick up the changes.
+func SidecarConfigMap(otelcol string) string {
+	return DNSName(Truncate("%s-collector-sidecar", 63, otelcol))
+}
+
 // TAConfigMap returns the name for the config map used in the TargetAllocator.
 func TAConfigMap(targetAllocator string) string {
 	return DNSName(Truncate("%s-targetallocator", 63, targetAllocator))
@@ -41,6 +53,11 @@ func ConfigMapExtra(extraConfigMapName string) string {
 	return DNSName(Truncate("configmap-%s", 63, extraConfigMapName))
 }
 
+// PersistenceVolume returns the name to use for the volume the exporters persist their sending queues to.
+func PersistenceVolume() string {
+	return "otc-persistence"
+}
+
 // TAConfigMapVolume returns the name to use for the config map's volume in the TargetAllocator pod.
 func TAConfigMapVolume() string {
 	return "ta-internal"
@@ -56,6 +73,11 @@ func Container() string {
 	return "otc-container"
 }
 
+// SidecarConfigReloader returns the name to use for the container reloading the configuration of the sidecar.
+func SidecarConfigReloader() string {
+	return "otc-config-reloader"
+}
+
 // TAContainer returns the name to use for the container in the TargetAllocator pod.
 func TAContainer() string {
 	return "ta-container"
@@ -71,11 +93,26 @@ func Collector(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// CollectorCanary builds the name of the deployment running the configuration being rolled out.
+func CollectorCanary(otelcol string) string {
+	return DNSName(Truncate("%s-collector-canary", 63, otelcol))
+}
+
+// NodePoolCollector builds the name of the daemonset running the collector on the nodes of a pool.
+func NodePoolCollector(otelcol, pool string) string {
+	return DNSName(Truncate("%s-collector-%s", 63, otelcol, pool))
+}
+
 // HorizontalPodAutoscaler builds the autoscaler name based on the instance.
 func HorizontalPodAutoscaler(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// ScaledObject builds the KEDA ScaledObject name based on the instance.
+func ScaledObject(otelcol string) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol))
+}
+
 // PodDisruptionBudget builds the pdb name based on the instance.
 func PodDisruptionBudget(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
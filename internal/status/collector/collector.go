@@ -17,17 +17,24 @@ package collector
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1beta1.OpenTelemetryCollector) error {
@@ -41,8 +48,10 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 	if mode == v1beta1.ModeSidecar {
 		changed.Status.Scale.Replicas = 0
 		changed.Status.Scale.Selector = ""
-		return nil
+		conditions.SetRollout(&changed.Status.Conditions, changed.Generation, nil)
+		return updateSidecarsStatus(ctx, cli, changed)
 	}
+	changed.Status.Sidecars = nil
 
 	name := naming.Collector(changed.Name)
 
@@ -64,6 +73,7 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 	var readyReplicas int32
 	var statusReplicas string
 	var statusImage string
+	var rollout conditions.Rollout
 
 	switch mode { // nolint:exhaustive
 	case v1beta1.ModeDeployment:
@@ -75,6 +85,7 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 		readyReplicas = obj.Status.ReadyReplicas
 		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
+		rollout = conditions.FromDeployment(obj)
 
 	case v1beta1.ModeStatefulSet:
 		obj := &appsv1.StatefulSet{}
@@ -85,6 +96,7 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 		readyReplicas = obj.Status.ReadyReplicas
 		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
+		rollout = conditions.FromStatefulSet(obj)
 
 	case v1beta1.ModeDaemonSet:
 		obj := &appsv1.DaemonSet{}
@@ -92,11 +104,105 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 			return fmt.Errorf("failed to get daemonSet status.replicas: %w", err)
 		}
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
+		rollout = conditions.FromDaemonSet(obj)
+	}
+
+	if mode == v1beta1.ModeDaemonSet {
+		if err := updateNodePoolsStatus(ctx, cli, changed); err != nil {
+			return err
+		}
+	} else {
+		changed.Status.NodePools = nil
 	}
 
 	changed.Status.Scale.Replicas = replicas
 	changed.Status.Image = statusImage
 	changed.Status.Scale.StatusReplicas = statusReplicas
+	conditions.SetRollout(&changed.Status.Conditions, changed.Generation, &rollout)
+
+	return nil
+}
+
+// updateNodePoolsStatus reports the daemonset of each node pool of the collector. The daemonset of a pool that was
+// just added may not exist yet.
+func updateNodePoolsStatus(ctx context.Context, cli client.Client, changed *v1beta1.OpenTelemetryCollector) error {
+	var statuses []v1beta1.NodePoolStatus
+	for _, pool := range changed.Spec.NodePools {
+		status := v1beta1.NodePoolStatus{Name: pool.Name}
+		obj := &appsv1.DaemonSet{}
+		err := cli.Get(ctx, client.ObjectKey{Namespace: changed.Namespace, Name: naming.NodePoolCollector(changed.Name, pool.Name)}, obj)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get the daemonSet of the %s node pool: %w", pool.Name, err)
+		}
+		if err == nil {
+			for _, volume := range obj.Spec.Template.Spec.Volumes {
+				if volume.Name == naming.ConfigMapVolume() && volume.ConfigMap != nil {
+					status.ConfigMap = volume.ConfigMap.Name
+				}
+			}
+			status.DesiredNumberScheduled = obj.Status.DesiredNumberScheduled
+			status.NumberReady = obj.Status.NumberReady
+			status.UpdatedNumberScheduled = obj.Status.UpdatedNumberScheduled
+		}
+		statuses = append(statuses, status)
+	}
+	changed.Status.NodePools = statuses
+	return nil
+}
 
+// updateSidecarsStatus counts the pods the sidecar collector is injected into, and the ones running an outdated
+// configuration. The config hash of the status is expected to be up-to-date.
+func updateSidecarsStatus(ctx context.Context, cli client.Client, changed *v1beta1.OpenTelemetryCollector) error {
+	if !featuregate.EnableSidecarConfigMap.IsEnabled() {
+		changed.Status.Sidecars = nil
+		return nil
+	}
+	var pods corev1.PodList
+	if err := cli.List(ctx, &pods, client.InNamespace(changed.Namespace), client.MatchingLabels{
+		constants.LabelSidecarInjected: naming.Truncate("%s.%s", 63, changed.Namespace, changed.Name),
+	}); err != nil {
+		return fmt.Errorf("failed to list the pods the sidecar is injected into: %w", err)
+	}
+	status := &v1beta1.SidecarsStatus{}
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		status.Pods++
+		reloads := slices.ContainsFunc(pod.Spec.Containers, func(c corev1.Container) bool {
+			return c.Name == naming.SidecarConfigReloader()
+		})
+		// the pods injected before the hash was recorded can't be told apart and are reported as outdated
+		if !reloads && pod.Annotations[constants.AnnotationSidecarConfigHash] != changed.Status.ConfigHash {
+			status.OutdatedPods++
+		}
+	}
+	changed.Status.Sidecars = status
+	return nil
+}
+
+// UpdateTargetAllocatorCondition sets the TargetAllocatorReady condition of the collector from the rollout of the
+// given target allocator, which is nil when the collector doesn't use one.
+func UpdateTargetAllocatorCondition(ctx context.Context, cli client.Client, changed *v1beta1.OpenTelemetryCollector, ta *v1alpha1.TargetAllocator) error {
+	if ta == nil {
+		conditions.SetTargetAllocatorReady(&changed.Status.Conditions, changed.Generation, nil)
+		return nil
+	}
+
+	obj := &appsv1.Deployment{}
+	objKey := client.ObjectKey{
+		Namespace: changed.GetNamespace(),
+		Name:      naming.TargetAllocator(ta.Name),
+	}
+	rollout := conditions.Rollout{}
+	if err := cli.Get(ctx, objKey, obj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get target allocator deployment: %w", err)
+		}
+		// the deployment is created by the target allocator reconciler, report it as pending until it shows up
+	} else {
+		rollout = conditions.FromDeployment(obj)
+	}
+	conditions.SetTargetAllocatorReady(&changed.Status.Conditions, changed.Generation, &rollout)
 	return nil
 }
@@ -19,13 +19,18 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func TestUpdateCollectorStatusUnsupported(t *testing.T) {
@@ -47,6 +52,7 @@ func TestUpdateCollectorStatusUnsupported(t *testing.T) {
 
 	assert.Equal(t, int32(0), changed.Status.Scale.Replicas, "expected replicas to be 0")
 	assert.Equal(t, "", changed.Status.Scale.Selector, "expected selector to be empty")
+	assert.True(t, meta.IsStatusConditionTrue(changed.Status.Conditions, v1beta1.ConditionTypeReady), "expected sidecar to be ready")
 }
 
 func createMockKubernetesClientDeployment() client.Client {
@@ -190,3 +196,146 @@ func TestUpdateCollectorStatusDaemonsetMode(t *testing.T) {
 	assert.Contains(t, changed.Status.Scale.Selector, "customLabel=customValue", "expected selector to contain customlabel=customValue")
 	assert.Equal(t, "app:latest", changed.Status.Image, "expected image to be app:latest")
 }
+
+func TestUpdateCollectorStatusNodePools(t *testing.T) {
+	ctx := context.TODO()
+	daemonSet := func(name string) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "otc-container", Image: "app:latest"}},
+						Volumes: []corev1.Volume{{
+							Name: "otc-internal",
+							VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: name + "-1a2b3c4d"},
+							}},
+						}},
+					},
+				},
+			},
+			Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 2, UpdatedNumberScheduled: 3},
+		}
+	}
+	cli := fake.NewClientBuilder().WithObjects(daemonSet("agent-collector"), daemonSet("agent-collector-gpu")).Build()
+
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDaemonSet,
+			NodePools: []v1beta1.NodePool{
+				{Name: "gpu", NodeSelector: map[string]string{"accelerator": "nvidia"}},
+				{Name: "edge", NodeSelector: map[string]string{"node-role": "edge"}},
+			},
+		},
+	}
+
+	require.NoError(t, UpdateCollectorStatus(ctx, cli, changed))
+	assert.Equal(t, []v1beta1.NodePoolStatus{
+		{
+			Name:                   "gpu",
+			ConfigMap:              "agent-collector-gpu-1a2b3c4d",
+			DesiredNumberScheduled: 3,
+			NumberReady:            2,
+			UpdatedNumberScheduled: 3,
+		},
+		// the daemonset of the pool isn't created yet
+		{Name: "edge"},
+	}, changed.Status.NodePools)
+}
+
+func TestUpdateTargetAllocatorCondition(t *testing.T) {
+	ctx := context.TODO()
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-targetallocator",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			UpdatedReplicas: 1,
+			ReadyReplicas:   1,
+		},
+	}
+	cli := fake.NewClientBuilder().WithObjects(deployment).Build()
+
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+	}
+
+	ta := &v1alpha1.TargetAllocator{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	assert.NoError(t, UpdateTargetAllocatorCondition(ctx, cli, changed, ta))
+	condition := meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionTypeTargetAllocatorReady)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	}
+
+	missing := &v1alpha1.TargetAllocator{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "default"}}
+	assert.NoError(t, UpdateTargetAllocatorCondition(ctx, cli, changed, missing))
+	condition = meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionTypeTargetAllocatorReady)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, v1beta1.ConditionReasonRolloutInProgress, condition.Reason)
+	}
+
+	assert.NoError(t, UpdateTargetAllocatorCondition(ctx, cli, changed, nil))
+	assert.Nil(t, meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionTypeTargetAllocatorReady))
+}
+
+func TestUpdateCollectorStatusSidecarPods(t *testing.T) {
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableSidecarConfigMap.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableSidecarConfigMap.ID(), false))
+	})
+
+	sidecarPod := func(name, hash string, containers ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Labels:      map[string]string{"sidecar.opentelemetry.io/injected": "default.test-sidecar"},
+				Annotations: map[string]string{},
+			},
+		}
+		if hash != "" {
+			pod.Annotations["sidecar.opentelemetry.io/config-hash"] = hash
+		}
+		for _, c := range containers {
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: c})
+		}
+		return pod
+	}
+	completed := sidecarPod("completed", "old", "otc-container")
+	completed.Status.Phase = corev1.PodSucceeded
+	otherCollector := sidecarPod("other", "old", "otc-container")
+	otherCollector.Labels["sidecar.opentelemetry.io/injected"] = "default.other"
+	cli := fake.NewClientBuilder().WithObjects(
+		sidecarPod("current", "current", "otc-container"),
+		sidecarPod("outdated", "old", "otc-container"),
+		sidecarPod("unknown", "", "otc-container"),
+		sidecarPod("reloaded", "old", "otc-container", "otc-config-reloader"),
+		completed,
+		otherCollector,
+	).Build()
+
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-sidecar",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeSidecar,
+		},
+		Status: v1beta1.OpenTelemetryCollectorStatus{
+			ConfigHash: "current",
+		},
+	}
+
+	assert.NoError(t, UpdateCollectorStatus(context.TODO(), cli, changed))
+	assert.Equal(t, &v1beta1.SidecarsStatus{Pods: 4, OutdatedPods: 2}, changed.Status.Sidecars)
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"sort"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/exporters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/extensions"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/processors"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/receivers"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// UpdateConfigStatus records the effective configuration of the collector described by params in the status of changed.
+func UpdateConfigStatus(params manifests.Params, changed *v1beta1.OpenTelemetryCollector) error {
+	cfg := params.OtelCol.Spec.Config
+	hash, err := manifestutils.GetConfigMapSHA(cfg)
+	if err != nil {
+		return err
+	}
+	changed.Status.ConfigHash = hash
+	changed.Status.ConfigMap = naming.ConfigMap(params.OtelCol.Name, hash)
+	changed.Status.Pipelines = pipelinesStatus(cfg)
+	changed.Status.UnknownComponents = unknownComponents(cfg)
+
+	var ports []v1beta1.PortStatus
+	for _, p := range collector.Container(params.Config, params.Log, params.OtelCol, true).Ports {
+		ports = append(ports, v1beta1.PortStatus{Name: p.Name, Port: p.ContainerPort, Protocol: p.Protocol})
+	}
+	changed.Status.Ports = ports
+	return nil
+}
+
+func pipelinesStatus(cfg v1beta1.Config) []v1beta1.PipelineStatus {
+	var pipelines []v1beta1.PipelineStatus
+	for name, pipeline := range cfg.Service.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		pipelines = append(pipelines, v1beta1.PipelineStatus{
+			Name:       name,
+			Receivers:  pipeline.Receivers,
+			Processors: pipeline.Processors,
+			Exporters:  pipeline.Exporters,
+		})
+	}
+	sort.Slice(pipelines, func(i, j int) bool {
+		return pipelines[i].Name < pipelines[j].Name
+	})
+	return pipelines
+}
+
+// unknownComponents returns the enabled components that are neither part of the collector distributions nor have a
+// registered parser, which usually means a typo or a component of a custom distribution. Connectors are skipped, they
+// show up as receivers and exporters in the pipelines but the operator never derives anything from them.
+func unknownComponents(cfg v1beta1.Config) []v1beta1.ComponentStatus {
+	isRegistered := map[v1beta1.ComponentKind]func(string) bool{
+		v1beta1.KindReceiver:  receivers.IsRegistered,
+		v1beta1.KindExporter:  exporters.IsRegistered,
+		v1beta1.KindProcessor: processors.IsRegistered,
+		v1beta1.KindExtension: extensions.IsRegistered,
+	}
+	var unknown []v1beta1.ComponentStatus
+	for kind, enabled := range cfg.GetEnabledComponents() {
+		for id := range enabled {
+			if cfg.Connectors != nil {
+				if _, ok := cfg.Connectors.Object[id]; ok {
+					continue
+				}
+			}
+			componentType := components.ComponentType(id)
+			if !components.IsKnown(kind.String(), componentType) && !isRegistered[kind](componentType) {
+				unknown = append(unknown, v1beta1.ComponentStatus{Kind: kind.String(), ID: id})
+			}
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool {
+		if unknown[i].Kind != unknown[j].Kind {
+			return unknown[i].Kind < unknown[j].Kind
+		}
+		return unknown[i].ID < unknown[j].ID
+	})
+	return unknown
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	go_yaml "gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+func TestUpdateConfigStatus(t *testing.T) {
+	cfg := v1beta1.Config{}
+	require.NoError(t, go_yaml.Unmarshal([]byte(`
+receivers:
+  otlp:
+    protocols:
+      grpc: {}
+  mycustomreceiver: {}
+processors:
+  batch: {}
+  k8sattributes: {}
+exporters:
+  debug: {}
+connectors:
+  spanmetrics: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, mycustomreceiver]
+      processors: [k8sattributes, batch]
+      exporters: [spanmetrics, debug]
+    metrics:
+      receivers: [spanmetrics]
+      exporters: [debug]
+`), &cfg))
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode:   v1beta1.ModeDeployment,
+			Config: cfg,
+		},
+	}
+	params := manifests.Params{
+		Config:  config.New(),
+		Log:     logf.Log.WithName("status"),
+		OtelCol: otelcol,
+	}
+
+	changed := otelcol.DeepCopy()
+	require.NoError(t, UpdateConfigStatus(params, changed))
+
+	hash, err := manifestutils.GetConfigMapSHA(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, hash, changed.Status.ConfigHash)
+	assert.Equal(t, naming.ConfigMap("test", hash), changed.Status.ConfigMap)
+	assert.Equal(t, []v1beta1.PipelineStatus{
+		{Name: "metrics", Receivers: []string{"spanmetrics"}, Exporters: []string{"debug"}},
+		{Name: "traces", Receivers: []string{"otlp", "mycustomreceiver"}, Processors: []string{"k8sattributes", "batch"}, Exporters: []string{"spanmetrics", "debug"}},
+	}, changed.Status.Pipelines)
+	assert.Equal(t, []v1beta1.PortStatus{
+		{Name: "metrics", Port: 8888, Protocol: corev1.ProtocolTCP},
+		{Name: "otlp-grpc", Port: 4317, Protocol: ""},
+	}, changed.Status.Ports)
+	assert.Equal(t, []v1beta1.ComponentStatus{
+		{Kind: "receiver", ID: "mycustomreceiver"},
+	}, changed.Status.UnknownComponents)
+}
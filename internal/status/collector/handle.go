@@ -16,6 +16,7 @@ package collector
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -24,6 +25,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	collectorupgrade "github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
 )
@@ -37,11 +39,39 @@ const (
 	reasonInfo          = "Info"
 )
 
+// ConfigError marks reconcile errors caused by a collector configuration that can't be turned into manifests.
+// These are reported through the ConfigValid condition.
+type ConfigError struct {
+	err error
+}
+
+// NewConfigError wraps err into a ConfigError.
+func NewConfigError(err error) error {
+	return &ConfigError{err: err}
+}
+
+func (e *ConfigError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.err
+}
+
 // HandleReconcileStatus handles updating the status of the CRDs managed by the operator.
 func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifests.Params, otelcol v1beta1.OpenTelemetryCollector, err error) (ctrl.Result, error) {
 	log.V(2).Info("updating collector status")
 	if err != nil {
 		params.Recorder.Event(&otelcol, eventTypeWarning, reasonError, err.Error())
+		failed := otelcol.DeepCopy()
+		conditions.SetReconcileError(&failed.Status.Conditions, failed.Generation, err)
+		var configErr *ConfigError
+		if errors.As(err, &configErr) {
+			conditions.SetConfigValid(&failed.Status.Conditions, failed.Generation, configErr.Unwrap())
+		}
+		if patchErr := params.Client.Status().Patch(ctx, failed, client.MergeFrom(&otelcol)); patchErr != nil {
+			log.V(2).Error(patchErr, "failed to apply the status conditions to the OpenTelemetry CR")
+		}
 		return ctrl.Result{}, err
 	}
 	changed := otelcol.DeepCopy()
@@ -58,7 +88,19 @@ func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifest
 		log.V(2).Error(upgradeErr, "failed to upgrade the OpenTelemetry CR")
 	}
 	changed = &upgraded
+	// the fragments applied while building the params are the ones that made it into the rendered config
+	changed.Status.ConfigFragments = params.OtelCol.Status.ConfigFragments
+	// so is the configuration version being rolled out
+	changed.Status.Rollout = params.OtelCol.Status.Rollout
+	if configErr := UpdateConfigStatus(params, changed); configErr != nil {
+		// don't fail to allow setting the rest of the status
+		log.V(2).Error(configErr, "failed to compute the effective configuration status")
+	}
+	conditions.SetConfigValid(&changed.Status.Conditions, changed.Generation, nil)
 	statusErr := UpdateCollectorStatus(ctx, params.Client, changed)
+	if statusErr == nil {
+		statusErr = UpdateTargetAllocatorCondition(ctx, params.Client, changed, params.TargetAllocator)
+	}
 	if statusErr != nil {
 		params.Recorder.Event(changed, eventTypeWarning, reasonStatusFailure, statusErr.Error())
 		return ctrl.Result{}, statusErr
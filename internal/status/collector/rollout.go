@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+var (
+	// sentItemsMetrics are the telemetry metrics counting the items sent by the exporters of the collector.
+	sentItemsMetrics = []string{
+		"otelcol_exporter_sent_spans",
+		"otelcol_exporter_sent_metric_points",
+		"otelcol_exporter_sent_log_records",
+	}
+	// failedItemsMetrics are the telemetry metrics counting the items the exporters of the collector failed to send.
+	failedItemsMetrics = []string{
+		"otelcol_exporter_send_failed_spans",
+		"otelcol_exporter_send_failed_metric_points",
+		"otelcol_exporter_send_failed_log_records",
+	}
+)
+
+// RolloutHealth is the health of the Deployment running the candidate configuration of a collector.
+type RolloutHealth struct {
+	// Ready is true when all the replicas of the Deployment run the candidate configuration and are available.
+	Ready bool
+	// Failure describes why the Deployment can't run the candidate configuration, e.g. when it exceeded its progress
+	// deadline.
+	Failure string
+	// Restarts of the collector containers of the Deployment.
+	Restarts int32
+	// SentItems and FailedItems are the items the exporters of the replicas sent and failed to send.
+	SentItems   int64
+	FailedItems int64
+}
+
+// ExportedItems returns the number of spans, metric points and log records the exporters of a collector sent and
+// failed to send, read from its telemetry metrics in the Prometheus text format.
+func ExportedItems(metrics io.Reader) (int64, int64, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(metrics)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int64(sumCounters(families, sentItemsMetrics)), int64(sumCounters(families, failedItemsMetrics)), nil
+}
+
+// Rollout returns the rollout status of the collector given the version of its current configuration. A new version
+// starts the rollout of a candidate configuration, while the Deployment of the collector keeps running the stable one.
+// Returning to the stable version ends the rollout. There's no rollout without the feature gate.
+func Rollout(otelcol v1beta1.OpenTelemetryCollector, configVersion string, now time.Time) *v1beta1.RolloutStatus {
+	if !featuregate.EnableCollectorRollout.IsEnabled() || otelcol.Spec.Rollout == nil {
+		return nil
+	}
+	previous := otelcol.Status.Rollout
+	switch {
+	case previous == nil || previous.StableConfigVersion == "":
+		// nothing to compare the configuration with, the first one is stable
+		return &v1beta1.RolloutStatus{Phase: v1beta1.RolloutPhaseSucceeded, StableConfigVersion: configVersion}
+	case configVersion == previous.StableConfigVersion:
+		if previous.Phase == v1beta1.RolloutPhaseSucceeded || previous.Phase == v1beta1.RolloutPhasePromoting {
+			return previous
+		}
+		return &v1beta1.RolloutStatus{
+			Phase:               v1beta1.RolloutPhaseSucceeded,
+			StableConfigVersion: configVersion,
+			Message:             "the configuration was reverted to the stable version",
+		}
+	case configVersion == previous.CandidateConfigVersion:
+		return previous
+	default:
+		return &v1beta1.RolloutStatus{
+			Phase:                  v1beta1.RolloutPhaseProgressing,
+			StableConfigVersion:    previous.StableConfigVersion,
+			CandidateConfigVersion: configVersion,
+			StartTime:              &metav1.Time{Time: now},
+		}
+	}
+}
+
+// AnalyzeRollout returns the rollout status of the collector given the health of the Deployment running its candidate
+// configuration. The candidate is rolled back as soon as it's unhealthy, and promoted once it stayed healthy for the
+// analysis duration. A promoted blue/green candidate serves the traffic until the Deployment of the collector runs it.
+func AnalyzeRollout(otelcol v1beta1.OpenTelemetryCollector, health RolloutHealth, now time.Time) *v1beta1.RolloutStatus {
+	previous := otelcol.Status.Rollout
+	if otelcol.Spec.Rollout == nil || previous == nil || previous.Phase != v1beta1.RolloutPhaseProgressing {
+		return previous
+	}
+	analysis := otelcol.Spec.Rollout.Analysis
+	next := previous.DeepCopy()
+	rollback := func(message string) *v1beta1.RolloutStatus {
+		next.Phase = v1beta1.RolloutPhaseRolledBack
+		next.Message = message
+		return next
+	}
+
+	if health.Failure != "" {
+		return rollback(health.Failure)
+	}
+	if health.Restarts > analysis.MaxRestarts {
+		return rollback(fmt.Sprintf("the collector containers running the candidate configuration restarted %d times", health.Restarts))
+	}
+	if exported := health.SentItems + health.FailedItems; exported > 0 &&
+		health.FailedItems*100 > int64(analysis.MaxExportFailurePercentage)*exported {
+		return rollback(fmt.Sprintf("the exporters of the candidate configuration failed to send %d of %d items", health.FailedItems, exported))
+	}
+	if !health.Ready || previous.StartTime == nil || now.Sub(previous.StartTime.Time) < analysis.Duration.Duration {
+		return previous
+	}
+
+	next.StableConfigVersion = previous.CandidateConfigVersion
+	next.Message = fmt.Sprintf("the candidate configuration stayed healthy for %s", analysis.Duration.Duration)
+	if otelcol.Spec.Rollout.Strategy == v1beta1.RolloutStrategyBlueGreen {
+		next.Phase = v1beta1.RolloutPhasePromoting
+		return next
+	}
+	next.Phase = v1beta1.RolloutPhaseSucceeded
+	next.CandidateConfigVersion = ""
+	return next
+}
@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfg "go.opentelemetry.io/collector/featuregate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func rolloutCollector(strategy v1beta1.RolloutStrategy, status *v1beta1.RolloutStatus) v1beta1.OpenTelemetryCollector {
+	return v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Rollout: &v1beta1.RolloutSpec{
+				Strategy: strategy,
+				Analysis: v1beta1.RolloutAnalysis{
+					Duration:                   metav1.Duration{Duration: 5 * time.Minute},
+					MaxRestarts:                1,
+					MaxExportFailurePercentage: 5,
+				},
+			},
+		},
+		Status: v1beta1.OpenTelemetryCollectorStatus{Rollout: status},
+	}
+}
+
+func TestExportedItems(t *testing.T) {
+	metrics := `# TYPE otelcol_exporter_sent_spans_total counter
+otelcol_exporter_sent_spans_total{exporter="otlp"} 90
+# TYPE otelcol_exporter_sent_log_records counter
+otelcol_exporter_sent_log_records{exporter="otlp"} 10
+# TYPE otelcol_exporter_send_failed_spans_total counter
+otelcol_exporter_send_failed_spans_total{exporter="otlp"} 4
+# TYPE otelcol_receiver_accepted_spans_total counter
+otelcol_receiver_accepted_spans_total{receiver="otlp"} 94
+`
+	sent, failed, err := ExportedItems(strings.NewReader(metrics))
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), sent)
+	assert.Equal(t, int64(4), failed)
+}
+
+func TestRollout(t *testing.T) {
+	require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableCollectorRollout.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableCollectorRollout.ID(), false))
+	})
+	now := time.Date(2024, 3, 4, 10, 30, 0, 0, time.UTC)
+	earlier := &metav1.Time{Time: now.Add(-time.Minute)}
+	progressing := &v1beta1.RolloutStatus{
+		Phase:                  v1beta1.RolloutPhaseProgressing,
+		StableConfigVersion:    "v1",
+		CandidateConfigVersion: "v2",
+		StartTime:              earlier,
+	}
+
+	for _, tt := range []struct {
+		name     string
+		status   *v1beta1.RolloutStatus
+		version  string
+		expected *v1beta1.RolloutStatus
+	}{
+		{
+			name:     "first configuration",
+			version:  "v1",
+			expected: &v1beta1.RolloutStatus{Phase: v1beta1.RolloutPhaseSucceeded, StableConfigVersion: "v1"},
+		},
+		{
+			name:    "new configuration",
+			status:  &v1beta1.RolloutStatus{Phase: v1beta1.RolloutPhaseSucceeded, StableConfigVersion: "v1"},
+			version: "v2",
+			expected: &v1beta1.RolloutStatus{
+				Phase:                  v1beta1.RolloutPhaseProgressing,
+				StableConfigVersion:    "v1",
+				CandidateConfigVersion: "v2",
+				StartTime:              &metav1.Time{Time: now},
+			},
+		},
+		{
+			name:     "configuration being rolled out",
+			status:   progressing,
+			version:  "v2",
+			expected: progressing,
+		},
+		{
+			name:    "configuration changed during the rollout",
+			status:  progressing,
+			version: "v3",
+			expected: &v1beta1.RolloutStatus{
+				Phase:                  v1beta1.RolloutPhaseProgressing,
+				StableConfigVersion:    "v1",
+				CandidateConfigVersion: "v3",
+				StartTime:              &metav1.Time{Time: now},
+			},
+		},
+		{
+			name:    "configuration reverted during the rollout",
+			status:  progressing,
+			version: "v1",
+			expected: &v1beta1.RolloutStatus{
+				Phase:               v1beta1.RolloutPhaseSucceeded,
+				StableConfigVersion: "v1",
+				Message:             "the configuration was reverted to the stable version",
+			},
+		},
+		{
+			name: "rolled back configuration",
+			status: &v1beta1.RolloutStatus{
+				Phase:                  v1beta1.RolloutPhaseRolledBack,
+				StableConfigVersion:    "v1",
+				CandidateConfigVersion: "v2",
+				Message:                "failed",
+			},
+			version: "v2",
+			expected: &v1beta1.RolloutStatus{
+				Phase:                  v1beta1.RolloutPhaseRolledBack,
+				StableConfigVersion:    "v1",
+				CandidateConfigVersion: "v2",
+				Message:                "failed",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Rollout(rolloutCollector(v1beta1.RolloutStrategyCanary, tt.status), tt.version, now))
+		})
+	}
+
+	t.Run("without rollout", func(t *testing.T) {
+		otelcol := rolloutCollector(v1beta1.RolloutStrategyCanary, progressing)
+		otelcol.Spec.Rollout = nil
+		assert.Nil(t, Rollout(otelcol, "v2", now))
+	})
+
+	t.Run("without the feature gate", func(t *testing.T) {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableCollectorRollout.ID(), false))
+		t.Cleanup(func() {
+			require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableCollectorRollout.ID(), true))
+		})
+		assert.Nil(t, Rollout(rolloutCollector(v1beta1.RolloutStrategyCanary, progressing), "v2", now))
+	})
+}
+
+func TestAnalyzeRollout(t *testing.T) {
+	now := time.Date(2024, 3, 4, 10, 30, 0, 0, time.UTC)
+	progressing := func(minutes int) *v1beta1.RolloutStatus {
+		return &v1beta1.RolloutStatus{
+			Phase:                  v1beta1.RolloutPhaseProgressing,
+			StableConfigVersion:    "v1",
+			CandidateConfigVersion: "v2",
+			StartTime:              &metav1.Time{Time: now.Add(-time.Duration(minutes) * time.Minute)},
+		}
+	}
+	rolledBack := func(message string) *v1beta1.RolloutStatus {
+		status := progressing(1)
+		status.Phase = v1beta1.RolloutPhaseRolledBack
+		status.Message = message
+		return status
+	}
+
+	for _, tt := range []struct {
+		name     string
+		strategy v1beta1.RolloutStrategy
+		status   *v1beta1.RolloutStatus
+		health   RolloutHealth
+		expected *v1beta1.RolloutStatus
+	}{
+		{
+			name:     "healthy during the analysis",
+			strategy: v1beta1.RolloutStrategyCanary,
+			status:   progressing(1),
+			health:   RolloutHealth{Ready: true, SentItems: 100, FailedItems: 5},
+			expected: progressing(1),
+		},
+		{
+			name:     "not ready after the analysis",
+			strategy: v1beta1.RolloutStrategyCanary,
+			status:   progressing(10),
+			health:   RolloutHealth{},
+			expected: progressing(10),
+		},
+		{
+			name:     "canary promoted",
+			strategy: v1beta1.RolloutStrategyCanary,
+			status:   progressing(10),
+			health:   RolloutHealth{Ready: true, Restarts: 1},
+			expected: &v1beta1.RolloutStatus{
+				Phase:               v1beta1.RolloutPhaseSucceeded,
+				StableConfigVersion: "v2",
+				StartTime:           progressing(10).StartTime,
+				Message:             "the candidate configuration stayed healthy for 5m0s",
+			},
+		},
+		{
+			name:     "blue/green promoted",
+			strategy: v1beta1.RolloutStrategyBlueGreen,
+			status:   progressing(10),
+			health:   RolloutHealth{Ready: true},
+			expected: &v1beta1.RolloutStatus{
+				Phase:                  v1beta1.RolloutPhasePromoting,
+				StableConfigVersion:    "v2",
+				CandidateConfigVersion: "v2",
+				StartTime:              progressing(10).StartTime,
+				Message:                "the candidate configuration stayed healthy for 5m0s",
+			},
+		},
+		{
+			name:     "deployment failed",
+			strategy: v1beta1.RolloutStrategyCanary,
+			status:   progressing(1),
+			health:   RolloutHealth{Failure: "the canary deployment exceeded its progress deadline"},
+			expected: rolledBack("the canary deployment exceeded its progress deadline"),
+		},
+		{
+			name:     "containers restarted",
+			strategy: v1beta1.RolloutStrategyCanary,
+			status:   progressing(1),
+			health:   RolloutHealth{Ready: true, Restarts: 2},
+			expected: rolledBack("the collector containers running the candidate configuration restarted 2 times"),
+		},
+		{
+			name:     "export failures",
+			strategy: v1beta1.RolloutStrategyCanary,
+			status:   progressing(1),
+			health:   RolloutHealth{Ready: true, SentItems: 90, FailedItems: 10},
+			expected: rolledBack("the exporters of the candidate configuration failed to send 10 of 100 items"),
+		},
+		{
+			name:     "rollout already rolled back",
+			strategy: v1beta1.RolloutStrategyCanary,
+			status:   rolledBack("failed"),
+			health:   RolloutHealth{Ready: true},
+			expected: rolledBack("failed"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, AnalyzeRollout(rolloutCollector(tt.strategy, tt.status), tt.health, now))
+		})
+	}
+}
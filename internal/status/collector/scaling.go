@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hashicorp/cronexpr"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// receivedItemsMetrics are the telemetry metrics counting the items accepted by the receivers of the collector.
+var receivedItemsMetrics = []string{
+	"otelcol_receiver_accepted_spans",
+	"otelcol_receiver_accepted_metric_points",
+	"otelcol_receiver_accepted_log_records",
+}
+
+// ReceivedItems returns the number of spans, metric points and log records accepted by the receivers of a collector,
+// read from its telemetry metrics in the Prometheus text format.
+func ReceivedItems(metrics io.Reader) (int64, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(metrics)
+	if err != nil {
+		return 0, err
+	}
+	return int64(sumCounters(families, receivedItemsMetrics)), nil
+}
+
+// sumCounters sums the values of the given counters, with and without the suffix added to counters by the
+// Prometheus exporter of the collector telemetry.
+func sumCounters(families map[string]*dto.MetricFamily, names []string) float64 {
+	var total float64
+	for _, name := range names {
+		for _, suffixed := range []string{name, name + "_total"} {
+			family, ok := families[suffixed]
+			if !ok {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				switch family.GetType() {
+				case dto.MetricType_COUNTER:
+					total += metric.GetCounter().GetValue()
+				case dto.MetricType_UNTYPED:
+					total += metric.GetUntyped().GetValue()
+				}
+			}
+		}
+	}
+	return total
+}
+
+// Scaling returns the scaling status of the collector at the given time. receivedItems is the number of items
+// received by all the replicas of the collector, or nil when it can't be observed because the collector has no
+// running replica. The collector is active when the number of received items changes, when a schedule window starts
+// and when it's updated; it's idle when it hasn't been active for the idle period.
+func Scaling(otelcol v1beta1.OpenTelemetryCollector, now time.Time, receivedItems *int64) (*v1beta1.ScalingStatus, error) {
+	spec := otelcol.Spec.Scaling
+	if spec == nil {
+		return nil, nil
+	}
+	location := time.UTC
+	if spec.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(spec.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %s: %w", spec.TimeZone, err)
+		}
+	}
+
+	previous := otelcol.Status.Scaling
+	if previous == nil {
+		previous = &v1beta1.ScalingStatus{}
+	}
+	status := &v1beta1.ScalingStatus{
+		ReceivedItems:      previous.ReceivedItems,
+		LastActivityTime:   previous.LastActivityTime,
+		ObservedGeneration: otelcol.Generation,
+	}
+	if status.LastActivityTime == nil || previous.ObservedGeneration != otelcol.Generation {
+		status.LastActivityTime = &metav1.Time{Time: now}
+	}
+	if receivedItems != nil {
+		if status.ReceivedItems == nil || *status.ReceivedItems != *receivedItems {
+			status.LastActivityTime = &metav1.Time{Time: now}
+		}
+		status.ReceivedItems = receivedItems
+	}
+
+	for _, schedule := range spec.Schedules {
+		start, err := windowStart(schedule, now.In(location))
+		if err != nil {
+			return nil, err
+		}
+		if start.IsZero() {
+			continue
+		}
+		if start.After(status.LastActivityTime.Time) {
+			status.LastActivityTime = &metav1.Time{Time: start}
+		}
+		replicas := schedule.Replicas
+		status.Replicas = &replicas
+		status.Reason = v1beta1.ScalingReasonSchedule
+		status.Schedule = schedule.Name
+		break
+	}
+
+	if spec.IdleScaleDown != nil && now.Sub(status.LastActivityTime.Time) >= spec.IdleScaleDown.After.Duration {
+		replicas := int32(0)
+		status.Replicas = &replicas
+		status.Reason = v1beta1.ScalingReasonIdle
+	}
+	return status, nil
+}
+
+// windowStart returns the start of the window of the schedule active at the given time, or the zero time when the
+// window isn't active.
+func windowStart(schedule v1beta1.ScalingSchedule, now time.Time) (time.Time, error) {
+	expr, err := cronexpr.Parse(schedule.Start)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid start of the %s schedule: %w", schedule.Name, err)
+	}
+	// the last start before now, if it's within the duration of the window
+	start := expr.Next(now.Add(-schedule.Duration.Duration))
+	if start.IsZero() || start.After(now) {
+		return time.Time{}, nil
+	}
+	return start, nil
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestReceivedItems(t *testing.T) {
+	metrics := `# TYPE otelcol_receiver_accepted_spans_total counter
+otelcol_receiver_accepted_spans_total{receiver="otlp",transport="grpc"} 10
+otelcol_receiver_accepted_spans_total{receiver="otlp",transport="http"} 5
+# TYPE otelcol_receiver_accepted_log_records counter
+otelcol_receiver_accepted_log_records{receiver="filelog"} 7
+# TYPE otelcol_exporter_sent_spans_total counter
+otelcol_exporter_sent_spans_total{exporter="debug"} 15
+`
+	items, err := ReceivedItems(strings.NewReader(metrics))
+	require.NoError(t, err)
+	assert.Equal(t, int64(22), items)
+
+	_, err = ReceivedItems(strings.NewReader("not metrics"))
+	assert.Error(t, err)
+}
+
+func TestScaling(t *testing.T) {
+	now := time.Date(2024, 3, 4, 10, 30, 0, 0, time.UTC)
+	minutesAgo := func(minutes int) *metav1.Time {
+		return &metav1.Time{Time: now.Add(-time.Duration(minutes) * time.Minute)}
+	}
+	items := func(count int64) *int64 { return &count }
+	replicas := func(count int32) *int32 { return &count }
+
+	collector := func(spec v1beta1.ScalingSpec, status *v1beta1.ScalingStatus) v1beta1.OpenTelemetryCollector {
+		return v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 2},
+			Spec:       v1beta1.OpenTelemetryCollectorSpec{Scaling: &spec},
+			Status:     v1beta1.OpenTelemetryCollectorStatus{Scaling: status},
+		}
+	}
+	idle := &v1beta1.IdleScaleDown{After: metav1.Duration{Duration: 15 * time.Minute}}
+	business := v1beta1.ScalingSchedule{
+		Name:     "business-hours",
+		Start:    "0 8 * * 1-5",
+		Duration: metav1.Duration{Duration: 10 * time.Hour},
+		Replicas: 5,
+	}
+
+	for _, tt := range []struct {
+		name          string
+		otelcol       v1beta1.OpenTelemetryCollector
+		receivedItems *int64
+		expected      *v1beta1.ScalingStatus
+	}{
+		{
+			name:          "first observation",
+			otelcol:       collector(v1beta1.ScalingSpec{IdleScaleDown: idle}, nil),
+			receivedItems: items(0),
+			expected:      &v1beta1.ScalingStatus{ReceivedItems: items(0), LastActivityTime: minutesAgo(0), ObservedGeneration: 2},
+		},
+		{
+			name: "received items changed",
+			otelcol: collector(v1beta1.ScalingSpec{IdleScaleDown: idle},
+				&v1beta1.ScalingStatus{ReceivedItems: items(10), LastActivityTime: minutesAgo(20), ObservedGeneration: 2}),
+			receivedItems: items(12),
+			expected:      &v1beta1.ScalingStatus{ReceivedItems: items(12), LastActivityTime: minutesAgo(0), ObservedGeneration: 2},
+		},
+		{
+			name: "idle",
+			otelcol: collector(v1beta1.ScalingSpec{IdleScaleDown: idle},
+				&v1beta1.ScalingStatus{ReceivedItems: items(10), LastActivityTime: minutesAgo(20), ObservedGeneration: 2}),
+			receivedItems: items(10),
+			expected: &v1beta1.ScalingStatus{Replicas: replicas(0), Reason: v1beta1.ScalingReasonIdle,
+				ReceivedItems: items(10), LastActivityTime: minutesAgo(20), ObservedGeneration: 2},
+		},
+		{
+			name: "stays idle without running replicas",
+			otelcol: collector(v1beta1.ScalingSpec{IdleScaleDown: idle},
+				&v1beta1.ScalingStatus{Replicas: replicas(0), Reason: v1beta1.ScalingReasonIdle,
+					ReceivedItems: items(10), LastActivityTime: minutesAgo(20), ObservedGeneration: 2}),
+			expected: &v1beta1.ScalingStatus{Replicas: replicas(0), Reason: v1beta1.ScalingReasonIdle,
+				ReceivedItems: items(10), LastActivityTime: minutesAgo(20), ObservedGeneration: 2},
+		},
+		{
+			name: "updated collector is active",
+			otelcol: collector(v1beta1.ScalingSpec{IdleScaleDown: idle},
+				&v1beta1.ScalingStatus{Replicas: replicas(0), Reason: v1beta1.ScalingReasonIdle,
+					ReceivedItems: items(10), LastActivityTime: minutesAgo(20), ObservedGeneration: 1}),
+			expected: &v1beta1.ScalingStatus{ReceivedItems: items(10), LastActivityTime: minutesAgo(0), ObservedGeneration: 2},
+		},
+		{
+			name:          "active schedule",
+			otelcol:       collector(v1beta1.ScalingSpec{Schedules: []v1beta1.ScalingSchedule{business}}, nil),
+			receivedItems: items(0),
+			expected: &v1beta1.ScalingStatus{Replicas: replicas(5), Reason: v1beta1.ScalingReasonSchedule, Schedule: "business-hours",
+				ReceivedItems: items(0), LastActivityTime: minutesAgo(0), ObservedGeneration: 2},
+		},
+		{
+			name:          "schedule in another time zone",
+			otelcol:       collector(v1beta1.ScalingSpec{Schedules: []v1beta1.ScalingSchedule{business}, TimeZone: "Asia/Tokyo"}, nil),
+			receivedItems: items(0),
+			expected:      &v1beta1.ScalingStatus{ReceivedItems: items(0), LastActivityTime: minutesAgo(0), ObservedGeneration: 2},
+		},
+		{
+			name: "start of a schedule window is an activity",
+			otelcol: collector(v1beta1.ScalingSpec{
+				Schedules: []v1beta1.ScalingSchedule{{
+					Name:     "batch",
+					Start:    "25 10 * * *",
+					Duration: metav1.Duration{Duration: time.Hour},
+					Replicas: 2,
+				}},
+				IdleScaleDown: idle,
+			}, &v1beta1.ScalingStatus{ReceivedItems: items(10), LastActivityTime: minutesAgo(60), ObservedGeneration: 2}),
+			receivedItems: items(10),
+			expected: &v1beta1.ScalingStatus{Replicas: replicas(2), Reason: v1beta1.ScalingReasonSchedule, Schedule: "batch",
+				ReceivedItems: items(10), LastActivityTime: minutesAgo(5), ObservedGeneration: 2},
+		},
+		{
+			name: "idle during a schedule window",
+			otelcol: collector(v1beta1.ScalingSpec{Schedules: []v1beta1.ScalingSchedule{business}, IdleScaleDown: idle},
+				&v1beta1.ScalingStatus{ReceivedItems: items(10), LastActivityTime: minutesAgo(30), ObservedGeneration: 2}),
+			receivedItems: items(10),
+			expected: &v1beta1.ScalingStatus{Replicas: replicas(0), Reason: v1beta1.ScalingReasonIdle, Schedule: "business-hours",
+				ReceivedItems: items(10), LastActivityTime: minutesAgo(30), ObservedGeneration: 2},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := Scaling(tt.otelcol, now, tt.receivedItems)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, status)
+		})
+	}
+
+	t.Run("without scaling", func(t *testing.T) {
+		status, err := Scaling(v1beta1.OpenTelemetryCollector{}, now, nil)
+		require.NoError(t, err)
+		assert.Nil(t, status)
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		_, err := Scaling(collector(v1beta1.ScalingSpec{Schedules: []v1beta1.ScalingSchedule{{Name: "bad", Start: "not a cron"}}}, nil), now, nil)
+		assert.ErrorContains(t, err, "bad")
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"math"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/sidecar"
+)
+
+const (
+	// recommendationMargin is added to the peak usage observed in the pods of a workload.
+	recommendationMargin = 1.15
+	// recommendationDecay is how much a recommendation can decrease in a single update, so that a short drop in
+	// usage doesn't shrink the sidecars right away.
+	recommendationDecay = 0.9
+	mebibyte            = 1024 * 1024
+)
+
+var recommendedResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+type workloadKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// SidecarResourceRecommendations returns the resources recommended for the sidecar of each workload, given the pods
+// the sidecar is injected into and the usage of their sidecar container. The recommendations grow with the peak
+// usage right away, but only decrease progressively. Pods without owner and pods without usage are ignored.
+func SidecarResourceRecommendations(otelcol v1beta1.OpenTelemetryCollector, pods []corev1.Pod, usage map[types.NamespacedName]corev1.ResourceList) []v1beta1.SidecarResourcesRecommendation {
+	if otelcol.Spec.SidecarResources == nil {
+		return nil
+	}
+
+	peaks := map[workloadKey]corev1.ResourceList{}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		podUsage, ok := usage[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]
+		if !ok {
+			continue
+		}
+		kind, name := sidecar.OwningWorkload(pod)
+		if kind == "Pod" {
+			continue
+		}
+		key := workloadKey{kind: kind, namespace: pod.Namespace, name: name}
+		peak, ok := peaks[key]
+		if !ok {
+			peak = corev1.ResourceList{}
+			peaks[key] = peak
+		}
+		for _, name := range recommendedResources {
+			if quantity, ok := podUsage[name]; ok {
+				if current, ok := peak[name]; !ok || quantity.Cmp(current) > 0 {
+					peak[name] = quantity.DeepCopy()
+				}
+			}
+		}
+	}
+
+	previous := map[workloadKey]corev1.ResourceList{}
+	for _, recommendation := range otelcol.Status.SidecarResources {
+		previous[workloadKey{kind: recommendation.Kind, namespace: recommendation.Namespace, name: recommendation.Name}] = recommendation.Requests
+	}
+
+	var recommendations []v1beta1.SidecarResourcesRecommendation
+	for key, peak := range peaks {
+		requests := corev1.ResourceList{}
+		for name, quantity := range peak {
+			recommended := scale(name, quantity, recommendationMargin)
+			if last, ok := previous[key][name]; ok {
+				if decayed := scale(name, last, recommendationDecay); decayed.Cmp(recommended) > 0 {
+					recommended = decayed
+				}
+			}
+			if minAllowed, ok := otelcol.Spec.SidecarResources.MinAllowed[name]; ok && recommended.Cmp(minAllowed) < 0 {
+				recommended = minAllowed.DeepCopy()
+			}
+			if maxAllowed, ok := otelcol.Spec.SidecarResources.MaxAllowed[name]; ok && recommended.Cmp(maxAllowed) > 0 {
+				recommended = maxAllowed.DeepCopy()
+			}
+			requests[name] = recommended
+		}
+		recommendations = append(recommendations, v1beta1.SidecarResourcesRecommendation{
+			Kind:      key.kind,
+			Namespace: key.namespace,
+			Name:      key.name,
+			Requests:  requests,
+			Limits:    recommendedLimits(otelcol.Spec.Resources, requests),
+		})
+	}
+	sort.Slice(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return recommendations
+}
+
+// recommendedLimits keeps the ratio between the limits and the requests of the collector resources. A limit set
+// without request is kept, unless it's lower than the recommended request.
+func recommendedLimits(resources corev1.ResourceRequirements, requests corev1.ResourceList) corev1.ResourceList {
+	limits := corev1.ResourceList{}
+	for name, recommended := range requests {
+		limit, ok := resources.Limits[name]
+		if !ok {
+			continue
+		}
+		request, ok := resources.Requests[name]
+		switch {
+		case ok && !request.IsZero():
+			limits[name] = scale(name, recommended, float64(limit.MilliValue())/float64(request.MilliValue()))
+		case limit.Cmp(recommended) < 0:
+			limits[name] = recommended.DeepCopy()
+		default:
+			limits[name] = limit.DeepCopy()
+		}
+	}
+	if len(limits) == 0 {
+		return nil
+	}
+	return limits
+}
+
+// scale multiplies the quantity by the given factor, rounded up to the millicore for cpu and to the mebibyte for
+// memory.
+func scale(name corev1.ResourceName, quantity resource.Quantity, factor float64) resource.Quantity {
+	if name == corev1.ResourceCPU {
+		return *resource.NewMilliQuantity(int64(math.Ceil(float64(quantity.MilliValue())*factor)), resource.DecimalSI)
+	}
+	mebibytes := int64(math.Ceil(float64(quantity.Value()) * factor / mebibyte))
+	return *resource.NewQuantity(mebibytes*mebibyte, resource.BinarySI)
+}
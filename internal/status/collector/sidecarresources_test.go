@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func sidecarWorkloadPod(namespace, name, replicaSet string) corev1.Pod {
+	controller := true
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       namespace,
+			Name:            name,
+			Labels:          map[string]string{"pod-template-hash": "5d8f7c"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: replicaSet + "-5d8f7c", Controller: &controller}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func usage(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func TestSidecarResourceRecommendations(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeSidecar,
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Resources: corev1.ResourceRequirements{
+					Requests: usage("100m", "64Mi"),
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+				},
+			},
+			SidecarResources: &v1beta1.SidecarResourcesSpec{
+				MinAllowed: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+				MaxAllowed: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			},
+		},
+		Status: v1beta1.OpenTelemetryCollectorStatus{
+			SidecarResources: []v1beta1.SidecarResourcesRecommendation{{
+				Kind:      "Deployment",
+				Namespace: "shop",
+				Name:      "cart",
+				Requests:  usage("500m", "100Mi"),
+			}},
+		},
+	}
+
+	standalone := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "standalone"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	completed := sidecarWorkloadPod("default", "api-3", "api")
+	completed.Status.Phase = corev1.PodSucceeded
+	pods := []corev1.Pod{
+		sidecarWorkloadPod("default", "api-1", "api"),
+		sidecarWorkloadPod("default", "api-2", "api"),
+		completed,
+		sidecarWorkloadPod("shop", "cart-1", "cart"),
+		sidecarWorkloadPod("shop", "checkout-1", "checkout"),
+		standalone,
+	}
+	podUsage := map[types.NamespacedName]corev1.ResourceList{
+		{Namespace: "default", Name: "api-1"}:      usage("2m", "40Mi"),
+		{Namespace: "default", Name: "api-2"}:      usage("200m", "30Mi"),
+		{Namespace: "default", Name: "api-3"}:      usage("4", "4Gi"),
+		{Namespace: "shop", Name: "cart-1"}:        usage("100m", "300Mi"),
+		{Namespace: "default", Name: "standalone"}: usage("1", "1Gi"),
+	}
+
+	recommendations := SidecarResourceRecommendations(otelcol, pods, podUsage)
+
+	assert.Equal(t, []v1beta1.SidecarResourcesRecommendation{
+		{
+			Kind:      "Deployment",
+			Namespace: "default",
+			Name:      "api",
+			// the peak usage of the running pods, with a margin
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    *resource.NewMilliQuantity(230, resource.DecimalSI),
+				corev1.ResourceMemory: *resource.NewQuantity(46*1024*1024, resource.BinarySI),
+			},
+			// the ratio between the limit and the request of the collector resources is kept
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: *resource.NewQuantity(92*1024*1024, resource.BinarySI),
+			},
+		},
+		{
+			Kind:      "Deployment",
+			Namespace: "shop",
+			Name:      "cart",
+			Requests: corev1.ResourceList{
+				// the previous recommendation only decreases progressively
+				corev1.ResourceCPU: *resource.NewMilliQuantity(450, resource.DecimalSI),
+				// bounded by the maximum
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: *resource.NewQuantity(512*1024*1024, resource.BinarySI),
+			},
+		},
+	}, recommendations)
+
+	otelcol.Spec.SidecarResources = nil
+	assert.Nil(t, SidecarResourceRecommendations(otelcol, pods, podUsage))
+}
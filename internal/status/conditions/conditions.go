@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conditions derives the status conditions of the resources managed by the operator.
+package conditions
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// Rollout summarizes the rollout state of the workload backing a resource.
+type Rollout struct {
+	// Desired is the number of pods the workload should run.
+	Desired int32
+	// Updated is the number of pods running the latest revision of the workload.
+	Updated int32
+	// Ready is the number of ready pods.
+	Ready int32
+	// Observed is true once the workload controller has observed the latest workload spec.
+	Observed bool
+	// Stalled holds the reason given by the workload controller when it stopped progressing the rollout.
+	Stalled string
+}
+
+// FromDeployment returns the rollout state of the given deployment.
+func FromDeployment(d *appsv1.Deployment) Rollout {
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	r := Rollout{
+		Desired:  desired,
+		Updated:  d.Status.UpdatedReplicas,
+		Ready:    d.Status.ReadyReplicas,
+		Observed: d.Status.ObservedGeneration >= d.Generation,
+	}
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == v1beta1.ConditionReasonProgressDeadlineExceeded {
+			r.Stalled = c.Message
+		}
+	}
+	return r
+}
+
+// FromStatefulSet returns the rollout state of the given statefulset.
+func FromStatefulSet(s *appsv1.StatefulSet) Rollout {
+	desired := int32(1)
+	if s.Spec.Replicas != nil {
+		desired = *s.Spec.Replicas
+	}
+	return Rollout{
+		Desired:  desired,
+		Updated:  s.Status.UpdatedReplicas,
+		Ready:    s.Status.ReadyReplicas,
+		Observed: s.Status.ObservedGeneration >= s.Generation,
+	}
+}
+
+// FromDaemonSet returns the rollout state of the given daemonset.
+func FromDaemonSet(d *appsv1.DaemonSet) Rollout {
+	return Rollout{
+		Desired:  d.Status.DesiredNumberScheduled,
+		Updated:  d.Status.UpdatedNumberScheduled,
+		Ready:    d.Status.NumberReady,
+		Observed: d.Status.ObservedGeneration >= d.Generation,
+	}
+}
+
+// Complete returns whether all the pods of the workload are updated and ready.
+func (r Rollout) Complete() bool {
+	return r.Observed && r.Updated >= r.Desired && r.Ready >= r.Desired
+}
+
+func (r Rollout) message() string {
+	if !r.Observed {
+		return "waiting for the workload controller to observe the latest spec"
+	}
+	return fmt.Sprintf("%d/%d pods updated, %d/%d pods ready", r.Updated, r.Desired, r.Ready, r.Desired)
+}
+
+// SetRollout sets the Ready, Progressing and Degraded conditions after a successful reconciliation, based on the
+// rollout state of the workload. A nil rollout means that the resource has no workload of its own.
+func SetRollout(conditions *[]metav1.Condition, generation int64, rollout *Rollout) {
+	switch {
+	case rollout == nil:
+		set(conditions, generation, v1beta1.ConditionTypeReady, metav1.ConditionTrue, v1beta1.ConditionReasonSidecar, "the collector is injected as a sidecar into the selected pods")
+		set(conditions, generation, v1beta1.ConditionTypeProgressing, metav1.ConditionFalse, v1beta1.ConditionReasonSidecar, "the collector has no workload of its own")
+		set(conditions, generation, v1beta1.ConditionTypeDegraded, metav1.ConditionFalse, v1beta1.ConditionReasonReconcileSucceeded, "")
+	case rollout.Complete():
+		set(conditions, generation, v1beta1.ConditionTypeReady, metav1.ConditionTrue, v1beta1.ConditionReasonRolloutComplete, rollout.message())
+		set(conditions, generation, v1beta1.ConditionTypeProgressing, metav1.ConditionFalse, v1beta1.ConditionReasonRolloutComplete, rollout.message())
+		set(conditions, generation, v1beta1.ConditionTypeDegraded, metav1.ConditionFalse, v1beta1.ConditionReasonReconcileSucceeded, "")
+	case rollout.Stalled != "":
+		set(conditions, generation, v1beta1.ConditionTypeReady, metav1.ConditionFalse, v1beta1.ConditionReasonProgressDeadlineExceeded, rollout.message())
+		set(conditions, generation, v1beta1.ConditionTypeProgressing, metav1.ConditionFalse, v1beta1.ConditionReasonProgressDeadlineExceeded, rollout.Stalled)
+		set(conditions, generation, v1beta1.ConditionTypeDegraded, metav1.ConditionTrue, v1beta1.ConditionReasonProgressDeadlineExceeded, rollout.Stalled)
+	default:
+		set(conditions, generation, v1beta1.ConditionTypeReady, metav1.ConditionFalse, v1beta1.ConditionReasonRolloutInProgress, rollout.message())
+		set(conditions, generation, v1beta1.ConditionTypeProgressing, metav1.ConditionTrue, v1beta1.ConditionReasonRolloutInProgress, rollout.message())
+		set(conditions, generation, v1beta1.ConditionTypeDegraded, metav1.ConditionFalse, v1beta1.ConditionReasonReconcileSucceeded, "")
+	}
+}
+
+// SetReconcileError marks the resource as degraded after a failed reconciliation. The Progressing condition is left
+// untouched, the workload may still be rolling out the last revision that was applied successfully.
+func SetReconcileError(conditions *[]metav1.Condition, generation int64, err error) {
+	set(conditions, generation, v1beta1.ConditionTypeReady, metav1.ConditionFalse, v1beta1.ConditionReasonReconcileError, err.Error())
+	set(conditions, generation, v1beta1.ConditionTypeDegraded, metav1.ConditionTrue, v1beta1.ConditionReasonReconcileError, err.Error())
+}
+
+// SetConfigValid sets the ConfigValid condition, err being the reason why the configuration was rejected, if any.
+func SetConfigValid(conditions *[]metav1.Condition, generation int64, err error) {
+	if err != nil {
+		set(conditions, generation, v1beta1.ConditionTypeConfigValid, metav1.ConditionFalse, v1beta1.ConditionReasonInvalidConfig, err.Error())
+		return
+	}
+	set(conditions, generation, v1beta1.ConditionTypeConfigValid, metav1.ConditionTrue, v1beta1.ConditionReasonConfigAccepted, "")
+}
+
+// SetTargetAllocatorReady sets the TargetAllocatorReady condition based on the rollout of the target allocator
+// workload. A nil rollout removes the condition, for collectors without a target allocator.
+func SetTargetAllocatorReady(conditions *[]metav1.Condition, generation int64, rollout *Rollout) {
+	switch {
+	case rollout == nil:
+		meta.RemoveStatusCondition(conditions, v1beta1.ConditionTypeTargetAllocatorReady)
+	case rollout.Complete():
+		set(conditions, generation, v1beta1.ConditionTypeTargetAllocatorReady, metav1.ConditionTrue, v1beta1.ConditionReasonRolloutComplete, rollout.message())
+	case rollout.Stalled != "":
+		set(conditions, generation, v1beta1.ConditionTypeTargetAllocatorReady, metav1.ConditionFalse, v1beta1.ConditionReasonProgressDeadlineExceeded, rollout.Stalled)
+	default:
+		set(conditions, generation, v1beta1.ConditionTypeTargetAllocatorReady, metav1.ConditionFalse, v1beta1.ConditionReasonRolloutInProgress, rollout.message())
+	}
+}
+
+func set(conditions *[]metav1.Condition, generation int64, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
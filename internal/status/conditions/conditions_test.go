@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conditions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func statusOf(conditions []metav1.Condition) map[string]string {
+	statuses := map[string]string{}
+	for _, c := range conditions {
+		statuses[c.Type] = string(c.Status) + "/" + c.Reason
+	}
+	return statuses
+}
+
+func TestSetRollout(t *testing.T) {
+	replicas := int32(2)
+	for _, tt := range []struct {
+		name     string
+		rollout  *Rollout
+		expected map[string]string
+	}{
+		{
+			name:    "sidecar",
+			rollout: nil,
+			expected: map[string]string{
+				v1beta1.ConditionTypeReady:       "True/Sidecar",
+				v1beta1.ConditionTypeProgressing: "False/Sidecar",
+				v1beta1.ConditionTypeDegraded:    "False/ReconcileSucceeded",
+			},
+		},
+		{
+			name: "rollout complete",
+			rollout: func() *Rollout {
+				r := FromDeployment(&appsv1.Deployment{
+					Spec:   appsv1.DeploymentSpec{Replicas: &replicas},
+					Status: appsv1.DeploymentStatus{UpdatedReplicas: 2, ReadyReplicas: 2},
+				})
+				return &r
+			}(),
+			expected: map[string]string{
+				v1beta1.ConditionTypeReady:       "True/RolloutComplete",
+				v1beta1.ConditionTypeProgressing: "False/RolloutComplete",
+				v1beta1.ConditionTypeDegraded:    "False/ReconcileSucceeded",
+			},
+		},
+		{
+			name: "rollout in progress",
+			rollout: func() *Rollout {
+				r := FromStatefulSet(&appsv1.StatefulSet{
+					Spec:   appsv1.StatefulSetSpec{Replicas: &replicas},
+					Status: appsv1.StatefulSetStatus{UpdatedReplicas: 1, ReadyReplicas: 2},
+				})
+				return &r
+			}(),
+			expected: map[string]string{
+				v1beta1.ConditionTypeReady:       "False/RolloutInProgress",
+				v1beta1.ConditionTypeProgressing: "True/RolloutInProgress",
+				v1beta1.ConditionTypeDegraded:    "False/ReconcileSucceeded",
+			},
+		},
+		{
+			name: "spec not observed yet",
+			rollout: func() *Rollout {
+				r := FromDaemonSet(&appsv1.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{Generation: 3},
+					Status:     appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 1, UpdatedNumberScheduled: 1, NumberReady: 1},
+				})
+				return &r
+			}(),
+			expected: map[string]string{
+				v1beta1.ConditionTypeReady:       "False/RolloutInProgress",
+				v1beta1.ConditionTypeProgressing: "True/RolloutInProgress",
+				v1beta1.ConditionTypeDegraded:    "False/ReconcileSucceeded",
+			},
+		},
+		{
+			name: "progress deadline exceeded",
+			rollout: func() *Rollout {
+				r := FromDeployment(&appsv1.Deployment{
+					Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+					Status: appsv1.DeploymentStatus{
+						UpdatedReplicas: 1,
+						Conditions: []appsv1.DeploymentCondition{{
+							Type:    appsv1.DeploymentProgressing,
+							Reason:  "ProgressDeadlineExceeded",
+							Message: `ReplicaSet "test-collector-abc" has timed out progressing.`,
+						}},
+					},
+				})
+				return &r
+			}(),
+			expected: map[string]string{
+				v1beta1.ConditionTypeReady:       "False/ProgressDeadlineExceeded",
+				v1beta1.ConditionTypeProgressing: "False/ProgressDeadlineExceeded",
+				v1beta1.ConditionTypeDegraded:    "True/ProgressDeadlineExceeded",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var conditions []metav1.Condition
+			SetRollout(&conditions, 5, tt.rollout)
+			assert.Equal(t, tt.expected, statusOf(conditions))
+			for _, c := range conditions {
+				assert.Equal(t, int64(5), c.ObservedGeneration)
+			}
+		})
+	}
+}
+
+func TestSetReconcileError(t *testing.T) {
+	var conditions []metav1.Condition
+	SetRollout(&conditions, 1, &Rollout{Observed: true, Desired: 1, Updated: 1, Ready: 1})
+	SetReconcileError(&conditions, 2, errors.New("failed to create objects"))
+
+	assert.Equal(t, map[string]string{
+		v1beta1.ConditionTypeReady:       "False/ReconcileError",
+		v1beta1.ConditionTypeProgressing: "False/RolloutComplete",
+		v1beta1.ConditionTypeDegraded:    "True/ReconcileError",
+	}, statusOf(conditions))
+	assert.Equal(t, "failed to create objects", meta.FindStatusCondition(conditions, v1beta1.ConditionTypeDegraded).Message)
+}
+
+func TestSetConfigValid(t *testing.T) {
+	var conditions []metav1.Condition
+	SetConfigValid(&conditions, 1, errors.New("config fragment conflict"))
+	assert.Equal(t, map[string]string{v1beta1.ConditionTypeConfigValid: "False/InvalidConfig"}, statusOf(conditions))
+
+	SetConfigValid(&conditions, 2, nil)
+	assert.Equal(t, map[string]string{v1beta1.ConditionTypeConfigValid: "True/ConfigAccepted"}, statusOf(conditions))
+}
+
+func TestSetTargetAllocatorReady(t *testing.T) {
+	var conditions []metav1.Condition
+	SetTargetAllocatorReady(&conditions, 1, &Rollout{})
+	assert.Equal(t, map[string]string{v1beta1.ConditionTypeTargetAllocatorReady: "False/RolloutInProgress"}, statusOf(conditions))
+
+	SetTargetAllocatorReady(&conditions, 1, &Rollout{Observed: true, Desired: 1, Updated: 1, Ready: 1})
+	assert.Equal(t, map[string]string{v1beta1.ConditionTypeTargetAllocatorReady: "True/RolloutComplete"}, statusOf(conditions))
+
+	SetTargetAllocatorReady(&conditions, 1, nil)
+	assert.Empty(t, conditions)
+}
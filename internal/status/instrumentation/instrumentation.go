@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package instrumentation computes the status of Instrumentations from the pods they were injected into.
+package instrumentation
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+type workloadKey struct {
+	kind      string
+	namespace string
+	name      string
+	language  v1alpha1.InstrumentationLanguage
+}
+
+// Status returns the status of the Instrumentation given the injected pods. Pods that aren't running anymore and pods
+// injected from other Instrumentations are ignored.
+func Status(logger logr.Logger, inst v1alpha1.Instrumentation, pods []corev1.Pod) v1alpha1.InstrumentationStatus {
+	instName := types.NamespacedName{Namespace: inst.Namespace, Name: inst.Name}.String()
+	status := v1alpha1.InstrumentationStatus{}
+	workloads := map[workloadKey]*v1alpha1.InstrumentedWorkload{}
+
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		injections, err := instrumentation.GetInjections(pod)
+		if err != nil {
+			logger.V(1).Info("ignoring pod with invalid injections", "namespace", pod.Namespace, "name", pod.Name, "error", err.Error())
+			continue
+		}
+
+		injected, outdated := false, false
+		kind, name := owningWorkload(pod)
+		for _, injection := range injections {
+			if injection.Instrumentation != instName {
+				continue
+			}
+			injected = true
+			key := workloadKey{kind: kind, namespace: pod.Namespace, name: name, language: injection.Language}
+			workload, ok := workloads[key]
+			if !ok {
+				workload = &v1alpha1.InstrumentedWorkload{Kind: kind, Namespace: pod.Namespace, Name: name, Language: injection.Language}
+				workloads[key] = workload
+			}
+			workload.Pods++
+			workload.Containers = appendMissing(workload.Containers, injection.Containers...)
+			if injection.Image != "" {
+				workload.Images = appendMissing(workload.Images, injection.Image)
+				if injection.Image != instrumentation.AgentImage(inst, injection.Language) {
+					workload.OutdatedPods++
+					outdated = true
+				}
+			}
+		}
+		if injected {
+			status.InjectedPods++
+		}
+		if outdated {
+			status.OutdatedPods++
+		}
+	}
+
+	for _, workload := range workloads {
+		sort.Strings(workload.Containers)
+		sort.Strings(workload.Images)
+		status.Workloads = append(status.Workloads, *workload)
+	}
+	sort.Slice(status.Workloads, func(i, j int) bool {
+		a, b := status.Workloads[i], status.Workloads[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Language < b.Language
+	})
+	return status
+}
+
+// owningWorkload returns the kind and name of the workload owning the pod. The Deployment of a ReplicaSet is derived
+// from the name of the ReplicaSet, which is the name of the Deployment followed by the pod template hash.
+func owningWorkload(pod corev1.Pod) (string, string) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return "Pod", pod.Name
+	}
+	if owner.Kind == "ReplicaSet" {
+		if hash, ok := pod.Labels["pod-template-hash"]; ok && strings.HasSuffix(owner.Name, "-"+hash) {
+			return "Deployment", strings.TrimSuffix(owner.Name, "-"+hash)
+		}
+	}
+	return owner.Kind, owner.Name
+}
+
+func appendMissing(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
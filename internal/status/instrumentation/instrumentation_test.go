@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+func injectedPod(namespace, name string, owner *metav1.OwnerReference, labels map[string]string, injections string) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Labels:      labels,
+			Annotations: map[string]string{instrumentation.AnnotationInjections: injections},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if owner != nil {
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func TestStatus(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "observability", Name: "default"},
+		Spec: v1alpha1.InstrumentationSpec{
+			Java:   v1alpha1.Java{Image: "java:2.0"},
+			Python: v1alpha1.Python{Image: "python:0.50"},
+		},
+	}
+	replicaSet := &metav1.OwnerReference{Kind: "ReplicaSet", Name: "cart-5d8f7c", Controller: ptr.To(true)}
+	statefulSet := &metav1.OwnerReference{Kind: "StatefulSet", Name: "orders", Controller: ptr.To(true)}
+	hash := map[string]string{"pod-template-hash": "5d8f7c"}
+
+	completed := injectedPod("shop", "job-1", nil, nil, `[{"language":"java","instrumentation":"observability/default","containers":["app"],"image":"java:2.0"}]`)
+	completed.Status.Phase = corev1.PodSucceeded
+
+	pods := []corev1.Pod{
+		injectedPod("shop", "cart-5d8f7c-a", replicaSet, hash, `[{"language":"java","instrumentation":"observability/default","containers":["app"],"image":"java:2.0"}]`),
+		injectedPod("shop", "cart-5d8f7c-b", replicaSet, hash, `[{"language":"java","instrumentation":"observability/default","containers":["app"],"image":"java:1.0"}]`),
+		injectedPod("shop", "orders-0", statefulSet, nil, `[{"language":"python","instrumentation":"observability/default","containers":["worker"],"image":"python:0.50"},{"language":"sdk","instrumentation":"observability/default","containers":["proxy"]}]`),
+		injectedPod("shop", "debug", nil, nil, `[{"language":"java","instrumentation":"shop/other","containers":["app"],"image":"java:1.0"}]`),
+		injectedPod("shop", "broken", nil, nil, `not json`),
+		completed,
+	}
+
+	status := Status(logr.Discard(), inst, pods)
+
+	assert.Equal(t, v1alpha1.InstrumentationStatus{
+		InjectedPods: 3,
+		OutdatedPods: 1,
+		Workloads: []v1alpha1.InstrumentedWorkload{
+			{
+				Kind:         "Deployment",
+				Namespace:    "shop",
+				Name:         "cart",
+				Language:     v1alpha1.LanguageJava,
+				Containers:   []string{"app"},
+				Images:       []string{"java:1.0", "java:2.0"},
+				Pods:         2,
+				OutdatedPods: 1,
+			},
+			{
+				Kind:       "StatefulSet",
+				Namespace:  "shop",
+				Name:       "orders",
+				Language:   v1alpha1.LanguagePython,
+				Containers: []string{"worker"},
+				Images:     []string{"python:0.50"},
+				Pods:       1,
+			},
+			{
+				Kind:       "StatefulSet",
+				Namespace:  "shop",
+				Name:       "orders",
+				Language:   v1alpha1.LanguageSdk,
+				Containers: []string{"proxy"},
+				Pods:       1,
+			},
+		},
+	}, status)
+}
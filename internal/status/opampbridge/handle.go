@@ -23,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
 )
 
 const (
@@ -40,6 +41,11 @@ func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifest
 	log.V(2).Info("updating opampbridge status")
 	if err != nil {
 		params.Recorder.Event(&params.OpAMPBridge, eventTypeWarning, reasonError, err.Error())
+		failed := params.OpAMPBridge.DeepCopy()
+		conditions.SetReconcileError(&failed.Status.Conditions, failed.Generation, err)
+		if patchErr := params.Client.Status().Patch(ctx, failed, client.MergeFrom(&params.OpAMPBridge)); patchErr != nil {
+			log.V(2).Error(patchErr, "failed to apply the status conditions")
+		}
 		return ctrl.Result{}, err
 	}
 	changed := params.OpAMPBridge.DeepCopy()
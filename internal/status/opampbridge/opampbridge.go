@@ -16,10 +16,15 @@ package opampbridge
 
 import (
 	"context"
+	"fmt"
 
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
@@ -27,5 +32,20 @@ func UpdateOpAMPBridgeStatus(ctx context.Context, cli client.Client, changed *v1
 	if changed.Status.Version == "" {
 		changed.Status.Version = version.OperatorOpAMPBridge()
 	}
+
+	obj := &appsv1.Deployment{}
+	objKey := client.ObjectKey{
+		Namespace: changed.GetNamespace(),
+		Name:      naming.OpAMPBridge(changed.Name),
+	}
+	rollout := conditions.Rollout{}
+	if err := cli.Get(ctx, objKey, obj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get the OpAMP Bridge deployment: %w", err)
+		}
+	} else {
+		rollout = conditions.FromDeployment(obj)
+	}
+	conditions.SetRollout(&changed.Status.Conditions, changed.Generation, &rollout)
 	return nil
 }
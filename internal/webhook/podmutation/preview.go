@@ -0,0 +1,343 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmutation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"gomodules.xyz/jsonpatch/v2"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// PreviewPath is the path of the pod mutation preview on the webhook server.
+const PreviewPath = "/preview-v1-pod"
+
+// PreviewRequest is the body of a pod mutation preview.
+type PreviewRequest struct {
+	// Namespace the pod would be created in.
+	Namespace string `json:"namespace"`
+	// Template of the pod, as in the spec of a workload.
+	Template corev1.PodTemplateSpec `json:"template"`
+}
+
+// PreviewResponse is the result of a pod mutation preview.
+type PreviewResponse struct {
+	// Patch is the JSON patch the pod mutation webhook would apply to the pod.
+	Patch []jsonpatch.JsonPatchOperation `json:"patch"`
+	// Mutators lists what each pod mutator did, in the order they ran.
+	Mutators []MutatorPreview `json:"mutators"`
+	// Error is the error the webhook would have failed with, the pod is then created without a patch.
+	Error string `json:"error,omitempty"`
+}
+
+// MutatorPreview is what a pod mutator did with the pod.
+type MutatorPreview struct {
+	// Name of the pod mutator.
+	Name string `json:"name"`
+	// Mutated is true when the pod mutator changed the pod.
+	Mutated bool `json:"mutated"`
+	// Messages logged by the pod mutator, explaining which instance was chosen or why the pod was skipped.
+	Messages []PreviewMessage `json:"messages,omitempty"`
+	// Error returned by the pod mutator.
+	Error string `json:"error,omitempty"`
+}
+
+// PreviewMessage is a message logged by a pod mutator.
+type PreviewMessage struct {
+	Message string            `json:"message"`
+	Error   string            `json:"error,omitempty"`
+	Values  map[string]string `json:"values,omitempty"`
+}
+
+// DiscardEvents is an event recorder discarding every event. The pod mutators of the preview should record their
+// events to it, the pods of a preview are never created.
+var DiscardEvents record.EventRecorder = discardEvents{}
+
+type discardEvents struct{}
+
+func (discardEvents) Event(runtime.Object, string, string, string) {}
+
+func (discardEvents) Eventf(runtime.Object, string, string, string, ...interface{}) {}
+
+func (discardEvents) AnnotatedEventf(runtime.Object, map[string]string, string, string, string, ...interface{}) {
+}
+
+type previewHandler struct {
+	client      client.Client
+	clientset   kubernetes.Interface
+	logger      logr.Logger
+	podMutators []PodMutator
+}
+
+// NewPreviewHandler creates the handler previewing the patch the pod mutation webhook would apply to a pod template.
+// The pod mutators are run the same way as by the webhook, the messages they log are returned in the response.
+// Requests are authenticated with the bearer token of their Authorization header, and only allowed for users who can
+// create pods in the namespace of the preview: it reveals the configuration of the Instrumentations and collectors
+// applied to the namespace.
+func NewPreviewHandler(logger logr.Logger, cl client.Client, clientset kubernetes.Interface, podMutators []PodMutator) http.Handler {
+	return &previewHandler{
+		client:      cl,
+		clientset:   clientset,
+		logger:      logger,
+		podMutators: podMutators,
+	}
+}
+
+func (h *previewHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	user, err := h.authenticate(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	var req PreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode the preview request: %s", err), http.StatusBadRequest)
+		return
+	}
+	if req.Namespace == "" {
+		http.Error(w, "the namespace of the preview request is required", http.StatusBadRequest)
+		return
+	}
+	if err := h.authorize(r.Context(), user, req.Namespace); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	resp, err := h.preview(r.Context(), req)
+	if err != nil {
+		h.logger.Error(err, "failed to preview the pod mutation")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error(err, "failed to write the pod mutation preview")
+	}
+}
+
+// authenticate returns the user the bearer token of the request belongs to.
+func (h *previewHandler) authenticate(r *http.Request) (authenticationv1.UserInfo, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return authenticationv1.UserInfo{}, errors.New("a bearer token is required")
+	}
+	review, err := h.clientset.AuthenticationV1().TokenReviews().Create(r.Context(), &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		h.logger.Error(err, "failed to review the token of the preview request")
+		return authenticationv1.UserInfo{}, errors.New("the bearer token couldn't be reviewed")
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, errors.New("the bearer token isn't valid")
+	}
+	return review.Status.User, nil
+}
+
+// authorize checks that the user can create pods in the namespace.
+func (h *previewHandler) authorize(ctx context.Context, user authenticationv1.UserInfo, namespace string) error {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	review, err := h.clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "create",
+				Resource:  "pods",
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		h.logger.Error(err, "failed to review the access of the preview request")
+		return errors.New("the access to the namespace couldn't be reviewed")
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("%s can't create pods in the namespace %s", user.Username, namespace)
+	}
+	return nil
+}
+
+func (h *previewHandler) preview(ctx context.Context, req PreviewRequest) (PreviewResponse, error) {
+	pod := corev1.Pod{
+		ObjectMeta: req.Template.ObjectMeta,
+		Spec:       req.Template.Spec,
+	}
+	pod.Namespace = req.Namespace
+	raw, err := json.Marshal(pod)
+	if err != nil {
+		return PreviewResponse{}, err
+	}
+
+	ns := corev1.Namespace{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: req.Namespace}, &ns); err != nil {
+		return PreviewResponse{}, err
+	}
+
+	recorder := &previewRecorder{}
+	mutated, err := mutate(context.WithValue(ctx, previewRecorderKey{}, recorder), h.podMutators, ns, pod)
+	resp := PreviewResponse{Mutators: recorder.mutators, Patch: []jsonpatch.JsonPatchOperation{}}
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, nil
+	}
+
+	marshaledPod, err := json.Marshal(mutated)
+	if err != nil {
+		return PreviewResponse{}, err
+	}
+	patch := admission.PatchResponseFromRaw(raw, marshaledPod)
+	if patch.Result != nil && patch.Result.Code != http.StatusOK {
+		return PreviewResponse{}, fmt.Errorf("failed to compute the patch: %s", patch.Result.Message)
+	}
+	if patch.Patches != nil {
+		resp.Patch = patch.Patches
+	}
+	return resp, nil
+}
+
+type previewRecorderKey struct{}
+
+// previewRecorder records what the pod mutators do during a preview.
+type previewRecorder struct {
+	mu       sync.Mutex
+	mutators []MutatorPreview
+}
+
+func (r *previewRecorder) start(m PodMutator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mutators = append(r.mutators, MutatorPreview{Name: strings.TrimPrefix(fmt.Sprintf("%T", m), "*")})
+}
+
+func (r *previewRecorder) finish(mutated bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := &r.mutators[len(r.mutators)-1]
+	current.Mutated = mutated
+	if err != nil {
+		current.Error = err.Error()
+	}
+}
+
+func (r *previewRecorder) record(msg PreviewMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.mutators) == 0 {
+		return
+	}
+	current := &r.mutators[len(r.mutators)-1]
+	current.Messages = append(current.Messages, msg)
+}
+
+// Logger returns the logger pod mutators log their decisions to. During a preview, the messages are recorded,
+// whatever their verbosity, and returned in the preview response.
+func Logger(ctx context.Context, logger logr.Logger) logr.Logger {
+	recorder, ok := ctx.Value(previewRecorderKey{}).(*previewRecorder)
+	if !ok {
+		return logger
+	}
+	return logr.New(&previewSink{delegate: logger.GetSink(), recorder: recorder})
+}
+
+// previewSink records the messages logged to it, and passes them on to the delegate sink when it's enabled.
+type previewSink struct {
+	delegate logr.LogSink
+	recorder *previewRecorder
+	values   []any
+}
+
+var _ logr.LogSink = (*previewSink)(nil)
+
+// Init is a no-op, the delegate sink was already initialized by its own logger.
+func (s *previewSink) Init(logr.RuntimeInfo) {}
+
+func (s *previewSink) Enabled(int) bool {
+	return true
+}
+
+func (s *previewSink) Info(level int, msg string, keysAndValues ...any) {
+	s.recorder.record(PreviewMessage{Message: msg, Values: s.valueMap(keysAndValues)})
+	if s.delegate != nil && s.delegate.Enabled(level) {
+		s.delegate.Info(level, msg, keysAndValues...)
+	}
+}
+
+func (s *previewSink) Error(err error, msg string, keysAndValues ...any) {
+	message := PreviewMessage{Message: msg, Values: s.valueMap(keysAndValues)}
+	if err != nil {
+		message.Error = err.Error()
+	}
+	s.recorder.record(message)
+	if s.delegate != nil {
+		s.delegate.Error(err, msg, keysAndValues...)
+	}
+}
+
+func (s *previewSink) WithValues(keysAndValues ...any) logr.LogSink {
+	sink := &previewSink{recorder: s.recorder, values: append(append([]any{}, s.values...), keysAndValues...)}
+	if s.delegate != nil {
+		sink.delegate = s.delegate.WithValues(keysAndValues...)
+	}
+	return sink
+}
+
+func (s *previewSink) WithName(name string) logr.LogSink {
+	sink := &previewSink{recorder: s.recorder, values: s.values}
+	if s.delegate != nil {
+		sink.delegate = s.delegate.WithName(name)
+	}
+	return sink
+}
+
+func (s *previewSink) valueMap(keysAndValues []any) map[string]string {
+	all := append(append([]any{}, s.values...), keysAndValues...)
+	if len(all) == 0 {
+		return nil
+	}
+	values := map[string]string{}
+	for i := 0; i+1 < len(all); i += 2 {
+		values[fmt.Sprint(all[i])] = fmt.Sprint(all[i+1])
+	}
+	return values
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmutation_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gomodules.xyz/jsonpatch/v2"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
+)
+
+type labelingMutator struct{}
+
+func (labelingMutator) Mutate(ctx context.Context, _ corev1.Namespace, pod corev1.Pod) (corev1.Pod, error) {
+	Logger(ctx, logr.Discard()).V(1).Info("labeling pod", "label", "injected")
+	pod.Labels = map[string]string{"injected": "true"}
+	return pod, nil
+}
+
+type skippingMutator struct{}
+
+func (skippingMutator) Mutate(ctx context.Context, _ corev1.Namespace, pod corev1.Pod) (corev1.Pod, error) {
+	Logger(ctx, logr.Discard()).WithValues("namespace", pod.Namespace).V(1).Info("annotation not present, skipping")
+	return pod, nil
+}
+
+type failingMutator struct{}
+
+func (failingMutator) Mutate(ctx context.Context, _ corev1.Namespace, pod corev1.Pod) (corev1.Pod, error) {
+	err := errors.New("instance not found")
+	Logger(ctx, logr.Discard()).Error(err, "failed to select an instance")
+	return pod, err
+}
+
+// reviewer authenticates the "valid" token as jane, who can create pods in every namespace but "forbidden".
+func reviewer() *k8sfake.Clientset {
+	c := k8sfake.NewSimpleClientset()
+	c.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		review.Status.Authenticated = review.Spec.Token == "valid"
+		review.Status.User = authenticationv1.UserInfo{Username: "jane", Groups: []string{"developers"}}
+		return true, review, nil
+	})
+	c.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		review.Status.Allowed = review.Spec.User == "jane" && attributes.Namespace != "forbidden" &&
+			attributes.Verb == "create" && attributes.Resource == "pods"
+		return true, review, nil
+	})
+	return c
+}
+
+func previewPod(t *testing.T, handler http.Handler, req PreviewRequest) *httptest.ResponseRecorder {
+	return previewPodWithToken(t, handler, req, "valid")
+}
+
+func previewPodWithToken(t *testing.T, handler http.Handler, req PreviewRequest, token string) *httptest.ResponseRecorder {
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	httpReq := httptest.NewRequest(http.MethodPost, PreviewPath, bytes.NewReader(body))
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+	handler.ServeHTTP(rec, httpReq)
+	return rec
+}
+
+func TestPreview(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "preview"}}
+	cl := fake.NewClientBuilder().WithObjects(ns).Build()
+	handler := NewPreviewHandler(logger, cl, reviewer(), []PodMutator{skippingMutator{}, labelingMutator{}})
+
+	rec := previewPod(t, handler, PreviewRequest{
+		Namespace: "preview",
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		},
+	})
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp PreviewResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, []jsonpatch.JsonPatchOperation{
+		{Operation: "add", Path: "/metadata/labels", Value: map[string]any{"injected": "true"}},
+	}, resp.Patch)
+	assert.Equal(t, []MutatorPreview{
+		{
+			Name:     "podmutation_test.skippingMutator",
+			Messages: []PreviewMessage{{Message: "annotation not present, skipping", Values: map[string]string{"namespace": "preview"}}},
+		},
+		{
+			Name:     "podmutation_test.labelingMutator",
+			Mutated:  true,
+			Messages: []PreviewMessage{{Message: "labeling pod", Values: map[string]string{"label": "injected"}}},
+		},
+	}, resp.Mutators)
+	assert.Empty(t, resp.Error)
+}
+
+func TestPreviewMutatorError(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "preview"}}
+	cl := fake.NewClientBuilder().WithObjects(ns).Build()
+	handler := NewPreviewHandler(logger, cl, reviewer(), []PodMutator{failingMutator{}, labelingMutator{}})
+
+	rec := previewPod(t, handler, PreviewRequest{
+		Namespace: "preview",
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		},
+	})
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp PreviewResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	// the webhook allows the pod without any patch when a mutator fails
+	assert.Empty(t, resp.Patch)
+	assert.Equal(t, "instance not found", resp.Error)
+	assert.Equal(t, []MutatorPreview{
+		{
+			Name:     "podmutation_test.failingMutator",
+			Messages: []PreviewMessage{{Message: "failed to select an instance", Error: "instance not found"}},
+			Error:    "instance not found",
+		},
+	}, resp.Mutators)
+}
+
+func TestPreviewInvalidRequest(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	handler := NewPreviewHandler(logger, cl, reviewer(), []PodMutator{labelingMutator{}})
+
+	rec := previewPod(t, handler, PreviewRequest{})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = previewPod(t, handler, PreviewRequest{Namespace: "does-not-exist"})
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PreviewPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestPreviewAccess(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "forbidden"}}
+	cl := fake.NewClientBuilder().WithObjects(ns).Build()
+	handler := NewPreviewHandler(logger, cl, reviewer(), []PodMutator{labelingMutator{}})
+	req := PreviewRequest{
+		Namespace: "forbidden",
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		},
+	}
+
+	rec := previewPodWithToken(t, handler, req, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = previewPodWithToken(t, handler, req, "expired")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = previewPodWithToken(t, handler, req, "valid")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "jane can't create pods in the namespace forbidden")
+}
+
+func TestLoggerOutsidePreview(t *testing.T) {
+	assert.Equal(t, logger, Logger(context.Background(), logger))
+}
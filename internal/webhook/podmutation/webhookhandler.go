@@ -22,6 +22,7 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -89,13 +90,11 @@ func (p *podMutationWebhook) Handle(ctx context.Context, req admission.Request)
 		return res
 	}
 
-	for _, m := range p.podMutators {
-		pod, err = m.Mutate(ctx, ns, pod)
-		if err != nil {
-			res := admission.Errored(http.StatusInternalServerError, err)
-			res.Allowed = true
-			return res
-		}
+	pod, err = mutate(ctx, p.podMutators, ns, pod)
+	if err != nil {
+		res := admission.Errored(http.StatusInternalServerError, err)
+		res.Allowed = true
+		return res
 	}
 
 	marshaledPod, err := json.Marshal(pod)
@@ -106,3 +105,26 @@ func (p *podMutationWebhook) Handle(ctx context.Context, req admission.Request)
 	}
 	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledPod)
 }
+
+// mutate runs the pod mutators in order, it's shared by the webhook and its preview.
+func mutate(ctx context.Context, podMutators []PodMutator, ns corev1.Namespace, pod corev1.Pod) (corev1.Pod, error) {
+	recorder, previewing := ctx.Value(previewRecorderKey{}).(*previewRecorder)
+	for _, m := range podMutators {
+		if !previewing {
+			var err error
+			if pod, err = m.Mutate(ctx, ns, pod); err != nil {
+				return pod, err
+			}
+			continue
+		}
+
+		recorder.start(m)
+		mutated, err := m.Mutate(ctx, ns, *pod.DeepCopy())
+		recorder.finish(err == nil && !equality.Semantic.DeepEqual(pod, mutated), err)
+		if err != nil {
+			return mutated, err
+		}
+		pod = mutated
+	}
+	return pod, nil
+}
@@ -31,8 +31,12 @@ import (
 	"github.com/spf13/pflag"
 	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -40,22 +44,28 @@ import (
 	k8sapiflag "k8s.io/component-base/cli/flag"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/controllers"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components/schema"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/fips"
+	kedav1alpha1 "github.com/open-telemetry/opentelemetry-operator/internal/keda/v1alpha1"
 	collectorManifests "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	openshiftDashboards "github.com/open-telemetry/opentelemetry-operator/internal/openshift/dashboards"
 	operatormetrics "github.com/open-telemetry/opentelemetry-operator/internal/operator-metrics"
@@ -118,11 +128,14 @@ func main() {
 		createRBACPermissions            bool
 		createOpenShiftDashboard         bool
 		enableMultiInstrumentation       bool
+		enablePodMutationPreview         bool
 		enableApacheHttpdInstrumentation bool
 		enableDotNetInstrumentation      bool
 		enableGoInstrumentation          bool
 		enablePythonInstrumentation      bool
 		enableNginxInstrumentation       bool
+		enableRubyInstrumentation        bool
+		enablePHPInstrumentation         bool
 		enableNodeJSInstrumentation      bool
 		enableJavaInstrumentation        bool
 		enableCRMetrics                  bool
@@ -136,6 +149,8 @@ func main() {
 		autoInstrumentationApacheHttpd   string
 		autoInstrumentationNginx         string
 		autoInstrumentationGo            string
+		autoInstrumentationRuby          string
+		autoInstrumentationPHP           string
 		labelsFilter                     []string
 		tmplabelsFilter                  []string
 		annotationsFilter                []string
@@ -146,6 +161,10 @@ func main() {
 		encodeTimeKey                    string
 		encodeLevelFormat                string
 		fipsDisabledComponents           string
+		configSchemaValidation           string
+		configSchemaConfigMap            string
+		sidecarConfigReloaderImage       string
+		sidecarConfigReloaderProcess     string
 	)
 
 	pflag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
@@ -157,11 +176,14 @@ func main() {
 	pflag.BoolVar(&createRBACPermissions, "create-rbac-permissions", false, "Automatically create RBAC permissions needed by the processors (deprecated)")
 	pflag.BoolVar(&createOpenShiftDashboard, "openshift-create-dashboard", false, "Create an OpenShift dashboard for monitoring the OpenTelemetryCollector instances")
 	pflag.BoolVar(&enableMultiInstrumentation, "enable-multi-instrumentation", true, "Controls whether the operator supports multi instrumentation")
+	pflag.BoolVar(&enablePodMutationPreview, "enable-pod-mutation-preview", false, "Controls whether the webhook server exposes a preview of the pod mutations on "+podmutation.PreviewPath)
 	pflag.BoolVar(&enableApacheHttpdInstrumentation, constants.FlagApacheHttpd, true, "Controls whether the operator supports Apache HTTPD auto-instrumentation")
 	pflag.BoolVar(&enableDotNetInstrumentation, constants.FlagDotNet, true, "Controls whether the operator supports dotnet auto-instrumentation")
 	pflag.BoolVar(&enableGoInstrumentation, constants.FlagGo, false, "Controls whether the operator supports Go auto-instrumentation")
 	pflag.BoolVar(&enablePythonInstrumentation, constants.FlagPython, true, "Controls whether the operator supports python auto-instrumentation")
 	pflag.BoolVar(&enableNginxInstrumentation, constants.FlagNginx, false, "Controls whether the operator supports nginx auto-instrumentation")
+	pflag.BoolVar(&enableRubyInstrumentation, constants.FlagRuby, false, "Controls whether the operator supports ruby auto-instrumentation")
+	pflag.BoolVar(&enablePHPInstrumentation, constants.FlagPHP, false, "Controls whether the operator supports php auto-instrumentation")
 	pflag.BoolVar(&enableNodeJSInstrumentation, constants.FlagNodeJS, true, "Controls whether the operator supports nodejs auto-instrumentation")
 	pflag.BoolVar(&enableJavaInstrumentation, constants.FlagJava, true, "Controls whether the operator supports java auto-instrumentation")
 	pflag.BoolVar(&enableCRMetrics, constants.FlagCRMetrics, false, "Controls whether exposing the CR metrics is enabled")
@@ -176,6 +198,8 @@ func main() {
 	stringFlagOrEnv(&autoInstrumentationGo, "auto-instrumentation-go-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_GO", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-go-instrumentation/autoinstrumentation-go:%s", v.AutoInstrumentationGo), "The default OpenTelemetry Go instrumentation image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&autoInstrumentationApacheHttpd, "auto-instrumentation-apache-httpd-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_APACHE_HTTPD", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationApacheHttpd), "The default OpenTelemetry Apache HTTPD instrumentation image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&autoInstrumentationNginx, "auto-instrumentation-nginx-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_NGINX", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationNginx), "The default OpenTelemetry Nginx instrumentation image. This image is used when no image is specified in the CustomResource.")
+	stringFlagOrEnv(&autoInstrumentationRuby, "auto-instrumentation-ruby-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_RUBY", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-ruby:%s", v.AutoInstrumentationRuby), "The default OpenTelemetry Ruby instrumentation image. This image is used when no image is specified in the CustomResource.")
+	stringFlagOrEnv(&autoInstrumentationPHP, "auto-instrumentation-php-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_PHP", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-php:%s", v.AutoInstrumentationPHP), "The default OpenTelemetry PHP instrumentation image. This image is used when no image is specified in the CustomResource.")
 	pflag.StringArrayVar(&labelsFilter, "labels-filter", []string{}, "Labels to filter away from propagating onto deploys. It should be a string array containing patterns, which are literal strings optionally containing a * wildcard character. Example: --labels-filter=.*filter.out will filter out labels that looks like: label.filter.out: true")
 	pflag.StringArrayVar(&tmplabelsFilter, "label", []string{}, "(Deprecated, please use the labels-filter flag) Labels to filter away from propagating onto deploys. It should be a string array containing patterns, which are literal strings optionally containing a * wildcard character. Example: --label=.*filter.out will filter out labels that looks like: label.filter.out: true")
 	pflag.StringArrayVar(&annotationsFilter, "annotations-filter", []string{}, "Annotations to filter away from propagating onto deploys. It should be a string array containing patterns, which are literal strings optionally containing a * wildcard character. Example: --annotations-filter=.*filter.out will filter out annotations that looks like: annotation.filter.out: true")
@@ -187,6 +211,10 @@ func main() {
 	pflag.StringVar(&encodeLevelFormat, "zap-level-format", "uppercase", "The level format to be used in the customized Log Encoder")
 	pflag.StringVar(&fipsDisabledComponents, "fips-disabled-components", "uppercase", "Disabled collector components when operator runs on FIPS enabled platform. Example flag value =receiver.foo,receiver.bar,exporter.baz")
 	pflag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook endpoint binds to.")
+	pflag.StringVar(&configSchemaValidation, "collector-config-schema-validation", string(schema.ValidationDisabled), "Controls how the webhook reports collector component configurations that don't match their schema. One of disabled, warn or enforce")
+	pflag.StringVar(&configSchemaConfigMap, "collector-config-schema-configmap", "", "The namespace/name of a ConfigMap holding additional component schemas, with keys in the form <collector version>_<kind>_<type>.json")
+	pflag.StringVar(&sidecarConfigReloaderImage, "sidecar-config-reloader-image", "", "The image of the container reloading the sidecar collectors on configuration changes. Requires the operator.sidecarcollector.configmap feature gate, and is only injected into the pods sharing their process namespace; when empty, sidecar collectors keep their configuration until the pod is recreated.")
+	pflag.StringVar(&sidecarConfigReloaderProcess, "sidecar-config-reloader-process-name", "otelcol", "The executable name of the sidecar collector process signaled by the config reloader.")
 	pflag.Parse()
 
 	// Using labelfilters both from label and labels-filter flags, until label flag is removed
@@ -214,6 +242,8 @@ func main() {
 		"auto-instrumentation-go", autoInstrumentationGo,
 		"auto-instrumentation-apache-httpd", autoInstrumentationApacheHttpd,
 		"auto-instrumentation-nginx", autoInstrumentationNginx,
+		"auto-instrumentation-ruby", autoInstrumentationRuby,
+		"auto-instrumentation-php", autoInstrumentationPHP,
 		"feature-gates", flagset.Lookup(featuregate.FeatureGatesFlag).Value.String(),
 		"build-date", v.BuildDate,
 		"go-version", v.Go,
@@ -227,6 +257,8 @@ func main() {
 		"enable-go-instrumentation", enableGoInstrumentation,
 		"enable-python-instrumentation", enablePythonInstrumentation,
 		"enable-nginx-instrumentation", enableNginxInstrumentation,
+		"enable-ruby-instrumentation", enableRubyInstrumentation,
+		"enable-php-instrumentation", enablePHPInstrumentation,
 		"enable-nodejs-instrumentation", enableNodeJSInstrumentation,
 		"enable-java-instrumentation", enableJavaInstrumentation,
 		"create-openshift-dashboard", createOpenShiftDashboard,
@@ -280,6 +312,26 @@ func main() {
 			DefaultNamespaces: namespaces,
 		},
 	}
+	sidecarPods := featuregate.EnableSidecarConfigMap.IsEnabled() || featuregate.EnableSidecarResourceRecommendations.IsEnabled()
+	switch {
+	case featuregate.EnableInstrumentationStatus.IsEnabled() && sidecarPods:
+		// both the instrumented and the sidecar pods are watched, the cache can't be restricted to one of them
+	case featuregate.EnableInstrumentationStatus.IsEnabled():
+		// only the injected pods are relevant to the Instrumentation status, don't cache all the pods of the cluster
+		mgrOptions.Cache.ByObject = map[client.Object]cache.ByObject{
+			&corev1.Pod{}: {Label: labels.SelectorFromSet(labels.Set{instrumentation.LabelInjected: "true"})},
+		}
+	case sidecarPods:
+		// only the pods with a sidecar are relevant to the collector status
+		sidecarInjected, selectorErr := labels.NewRequirement(constants.LabelSidecarInjected, selection.Exists, nil)
+		if selectorErr != nil {
+			setupLog.Error(selectorErr, "failed to build the sidecar pod selector")
+			os.Exit(1)
+		}
+		mgrOptions.Cache.ByObject = map[client.Object]cache.ByObject{
+			&corev1.Pod{}: {Label: labels.NewSelector().Add(*sidecarInjected)},
+		}
+	}
 
 	mgr, err := ctrl.NewManager(restConfig, mgrOptions)
 	if err != nil {
@@ -303,6 +355,17 @@ func main() {
 
 	reviewer := rbac.NewReviewer(clientset)
 
+	schemaValidation, err := schema.ParseValidationMode(configSchemaValidation)
+	if err != nil {
+		setupLog.Error(err, "invalid collector config schema validation mode")
+		os.Exit(1)
+	}
+	schemas, err := loadConfigSchemas(ctx, clientset, configSchemaConfigMap)
+	if err != nil {
+		setupLog.Error(err, "failed to load the collector config schemas")
+		os.Exit(1)
+	}
+
 	// builds the operator's configuration
 	ad, err := autodetect.New(restConfig, reviewer)
 	if err != nil {
@@ -319,6 +382,8 @@ func main() {
 		config.WithEnableDotNetInstrumentation(enableDotNetInstrumentation),
 		config.WithEnableGoInstrumentation(enableGoInstrumentation),
 		config.WithEnableNginxInstrumentation(enableNginxInstrumentation),
+		config.WithEnableRubyInstrumentation(enableRubyInstrumentation),
+		config.WithEnablePHPInstrumentation(enablePHPInstrumentation),
 		config.WithEnablePythonInstrumentation(enablePythonInstrumentation),
 		config.WithEnableNodeJSInstrumentation(enableNodeJSInstrumentation),
 		config.WithEnableJavaInstrumentation(enableJavaInstrumentation),
@@ -331,9 +396,15 @@ func main() {
 		config.WithAutoInstrumentationGoImage(autoInstrumentationGo),
 		config.WithAutoInstrumentationApacheHttpdImage(autoInstrumentationApacheHttpd),
 		config.WithAutoInstrumentationNginxImage(autoInstrumentationNginx),
+		config.WithAutoInstrumentationRubyImage(autoInstrumentationRuby),
+		config.WithAutoInstrumentationPHPImage(autoInstrumentationPHP),
 		config.WithAutoDetect(ad),
 		config.WithLabelFilters(labelsFilter),
 		config.WithAnnotationFilters(annotationsFilter),
+		config.WithCollectorConfigSchemaValidation(schemaValidation),
+		config.WithCollectorConfigSchemas(schemas),
+		config.WithSidecarConfigReloaderImage(sidecarConfigReloaderImage),
+		config.WithSidecarConfigReloaderProcessName(sidecarConfigReloaderProcess),
 	)
 	err = cfg.AutoDetect()
 	if err != nil {
@@ -352,6 +423,18 @@ func main() {
 	} else {
 		setupLog.Info("Openshift CRDs are not installed, skipping adding to scheme.")
 	}
+	if cfg.KEDAAvailability() == keda.Available {
+		setupLog.Info("KEDA CRDs are installed, adding to scheme.")
+		utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+	} else {
+		setupLog.Info("KEDA CRDs are not installed, skipping adding to scheme.")
+	}
+	if cfg.GatewayAPIAvailability() == gatewayapi.Available {
+		setupLog.Info("Gateway API CRDs are installed, adding to scheme.")
+		utilruntime.Must(gatewayv1.Install(scheme))
+	} else {
+		setupLog.Info("Gateway API CRDs are not installed, skipping adding to scheme.")
+	}
 	if cfg.CertManagerAvailability() == certmanager.Available {
 		setupLog.Info("Cert-Manager is available to the operator, adding to scheme.")
 		utilruntime.Must(cmv1.AddToScheme(scheme))
@@ -424,6 +507,77 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = controllers.NewInstrumentationConfigReconciler(controllers.InstrumentationConfigReconcilerParams{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("instrumentation"),
+		Log:      ctrl.Log.WithName("controllers").WithName("InstrumentationConfig"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "InstrumentationConfig")
+		os.Exit(1)
+	}
+
+	if featuregate.EnableInstrumentationStatus.IsEnabled() {
+		if err = controllers.NewInstrumentationReconciler(controllers.InstrumentationReconcilerParams{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor("instrumentation"),
+			Log:      ctrl.Log.WithName("controllers").WithName("Instrumentation"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Instrumentation")
+			os.Exit(1)
+		}
+	}
+
+	if featuregate.EnableSidecarResourceRecommendations.IsEnabled() {
+		if err = controllers.NewSidecarResourcesReconciler(controllers.SidecarResourcesReconcilerParams{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor("opentelemetry-operator"),
+			Log:      ctrl.Log.WithName("controllers").WithName("SidecarResources"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SidecarResources")
+			os.Exit(1)
+		}
+	}
+
+	if featuregate.EnableCollectorScaling.IsEnabled() {
+		if err = controllers.NewScalingReconciler(controllers.ScalingReconcilerParams{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Recorder:  mgr.GetEventRecorderFor("opentelemetry-operator"),
+			Log:       ctrl.Log.WithName("controllers").WithName("Scaling"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Scaling")
+			os.Exit(1)
+		}
+	}
+
+	if featuregate.EnableCollectorRollout.IsEnabled() {
+		if err = controllers.NewRolloutReconciler(controllers.RolloutReconcilerParams{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Recorder:  mgr.GetEventRecorderFor("opentelemetry-operator"),
+			Log:       ctrl.Log.WithName("controllers").WithName("Rollout"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Rollout")
+			os.Exit(1)
+		}
+	}
+
+	if featuregate.EnableWorkloadInstrumentation.IsEnabled() {
+		for _, reconciler := range controllers.NewWorkloadInstrumentationReconcilers(controllers.WorkloadInstrumentationReconcilerParams{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("opentelemetry-operator"),
+			Log:      ctrl.Log.WithName("controllers").WithName("WorkloadInstrumentation"),
+			Config:   cfg,
+		}) {
+			if err = reconciler.SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "WorkloadInstrumentation")
+				os.Exit(1)
+			}
+		}
+	}
+
 	if cfg.PrometheusCRAvailability() == prometheus.Available {
 		operatorMetrics, opError := operatormetrics.NewOperatorMetrics(mgr.GetConfig(), scheme, ctrl.Log.WithName("operator-metrics-sm"))
 		if opError != nil {
@@ -488,13 +642,21 @@ func main() {
 			os.Exit(1)
 		}
 		decoder := admission.NewDecoder(mgr.GetScheme())
+		podMutators := []podmutation.PodMutator{
+			sidecar.NewMutator(logger, cfg, mgr.GetClient()),
+			instrumentation.NewMutator(logger, mgr.GetClient(), mgr.GetEventRecorderFor("opentelemetry-operator"), cfg),
+		}
 		mgr.GetWebhookServer().Register("/mutate-v1-pod", &webhook.Admission{
-			Handler: podmutation.NewWebhookHandler(cfg, ctrl.Log.WithName("pod-webhook"), decoder, mgr.GetClient(),
-				[]podmutation.PodMutator{
-					sidecar.NewMutator(logger, cfg, mgr.GetClient()),
-					instrumentation.NewMutator(logger, mgr.GetClient(), mgr.GetEventRecorderFor("opentelemetry-operator"), cfg),
-				}),
+			Handler: podmutation.NewWebhookHandler(cfg, ctrl.Log.WithName("pod-webhook"), decoder, mgr.GetClient(), podMutators),
 		})
+		if enablePodMutationPreview {
+			// the previewed pods are never created, the events about them are discarded
+			previewMutators := []podmutation.PodMutator{
+				sidecar.NewMutator(logger, cfg, mgr.GetClient()),
+				instrumentation.NewMutator(logger, mgr.GetClient(), podmutation.DiscardEvents, cfg),
+			}
+			mgr.GetWebhookServer().Register(podmutation.PreviewPath, podmutation.NewPreviewHandler(ctrl.Log.WithName("pod-webhook-preview"), mgr.GetClient(), clientset, previewMutators))
+		}
 
 		if err = otelv1alpha1.SetupOpAMPBridgeWebhook(mgr, cfg); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OpAMPBridge")
@@ -599,3 +761,29 @@ func parseFipsFlag(fipsFlag string) ([]string, []string, []string, []string) {
 	}
 	return receivers, exporters, processors, extensions
 }
+
+// loadConfigSchemas returns the component schemas shipped with the operator, extended with the ones from the
+// ConfigMap referenced as namespace/name, if any.
+func loadConfigSchemas(ctx context.Context, clientset kubernetes.Interface, configMap string) (*schema.Bundle, error) {
+	bundle, err := schema.Shipped()
+	if err != nil {
+		return nil, err
+	}
+	if configMap == "" {
+		return bundle, nil
+	}
+	namespace, name, found := strings.Cut(configMap, "/")
+	if !found {
+		return nil, fmt.Errorf("invalid schema ConfigMap %q, expected namespace/name", configMap)
+	}
+	cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema ConfigMap %s: %w", configMap, err)
+	}
+	for key, content := range cm.Data {
+		if err := bundle.Add(key, []byte(content)); err != nil {
+			return nil, err
+		}
+	}
+	return bundle, nil
+}
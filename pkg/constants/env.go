@@ -21,10 +21,17 @@ const (
 	EnvOTELTracesSampler    = "OTEL_TRACES_SAMPLER"
 	EnvOTELTracesSamplerArg = "OTEL_TRACES_SAMPLER_ARG"
 
-	EnvOTELExporterOTLPEndpoint      = "OTEL_EXPORTER_OTLP_ENDPOINT"
-	EnvOTELExporterCertificate       = "OTEL_EXPORTER_OTLP_CERTIFICATE"
-	EnvOTELExporterClientCertificate = "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE"
-	EnvOTELExporterClientKey         = "OTEL_EXPORTER_OTLP_CLIENT_KEY"
+	EnvOTELExporterOTLPEndpoint        = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	EnvOTELExporterCertificate         = "OTEL_EXPORTER_OTLP_CERTIFICATE"
+	EnvOTELExporterClientCertificate   = "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE"
+	EnvOTELExporterClientKey           = "OTEL_EXPORTER_OTLP_CLIENT_KEY"
+	EnvOTELExporterOTLPProtocol        = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	EnvOTELExporterOTLPHeaders         = "OTEL_EXPORTER_OTLP_HEADERS"
+	EnvOTELExporterOTLPCompression     = "OTEL_EXPORTER_OTLP_COMPRESSION"
+	EnvOTELExporterOTLPTimeout         = "OTEL_EXPORTER_OTLP_TIMEOUT"
+	EnvOTELExporterOTLPTracesEndpoint  = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	EnvOTELExporterOTLPMetricsEndpoint = "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"
+	EnvOTELExporterOTLPLogsEndpoint    = "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"
 
 	InstrumentationPrefix                           = "instrumentation.opentelemetry.io/"
 	AnnotationDefaultAutoInstrumentationJava        = InstrumentationPrefix + "default-auto-instrumentation-java-image"
@@ -34,13 +41,22 @@ const (
 	AnnotationDefaultAutoInstrumentationGo          = InstrumentationPrefix + "default-auto-instrumentation-go-image"
 	AnnotationDefaultAutoInstrumentationApacheHttpd = InstrumentationPrefix + "default-auto-instrumentation-apache-httpd-image"
 	AnnotationDefaultAutoInstrumentationNginx       = InstrumentationPrefix + "default-auto-instrumentation-nginx-image"
+	AnnotationDefaultAutoInstrumentationRuby        = InstrumentationPrefix + "default-auto-instrumentation-ruby-image"
+	AnnotationDefaultAutoInstrumentationPHP         = InstrumentationPrefix + "default-auto-instrumentation-php-image"
 
 	LabelAppName    = "app.kubernetes.io/name"
 	LabelAppVersion = "app.kubernetes.io/version"
 	LabelAppPartOf  = "app.kubernetes.io/part-of"
 
 	LabelTargetAllocator              = "opentelemetry.io/target-allocator"
+	LabelConfigFragment               = "opentelemetry.io/config-fragment"
 	ResourceAttributeAnnotationPrefix = "resource.opentelemetry.io/"
+	// LabelSidecarInjected marks the pods a sidecar collector is injected into, with the namespace and name of the collector.
+	LabelSidecarInjected = "sidecar.opentelemetry.io/injected"
+	// AnnotationSidecarConfigHash records on the pods the hash of the configuration their sidecar collector was injected with.
+	AnnotationSidecarConfigHash = "sidecar.opentelemetry.io/config-hash"
+	// LabelNodePool marks the collector pods running on the nodes of a pool with the name of the pool.
+	LabelNodePool = "opentelemetry.io/node-pool"
 
 	EnvPodName  = "OTEL_RESOURCE_ATTRIBUTES_POD_NAME"
 	EnvPodUID   = "OTEL_RESOURCE_ATTRIBUTES_POD_UID"
@@ -56,6 +72,8 @@ const (
 	FlagNginx       = "enable-nginx-instrumentation"
 	FlagNodeJS      = "enable-nodejs-instrumentation"
 	FlagJava        = "enable-java-instrumentation"
+	FlagRuby        = "enable-ruby-instrumentation"
+	FlagPHP         = "enable-php-instrumentation"
 
 	TACollectorTLSDirPath      = "/tls"
 	TACollectorCAFileName      = "ca.crt"
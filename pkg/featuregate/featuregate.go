@@ -82,6 +82,70 @@ var (
 		featuregate.WithRegisterDescription("enables the operator to default the endpoint for known components"),
 		featuregate.WithRegisterFromVersion("v0.110.0"),
 	)
+	// EnableProbeFallback is the feature gate that enables TCP probes on an OTLP receiver port for collectors without
+	// a health extension.
+	EnableProbeFallback = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.probes.fallback",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables TCP probes on an OTLP receiver port for collectors without a health extension"),
+		featuregate.WithRegisterFromVersion("v0.117.0"),
+	)
+	// EnableHealthCheckInjection is the feature gate that enables the injection of a health_check extension into the
+	// configuration of collectors without a health extension.
+	EnableHealthCheckInjection = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.healthcheck.injection",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables the injection of a health_check extension into collector configurations without a health extension"),
+		featuregate.WithRegisterFromVersion("v0.117.0"),
+	)
+	// EnableInstrumentationStatus is the feature gate that enables the reporting of the workloads injected from an
+	// Instrumentation in its status. The operator then watches the injected pods.
+	EnableInstrumentationStatus = featuregate.GlobalRegistry().MustRegister(
+		"operator.instrumentation.status",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables the reporting of injected workloads in the Instrumentation status"),
+		featuregate.WithRegisterFromVersion("v0.117.0"),
+	)
+	// EnableWorkloadInstrumentation is the feature gate that enables the injection of the auto-instrumentation into the
+	// pod templates of Deployments, StatefulSets and DaemonSets, for clusters where the pod mutation webhook can't be used.
+	EnableWorkloadInstrumentation = featuregate.GlobalRegistry().MustRegister(
+		"operator.instrumentation.workloads",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables the injection of the auto-instrumentation into the pod templates of workloads"),
+		featuregate.WithRegisterFromVersion("v0.117.0"),
+	)
+	// EnableSidecarConfigMap is the feature gate that makes the sidecar collectors read their configuration from a
+	// ConfigMap updated in place, instead of an env var only updated when the pods are recreated.
+	EnableSidecarConfigMap = featuregate.GlobalRegistry().MustRegister(
+		"operator.sidecarcollector.configmap",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables the sidecar collectors to read their configuration from a ConfigMap updated in place"),
+		featuregate.WithRegisterFromVersion("v0.117.0"),
+	)
+	// EnableSidecarResourceRecommendations is the feature gate that enables the recommendation of the sidecar collector
+	// resources from the usage reported by the metrics.k8s.io API.
+	EnableSidecarResourceRecommendations = featuregate.GlobalRegistry().MustRegister(
+		"operator.sidecarcollector.resourcerecommendations",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables the recommendation of the sidecar collector resources from their observed usage"),
+		featuregate.WithRegisterFromVersion("v0.117.0"),
+	)
+	// EnableCollectorScaling is the feature gate that enables the scheduled and idle scaling of the collectors with
+	// spec.scaling. The operator then reads the pods of the collectors to scrape their telemetry.
+	EnableCollectorScaling = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.scaling",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables the scheduled and idle scaling of the collectors with spec.scaling"),
+		featuregate.WithRegisterFromVersion("v0.117.0"),
+	)
+	// EnableCollectorRollout is the feature gate that enables the progressive rollout of the configuration changes of
+	// the collectors with spec.rollout. The operator then reads the pods of the collectors to scrape their telemetry.
+	EnableCollectorRollout = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.rollout",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables the progressive rollout of the configuration of the collectors with spec.rollout"),
+		featuregate.WithRegisterFromVersion("v0.117.0"),
+	)
 )
 
 // Flags creates a new FlagSet that represents the available featuregate flags using the supplied featuregate registry.
@@ -43,6 +43,16 @@ const (
 	annotationInjectApacheHttpdContainersName = "instrumentation.opentelemetry.io/apache-httpd-container-names"
 	annotationInjectNginx                     = "instrumentation.opentelemetry.io/inject-nginx"
 	annotationInjectNginxContainersName       = "instrumentation.opentelemetry.io/inject-nginx-container-names"
+	annotationInjectRuby                      = "instrumentation.opentelemetry.io/inject-ruby"
+	annotationInjectRubyContainersName        = "instrumentation.opentelemetry.io/ruby-container-names"
+	annotationInjectPHP                       = "instrumentation.opentelemetry.io/inject-php"
+	annotationInjectPHPContainersName         = "instrumentation.opentelemetry.io/php-container-names"
+	annotationPHPVersion                      = "instrumentation.opentelemetry.io/otel-php-version"
+	// annotationInjectAuto indicates whether the language of the containers should be guessed to inject the matching
+	// auto-instrumentation. Possible values are "true", "false" or "<Instrumentation>" name.
+	annotationInjectAuto = "instrumentation.opentelemetry.io/inject-auto"
+	// annotationGuessedLanguages records the languages guessed for inject-auto, as a list of <container>=<language>.
+	annotationGuessedLanguages = "instrumentation.opentelemetry.io/guessed-languages"
 )
 
 // annotationValue returns the effective annotationInjectJava value, based on the annotations from the pod and namespace.
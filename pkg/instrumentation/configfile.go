@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation/sdkconfig"
+)
+
+// configureConfigFile mounts the ConfigMap with the declarative configuration file of the Instrumentation and points
+// the SDK to the file of its language. The ConfigMap only exists in the namespace of the Instrumentation, the pods in
+// other namespaces are only configured through env vars.
+func configureConfigFile(otelinst v1alpha1.Instrumentation, lang v1alpha1.InstrumentationLanguage, ns corev1.Namespace, pod *corev1.Pod, container *corev1.Container) {
+	if !otelinst.Spec.ConfigFile.Enabled || otelinst.Namespace != ns.Name {
+		return
+	}
+	if getIndexOfEnv(container.Env, sdkconfig.EnvConfigFile) != -1 {
+		return
+	}
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  sdkconfig.EnvConfigFile,
+		Value: fmt.Sprintf("%s/%s", sdkconfig.MountPath, sdkconfig.FileNameFor(otelinst, lang)),
+	})
+
+	addVolume := true
+	for _, vol := range pod.Spec.Volumes {
+		if vol.Name == sdkconfig.VolumeName {
+			addVolume = false
+		}
+	}
+	if addVolume {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: sdkconfig.VolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: sdkconfig.ConfigMapName(otelinst),
+					},
+				},
+			}})
+	}
+	addVolumeMount := true
+	for _, vol := range container.VolumeMounts {
+		if vol.Name == sdkconfig.VolumeName {
+			addVolumeMount = false
+		}
+	}
+	if addVolumeMount {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      sdkconfig.VolumeName,
+			MountPath: sdkconfig.MountPath,
+			ReadOnly:  true,
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestConfigFile(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-inst"},
+		Spec: v1alpha1.InstrumentationSpec{
+			ConfigFile: v1alpha1.ConfigFile{Enabled: true},
+		},
+	}
+	tests := []struct {
+		name     string
+		inst     v1alpha1.Instrumentation
+		lang     v1alpha1.InstrumentationLanguage
+		ns       string
+		env      []corev1.EnvVar
+		expected corev1.Pod
+	}{
+		{
+			name: "config file mounted",
+			inst: inst,
+			ns:   "my-ns",
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "otel-auto-sdk-config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "my-inst-sdk-config"},
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Env: []corev1.EnvVar{
+								{
+									Name:  "OTEL_EXPERIMENTAL_CONFIG_FILE",
+									Value: "/otel-auto-instrumentation-sdk-config/sdk-config.yaml",
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "otel-auto-sdk-config",
+									ReadOnly:  true,
+									MountPath: "/otel-auto-instrumentation-sdk-config",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "language with its own config file",
+			inst: v1alpha1.Instrumentation{
+				ObjectMeta: inst.ObjectMeta,
+				Spec: v1alpha1.InstrumentationSpec{
+					ConfigFile: v1alpha1.ConfigFile{Enabled: true},
+					Python: v1alpha1.Python{
+						Env: []corev1.EnvVar{{Name: "OTEL_LOGS_EXPORTER", Value: "none"}},
+					},
+				},
+			},
+			lang: v1alpha1.LanguagePython,
+			ns:   "my-ns",
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "otel-auto-sdk-config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "my-inst-sdk-config"},
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Env: []corev1.EnvVar{
+								{
+									Name:  "OTEL_EXPERIMENTAL_CONFIG_FILE",
+									Value: "/otel-auto-instrumentation-sdk-config/sdk-config-python.yaml",
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "otel-auto-sdk-config",
+									ReadOnly:  true,
+									MountPath: "/otel-auto-instrumentation-sdk-config",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "config file not enabled",
+			inst: v1alpha1.Instrumentation{ObjectMeta: inst.ObjectMeta},
+			ns:   "my-ns",
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{}},
+				},
+			},
+		},
+		{
+			name: "pod in another namespace",
+			inst: inst,
+			ns:   "other-ns",
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{}},
+				},
+			},
+		},
+		{
+			name: "config file set by the container",
+			inst: inst,
+			ns:   "my-ns",
+			env:  []corev1.EnvVar{{Name: "OTEL_EXPERIMENTAL_CONFIG_FILE", Value: "/app/otel.yaml"}},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Env: []corev1.EnvVar{{Name: "OTEL_EXPERIMENTAL_CONFIG_FILE", Value: "/app/otel.yaml"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Env: test.env}},
+				},
+			}
+			configureConfigFile(test.inst, test.lang, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: test.ns}}, &pod, &pod.Spec.Containers[0])
+			assert.Equal(t, test.expected, pod)
+		})
+	}
+}
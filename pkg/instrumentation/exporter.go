@@ -17,6 +17,8 @@ package instrumentation
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -25,15 +27,20 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 )
 
-func configureExporter(exporter v1alpha1.Exporter, pod *corev1.Pod, container *corev1.Container) {
-	if exporter.Endpoint != "" {
-		if getIndexOfEnv(container.Env, constants.EnvOTELExporterOTLPEndpoint) == -1 {
-			container.Env = append(container.Env, corev1.EnvVar{
-				Name:  constants.EnvOTELExporterOTLPEndpoint,
-				Value: exporter.Endpoint,
-			})
-		}
+// configureExporter sets the exporter settings of the Instrumentation on the container. The headers sourced from secrets
+// are only set when the secrets are available, i.e. when the pod is in the namespace of the Instrumentation.
+func configureExporter(exporter v1alpha1.Exporter, secretsAvailable bool, pod *corev1.Pod, container *corev1.Container) {
+	setEnvIfAbsent(container, constants.EnvOTELExporterOTLPEndpoint, exporter.Endpoint)
+	setEnvIfAbsent(container, constants.EnvOTELExporterOTLPTracesEndpoint, exporter.TracesEndpoint)
+	setEnvIfAbsent(container, constants.EnvOTELExporterOTLPMetricsEndpoint, exporter.MetricsEndpoint)
+	setEnvIfAbsent(container, constants.EnvOTELExporterOTLPLogsEndpoint, exporter.LogsEndpoint)
+	setEnvIfAbsent(container, constants.EnvOTELExporterOTLPProtocol, string(exporter.Protocol))
+	setEnvIfAbsent(container, constants.EnvOTELExporterOTLPCompression, string(exporter.Compression))
+	if exporter.Timeout != nil {
+		setEnvIfAbsent(container, constants.EnvOTELExporterOTLPTimeout, strconv.FormatInt(exporter.Timeout.Milliseconds(), 10))
 	}
+	configureExporterHeaders(exporter.Headers, secretsAvailable, container)
+
 	if exporter.TLS == nil {
 		return
 	}
@@ -148,3 +155,73 @@ func configureExporter(exporter v1alpha1.Exporter, pod *corev1.Pod, container *c
 		}
 	}
 }
+
+// configureExporterHeaders sets the headers in the OTEL_EXPORTER_OTLP_HEADERS env var. The values sourced from secrets
+// are set in their own env vars first, and referenced from OTEL_EXPORTER_OTLP_HEADERS as dependent env vars. They're
+// skipped when the secrets aren't available. The other values are percent-encoded like the W3C baggage values.
+func configureExporterHeaders(headers []v1alpha1.ExporterHeader, secretsAvailable bool, container *corev1.Container) {
+	if len(headers) == 0 || getIndexOfEnv(container.Env, constants.EnvOTELExporterOTLPHeaders) != -1 {
+		return
+	}
+	values := make([]string, 0, len(headers))
+	for _, header := range headers {
+		if header.SecretKeyRef == nil {
+			values = append(values, fmt.Sprintf("%s=%s", header.Name, encodeHeaderValue(header.Value)))
+			continue
+		}
+		if !secretsAvailable {
+			continue
+		}
+		envName := header.EnvName()
+		if getIndexOfEnv(container.Env, envName) == -1 {
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name: envName,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: header.SecretKeyRef.DeepCopy(),
+				},
+			})
+		}
+		values = append(values, fmt.Sprintf("%s=$(%s)", header.Name, envName))
+	}
+	if len(values) == 0 {
+		return
+	}
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  constants.EnvOTELExporterOTLPHeaders,
+		Value: strings.Join(values, ","),
+	})
+}
+
+// encodeHeaderValue percent-encodes the characters of the value that aren't W3C baggage octets, along with '=' and
+// '+' which some SDKs split on or decode as a space.
+func encodeHeaderValue(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c < 0x21 || c > 0x7e || strings.IndexByte("\",;=\\%+", c) != -1 {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func hasSecretHeaders(exporter v1alpha1.Exporter) bool {
+	for _, header := range exporter.Headers {
+		if header.SecretKeyRef != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func setEnvIfAbsent(container *corev1.Container, name, value string) {
+	if value == "" || getIndexOfEnv(container.Env, name) != -1 {
+		return
+	}
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  name,
+		Value: value,
+	})
+}
@@ -16,9 +16,11 @@ package instrumentation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 )
@@ -202,8 +204,105 @@ func TestExporter(t *testing.T) {
 					},
 				},
 			}
-			configureExporter(test.exporter, &pod, &pod.Spec.Containers[0])
+			configureExporter(test.exporter, true, &pod, &pod.Spec.Containers[0])
 			assert.Equal(t, test.expected, pod)
 		})
 	}
 }
+
+func TestExporterSettings(t *testing.T) {
+	exporter := v1alpha1.Exporter{
+		Endpoint:        "http://collector:4317",
+		TracesEndpoint:  "http://traces:4317",
+		MetricsEndpoint: "http://metrics:4317",
+		LogsEndpoint:    "http://logs:4317",
+		Protocol:        v1alpha1.ExporterProtocolGRPC,
+		Compression:     v1alpha1.ExporterCompressionGzip,
+		Timeout:         &metav1.Duration{Duration: 5 * time.Second},
+		Headers: []v1alpha1.ExporterHeader{
+			{Name: "x-tenant", Value: "shop"},
+			{
+				Name: "Authorization",
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "otlp-auth"},
+					Key:                  "token",
+				},
+			},
+		},
+	}
+	container := corev1.Container{
+		Env: []corev1.EnvVar{{Name: "OTEL_EXPORTER_OTLP_COMPRESSION", Value: "none"}},
+	}
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{container}}}
+
+	configureExporter(exporter, true, &pod, &pod.Spec.Containers[0])
+
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "OTEL_EXPORTER_OTLP_COMPRESSION", Value: "none"},
+		{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://collector:4317"},
+		{Name: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", Value: "http://traces:4317"},
+		{Name: "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", Value: "http://metrics:4317"},
+		{Name: "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", Value: "http://logs:4317"},
+		{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "grpc"},
+		{Name: "OTEL_EXPORTER_OTLP_TIMEOUT", Value: "5000"},
+		{
+			Name: "OTEL_EXPORTER_OTLP_HEADER_AUTHORIZATION",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "otlp-auth"},
+					Key:                  "token",
+				},
+			},
+		},
+		{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "x-tenant=shop,Authorization=$(OTEL_EXPORTER_OTLP_HEADER_AUTHORIZATION)"},
+	}, pod.Spec.Containers[0].Env)
+}
+
+func TestExporterHeadersSetByContainer(t *testing.T) {
+	exporter := v1alpha1.Exporter{
+		Headers: []v1alpha1.ExporterHeader{{Name: "x-tenant", Value: "shop"}},
+	}
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Env: []corev1.EnvVar{{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "x-tenant=other"}},
+	}}}}
+
+	configureExporter(exporter, true, &pod, &pod.Spec.Containers[0])
+
+	assert.Equal(t, []corev1.EnvVar{{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "x-tenant=other"}}, pod.Spec.Containers[0].Env)
+}
+
+func TestExporterHeadersEncoded(t *testing.T) {
+	exporter := v1alpha1.Exporter{
+		Headers: []v1alpha1.ExporterHeader{
+			{Name: "x-tenant", Value: "shop, eu=1"},
+			{Name: "x-key", Value: "a+b/c%é"},
+		},
+	}
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{}}}}
+
+	configureExporter(exporter, true, &pod, &pod.Spec.Containers[0])
+
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "x-tenant=shop%2C%20eu%3D1,x-key=a%2Bb/c%25%C3%A9"},
+	}, pod.Spec.Containers[0].Env)
+}
+
+func TestExporterSecretHeadersFromOtherNamespace(t *testing.T) {
+	exporter := v1alpha1.Exporter{
+		Headers: []v1alpha1.ExporterHeader{
+			{Name: "x-tenant", Value: "shop"},
+			{
+				Name: "Authorization",
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "otlp-auth"},
+					Key:                  "token",
+				},
+			},
+		},
+	}
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{}}}}
+
+	configureExporter(exporter, false, &pod, &pod.Spec.Containers[0])
+
+	assert.Equal(t, []corev1.EnvVar{{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "x-tenant=shop"}}, pod.Spec.Containers[0].Env)
+}
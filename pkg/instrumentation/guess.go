@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+var (
+	// commandLanguages maps executables found in the command or arguments of a container to their language.
+	commandLanguages = map[string]v1alpha1.InstrumentationLanguage{
+		"java":      v1alpha1.LanguageJava,
+		"node":      v1alpha1.LanguageNodeJS,
+		"nodejs":    v1alpha1.LanguageNodeJS,
+		"npm":       v1alpha1.LanguageNodeJS,
+		"npx":       v1alpha1.LanguageNodeJS,
+		"yarn":      v1alpha1.LanguageNodeJS,
+		"python":    v1alpha1.LanguagePython,
+		"python3":   v1alpha1.LanguagePython,
+		"gunicorn":  v1alpha1.LanguagePython,
+		"uvicorn":   v1alpha1.LanguagePython,
+		"dotnet":    v1alpha1.LanguageDotNet,
+		"nginx":     v1alpha1.LanguageNginx,
+		"httpd":     v1alpha1.LanguageApacheHttpd,
+		"apachectl": v1alpha1.LanguageApacheHttpd,
+		"apache2":   v1alpha1.LanguageApacheHttpd,
+		"ruby":      v1alpha1.LanguageRuby,
+		"rails":     v1alpha1.LanguageRuby,
+		"puma":      v1alpha1.LanguageRuby,
+		"unicorn":   v1alpha1.LanguageRuby,
+		"rackup":    v1alpha1.LanguageRuby,
+		"php":       v1alpha1.LanguagePHP,
+		"php-fpm":   v1alpha1.LanguagePHP,
+	}
+
+	// envLanguages maps well-known environment variables, mostly set by the official base images, to their language.
+	// The variables the operator sets when injecting an auto-instrumentation, e.g. JAVA_TOOL_OPTIONS or PYTHONPATH,
+	// aren't listed: an instrumented pod mutated again would be guessed from its own instrumentation.
+	envLanguages = map[string]v1alpha1.InstrumentationLanguage{
+		"JAVA_HOME":                   v1alpha1.LanguageJava,
+		"JAVA_VERSION":                v1alpha1.LanguageJava,
+		"JDK_JAVA_OPTIONS":            v1alpha1.LanguageJava,
+		"NODE_VERSION":                v1alpha1.LanguageNodeJS,
+		"PYTHON_VERSION":              v1alpha1.LanguagePython,
+		"DOTNET_VERSION":              v1alpha1.LanguageDotNet,
+		"ASPNET_VERSION":              v1alpha1.LanguageDotNet,
+		"ASPNETCORE_URLS":             v1alpha1.LanguageDotNet,
+		"DOTNET_RUNNING_IN_CONTAINER": v1alpha1.LanguageDotNet,
+		"HTTPD_VERSION":               v1alpha1.LanguageApacheHttpd,
+		"RUBY_VERSION":                v1alpha1.LanguageRuby,
+		"GEM_HOME":                    v1alpha1.LanguageRuby,
+		"PHP_VERSION":                 v1alpha1.LanguagePHP,
+		"PHP_INI_DIR":                 v1alpha1.LanguagePHP,
+	}
+
+	// imageTokens maps the tokens of the repository name of an image, e.g. eclipse and temurin in eclipse-temurin, to
+	// their language, in order of precedence. A token matches when it's equal to the entry, optionally followed by a
+	// version, e.g. python3 or openjdk17.
+	imageTokens = []struct {
+		token    string
+		language v1alpha1.InstrumentationLanguage
+	}{
+		{"openjdk", v1alpha1.LanguageJava},
+		{"temurin", v1alpha1.LanguageJava},
+		{"corretto", v1alpha1.LanguageJava},
+		{"amazoncorretto", v1alpha1.LanguageJava},
+		{"jdk", v1alpha1.LanguageJava},
+		{"jre", v1alpha1.LanguageJava},
+		{"java", v1alpha1.LanguageJava},
+		{"node", v1alpha1.LanguageNodeJS},
+		{"nodejs", v1alpha1.LanguageNodeJS},
+		{"python", v1alpha1.LanguagePython},
+		{"aspnet", v1alpha1.LanguageDotNet},
+		{"dotnet", v1alpha1.LanguageDotNet},
+		{"nginx", v1alpha1.LanguageNginx},
+		{"httpd", v1alpha1.LanguageApacheHttpd},
+		{"ruby", v1alpha1.LanguageRuby},
+		{"rails", v1alpha1.LanguageRuby},
+		{"php", v1alpha1.LanguagePHP},
+		{"laravel", v1alpha1.LanguagePHP},
+	}
+)
+
+// guessLanguage guesses the language of a container for the inject-auto annotation, or returns an empty string when it
+// can't. It's a heuristic on the container spec only, the image itself isn't inspected: the command and arguments of
+// the container are the most specific hint, followed by its environment variables and the name of its image.
+func guessLanguage(container corev1.Container) v1alpha1.InstrumentationLanguage {
+	if language := languageFromCommand(append(append([]string{}, container.Command...), container.Args...)); language != "" {
+		return language
+	}
+	for _, env := range container.Env {
+		if language, ok := envLanguages[env.Name]; ok {
+			return language
+		}
+	}
+	return languageFromImageName(container.Image)
+}
+
+func languageFromCommand(command []string) v1alpha1.InstrumentationLanguage {
+	for _, arg := range command {
+		// the command may be a shell script, e.g. sh -c "exec java -jar app.jar"
+		for _, field := range strings.Fields(arg) {
+			executable := path.Base(field)
+			if language, ok := commandLanguages[executable]; ok {
+				return language
+			}
+			switch {
+			case strings.HasSuffix(executable, ".jar"):
+				return v1alpha1.LanguageJava
+			case strings.HasSuffix(executable, ".dll"):
+				return v1alpha1.LanguageDotNet
+			case strings.HasPrefix(executable, "python3."):
+				return v1alpha1.LanguagePython
+			case strings.HasPrefix(executable, "php-fpm"), strings.HasPrefix(executable, "php8"):
+				return v1alpha1.LanguagePHP
+			}
+		}
+	}
+	return ""
+}
+
+// languageFromImageName matches the tokens of the last path element of the repository of the image, separated by -, _
+// or ., against imageTokens. Registries, namespaces, tags and digests are ignored.
+func languageFromImageName(image string) v1alpha1.InstrumentationLanguage {
+	repository, _, _ := strings.Cut(image, "@")
+	// the tag follows the last colon, unless it's the port of the registry
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+	tokens := strings.FieldsFunc(path.Base(repository), func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	})
+	for _, it := range imageTokens {
+		for _, token := range tokens {
+			if version, ok := strings.CutPrefix(token, it.token); ok && isVersion(version) {
+				return it.language
+			}
+		}
+	}
+	return ""
+}
+
+// isVersion returns whether s is empty or only made of digits.
+func isVersion(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// guessedLanguage is a language guessed for inject-auto, with the containers it was guessed for.
+type guessedLanguage struct {
+	language   v1alpha1.InstrumentationLanguage
+	iwc        *instrumentationWithContainers
+	containers []string
+}
+
+// guessLanguages guesses the language of the containers of the pod and assigns the Instrumentation to them. The
+// languages requested explicitly by an annotation are left untouched, as are the ones that aren't enabled.
+func (pm *instPodMutator) guessLanguages(logger logr.Logger, insts *languageInstrumentations, inst *v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod) []guessedLanguage {
+	targets := map[v1alpha1.InstrumentationLanguage]struct {
+		iwc     *instrumentationWithContainers
+		enabled bool
+	}{
+		v1alpha1.LanguageJava:        {&insts.Java, pm.config.EnableJavaAutoInstrumentation()},
+		v1alpha1.LanguageNodeJS:      {&insts.NodeJS, pm.config.EnableNodeJSAutoInstrumentation()},
+		v1alpha1.LanguagePython:      {&insts.Python, pm.config.EnablePythonAutoInstrumentation()},
+		v1alpha1.LanguageDotNet:      {&insts.DotNet, pm.config.EnableDotNetAutoInstrumentation()},
+		v1alpha1.LanguageApacheHttpd: {&insts.ApacheHttpd, pm.config.EnableApacheHttpdAutoInstrumentation()},
+		v1alpha1.LanguageNginx:       {&insts.Nginx, pm.config.EnableNginxAutoInstrumentation()},
+		v1alpha1.LanguageRuby:        {&insts.Ruby, pm.config.EnableRubyAutoInstrumentation()},
+		v1alpha1.LanguagePHP:         {&insts.PHP, pm.config.EnablePHPAutoInstrumentation()},
+	}
+
+	containers := pod.Spec.Containers
+	if containersAnnotation := annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectContainerName); containersAnnotation != "" {
+		containers = nil
+		for _, name := range strings.Split(containersAnnotation, ",") {
+			for _, container := range pod.Spec.Containers {
+				if container.Name == name {
+					containers = append(containers, container)
+				}
+			}
+		}
+	}
+
+	var guessed []guessedLanguage
+	indexes := map[v1alpha1.InstrumentationLanguage]int{}
+	for _, container := range containers {
+		language := guessLanguage(container)
+		if language == "" {
+			logger.V(1).Info("could not guess the language of the container", "container", container.Name)
+			continue
+		}
+		if i, ok := indexes[language]; ok {
+			guessed[i].containers = append(guessed[i].containers, container.Name)
+			continue
+		}
+		target := targets[language]
+		if target.iwc.Instrumentation != nil {
+			logger.V(1).Info("language requested by annotation, skipping the guessed language", "container", container.Name, "language", language)
+			continue
+		}
+		if !target.enabled {
+			logger.Error(nil, fmt.Sprintf("support for %s auto instrumentation is not enabled", language), "container", container.Name)
+			pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", fmt.Sprintf("support for %s auto instrumentation guessed for container %s is not enabled", language, container.Name))
+			continue
+		}
+		target.iwc.Instrumentation = inst
+		indexes[language] = len(guessed)
+		guessed = append(guessed, guessedLanguage{language: language, iwc: target.iwc, containers: []string{container.Name}})
+	}
+
+	if len(guessed) == 0 {
+		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationLanguageNotGuessed", "could not guess the language of any container")
+		return nil
+	}
+	value := guessedLanguagesValue(guessed)
+	logger.V(1).Info("guessed languages for auto-instrumentation", "languages", value)
+	pm.Recorder.Event(pod.DeepCopy(), "Normal", "InstrumentationLanguageGuessed", fmt.Sprintf("guessed languages: %s", value))
+	return guessed
+}
+
+// guessedLanguagesValue returns the value of the guessed-languages annotation, e.g. app=java,worker=python.
+func guessedLanguagesValue(guessed []guessedLanguage) string {
+	var pairs []string
+	for _, d := range guessed {
+		for _, container := range d.containers {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", container, d.language))
+		}
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestGuessLanguage(t *testing.T) {
+	for _, tt := range []struct {
+		desc      string
+		container corev1.Container
+		expected  v1alpha1.InstrumentationLanguage
+	}{
+		{
+			desc:      "java jar",
+			container: corev1.Container{Command: []string{"java"}, Args: []string{"-jar", "/app/app.jar"}},
+			expected:  v1alpha1.LanguageJava,
+		},
+		{
+			desc:      "shell script",
+			container: corev1.Container{Command: []string{"/bin/sh", "-c", "exec /usr/local/bin/node server.js"}},
+			expected:  v1alpha1.LanguageNodeJS,
+		},
+		{
+			desc:      "versioned python",
+			container: corev1.Container{Command: []string{"python3.12", "-m", "app"}},
+			expected:  v1alpha1.LanguagePython,
+		},
+		{
+			desc:      "dotnet dll",
+			container: corev1.Container{Args: []string{"App.dll"}},
+			expected:  v1alpha1.LanguageDotNet,
+		},
+		{
+			desc: "command wins over env and image",
+			container: corev1.Container{
+				Image:   "python:3.12",
+				Command: []string{"gunicorn", "app:app"},
+				Env:     []corev1.EnvVar{{Name: "JAVA_HOME", Value: "/opt/java"}},
+			},
+			expected: v1alpha1.LanguagePython,
+		},
+		{
+			desc: "env",
+			container: corev1.Container{
+				Image: "registry.example.com/shop/cart:1.0",
+				Env:   []corev1.EnvVar{{Name: "ASPNETCORE_URLS", Value: "http://+:8080"}},
+			},
+			expected: v1alpha1.LanguageDotNet,
+		},
+		{
+			desc:      "rails",
+			container: corev1.Container{Command: []string{"bundle", "exec", "rails", "server"}},
+			expected:  v1alpha1.LanguageRuby,
+		},
+		{
+			desc:      "versioned php-fpm",
+			container: corev1.Container{Command: []string{"php-fpm8.2", "-F"}},
+			expected:  v1alpha1.LanguagePHP,
+		},
+		{
+			desc: "php env",
+			container: corev1.Container{
+				Image: "registry.example.com/shop/cart:1.0",
+				Env:   []corev1.EnvVar{{Name: "PHP_INI_DIR", Value: "/usr/local/etc/php"}},
+			},
+			expected: v1alpha1.LanguagePHP,
+		},
+		{
+			desc: "env set by the operator",
+			container: corev1.Container{
+				Image: "registry.example.com/shop/cart:1.0",
+				Env:   []corev1.EnvVar{{Name: "JAVA_TOOL_OPTIONS", Value: " -javaagent:/otel-auto-instrumentation-java/javaagent.jar"}},
+			},
+			expected: "",
+		},
+		{
+			desc:      "image",
+			container: corev1.Container{Image: "eclipse-temurin:21-jre"},
+			expected:  v1alpha1.LanguageJava,
+		},
+		{
+			desc:      "image with registry port and digest",
+			container: corev1.Container{Image: "registry.example.com:5000/library/nginx:1.27@sha256:0123"},
+			expected:  v1alpha1.LanguageNginx,
+		},
+		{
+			desc:      "versioned image token",
+			container: corev1.Container{Image: "registry.example.com/base/python3.12-slim:latest"},
+			expected:  v1alpha1.LanguagePython,
+		},
+		{
+			desc:      "image tokens are anchored",
+			container: corev1.Container{Image: "registry.example.com/infra/nodeport-proxy:1.0"},
+			expected:  "",
+		},
+		{
+			desc:      "unknown",
+			container: corev1.Container{Image: "registry.example.com:5000/shop/cart:node"},
+			expected:  "",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, guessLanguage(tt.container))
+		})
+	}
+}
+
+func TestGuessLanguages(t *testing.T) {
+	inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "auto"}}
+	explicit := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "explicit"}}
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Image: "eclipse-temurin:21"},
+				{Name: "worker", Image: "python:3.12"},
+				{Name: "batch", Command: []string{"java", "-jar", "batch.jar"}},
+				{Name: "proxy", Image: "nginx:1.27"},
+				{Name: "unknown", Image: "busybox"},
+			},
+		},
+	}
+	pm := &instPodMutator{
+		Recorder: record.NewFakeRecorder(10),
+		config:   config.New(config.WithEnableNginxInstrumentation(false)),
+	}
+	insts := languageInstrumentations{}
+	insts.Python.Instrumentation = explicit
+
+	guessed := pm.guessLanguages(logr.Discard(), &insts, inst, corev1.Namespace{}, pod)
+
+	assert.Equal(t, "app=java,batch=java", guessedLanguagesValue(guessed))
+	assert.Equal(t, inst, insts.Java.Instrumentation)
+	assert.Equal(t, []string{"app", "batch"}, guessed[0].containers)
+	assert.Equal(t, explicit, insts.Python.Instrumentation)
+	assert.Nil(t, insts.Nginx.Instrumentation)
+}
+
+func TestGuessLanguagesRestrictedContainers(t *testing.T) {
+	inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "auto"}}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{annotationInjectContainerName: "worker"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Image: "eclipse-temurin:21"},
+				{Name: "worker", Image: "python:3.12"},
+			},
+		},
+	}
+	pm := &instPodMutator{
+		Recorder: record.NewFakeRecorder(10),
+		config:   config.New(config.WithEnablePythonInstrumentation(true)),
+	}
+	insts := languageInstrumentations{}
+
+	guessed := pm.guessLanguages(logr.Discard(), &insts, inst, corev1.Namespace{}, pod)
+
+	assert.Equal(t, "worker=python", guessedLanguagesValue(guessed))
+	assert.Nil(t, insts.Java.Instrumentation)
+	assert.Equal(t, inst, insts.Python.Instrumentation)
+}
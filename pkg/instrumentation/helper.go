@@ -50,6 +50,8 @@ func isAutoInstrumentationInjected(pod corev1.Pod) bool {
 			javaInitContainerName,
 			nodejsInitContainerName,
 			pythonInitContainerName,
+			rubyInitContainerName,
+			phpInitContainerName,
 			apacheAgentInitContainerName,
 			apacheAgentCloneContainerName,
 		}, cont.Name) {
@@ -182,3 +184,38 @@ func setContainersFromAnnotation(inst *instrumentationWithContainers, annotation
 	inst.Containers = append(inst.Containers, languageContainers...)
 	return nil
 }
+
+// containerInstrumentation is a container mapped to an Instrumentation in an inject annotation.
+type containerInstrumentation struct {
+	container       string
+	instrumentation string
+}
+
+// isContainerInstrumentationsValue returns whether the value of an inject annotation maps containers to
+// Instrumentations, e.g. "app=my-instrumentation,worker=other-namespace/other-instrumentation".
+func isContainerInstrumentationsValue(value string) bool {
+	return strings.Contains(value, "=")
+}
+
+// parseContainerInstrumentations parses the containers, and the Instrumentation they are mapped to, from the value of
+// an inject annotation. The Instrumentation is either "true", or its name optionally prefixed by its namespace.
+func parseContainerInstrumentations(value string) ([]containerInstrumentation, error) {
+	var containerInsts []containerInstrumentation
+	var containers []string
+	for _, entry := range strings.Split(value, ",") {
+		container, inst, _ := strings.Cut(entry, "=")
+		if container == "" || inst == "" || strings.EqualFold(inst, "false") {
+			return nil, fmt.Errorf("not valid container instrumentation %q in the instrumentation annotation %s", entry, value)
+		}
+		if err := isValidContainersAnnotation(container); err != nil {
+			return nil, err
+		}
+		containerInsts = append(containerInsts, containerInstrumentation{container: container, instrumentation: inst})
+		containers = append(containers, container)
+	}
+
+	if err := findDuplicatedContainers(containers); err != nil {
+		return nil, err
+	}
+	return containerInsts, nil
+}
@@ -326,3 +326,50 @@ func TestInstrWithoutContainers(t *testing.T) {
 		})
 	}
 }
+
+func TestParseContainerInstrumentations(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []containerInstrumentation
+		err      error
+	}{
+		{
+			name:  "Containers mapped to instrumentations",
+			value: "app=my-instrumentation,worker=other-namespace/other-instrumentation,proxy=true",
+			expected: []containerInstrumentation{
+				{container: "app", instrumentation: "my-instrumentation"},
+				{container: "worker", instrumentation: "other-namespace/other-instrumentation"},
+				{container: "proxy", instrumentation: "true"},
+			},
+		},
+		{
+			name:  "Duplicates in containers",
+			value: "app=my-instrumentation,app=other-instrumentation",
+			err:   fmt.Errorf("duplicated container names detected: [app]"),
+		},
+		{
+			name:  "Container without instrumentation",
+			value: "app=my-instrumentation,worker",
+			err:   fmt.Errorf("not valid container instrumentation \"worker\" in the instrumentation annotation app=my-instrumentation,worker"),
+		},
+		{
+			name:  "Container mapped to false",
+			value: "app=false",
+			err:   fmt.Errorf("not valid container instrumentation \"app=false\" in the instrumentation annotation app=false"),
+		},
+		{
+			name:  "Not valid container name",
+			value: "app_1=my-instrumentation",
+			err:   fmt.Errorf("not valid characters included in the instrumentation container annotation app_1"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			containerInsts, err := parseContainerInstrumentations(test.value)
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.expected, containerInsts)
+		})
+	}
+}
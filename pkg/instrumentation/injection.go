@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	// LabelInjected marks the pods with an injected auto-instrumentation, so that only those need to be watched.
+	LabelInjected = "instrumentation.opentelemetry.io/injected"
+	// AnnotationInjections records the auto-instrumentations injected into a pod, as a JSON list of Injection.
+	AnnotationInjections = "instrumentation.opentelemetry.io/injections"
+)
+
+// Injection records an auto-instrumentation injected into a pod.
+type Injection struct {
+	// Language of the auto-instrumentation.
+	Language v1alpha1.InstrumentationLanguage `json:"language"`
+	// Instrumentation is the namespaced name of the Instrumentation the auto-instrumentation was injected from.
+	Instrumentation string `json:"instrumentation"`
+	// Containers lists the injected containers.
+	Containers []string `json:"containers"`
+	// Image is the agent image injected into the pod, if any.
+	Image string `json:"image,omitempty"`
+}
+
+// GetInjections returns the auto-instrumentations recorded on the pod.
+func GetInjections(pod corev1.Pod) ([]Injection, error) {
+	value, ok := pod.Annotations[AnnotationInjections]
+	if !ok {
+		return nil, nil
+	}
+	var injections []Injection
+	if err := json.Unmarshal([]byte(value), &injections); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s annotation: %w", AnnotationInjections, err)
+	}
+	return injections, nil
+}
+
+// AgentImage returns the agent image of the given language in the spec of the Instrumentation.
+func AgentImage(inst v1alpha1.Instrumentation, language v1alpha1.InstrumentationLanguage) string {
+	switch language {
+	case v1alpha1.LanguageJava:
+		return inst.Spec.Java.Image
+	case v1alpha1.LanguageNodeJS:
+		return inst.Spec.NodeJS.Image
+	case v1alpha1.LanguagePython:
+		return inst.Spec.Python.Image
+	case v1alpha1.LanguageDotNet:
+		return inst.Spec.DotNet.Image
+	case v1alpha1.LanguageGo:
+		return inst.Spec.Go.Image
+	case v1alpha1.LanguageApacheHttpd:
+		return inst.Spec.ApacheHttpd.Image
+	case v1alpha1.LanguageNginx:
+		return inst.Spec.Nginx.Image
+	case v1alpha1.LanguageRuby:
+		return inst.Spec.Ruby.Image
+	case v1alpha1.LanguagePHP:
+		return inst.Spec.PHP.Image
+	default:
+		return ""
+	}
+}
+
+// recordInjections records the auto-instrumentations that were injected into the pod in its annotations, and labels
+// it accordingly. The image of an injection is the one of the init container, or sidecar, that was actually added.
+func recordInjections(insts languageInstrumentations, pod corev1.Pod) (corev1.Pod, error) {
+	languages := []struct {
+		language  v1alpha1.InstrumentationLanguage
+		iwc       instrumentationWithContainers
+		container string
+	}{
+		{v1alpha1.LanguageJava, insts.Java, javaInitContainerName},
+		{v1alpha1.LanguageNodeJS, insts.NodeJS, nodejsInitContainerName},
+		{v1alpha1.LanguagePython, insts.Python, pythonInitContainerName},
+		{v1alpha1.LanguageDotNet, insts.DotNet, dotnetInitContainerName},
+		{v1alpha1.LanguageGo, insts.Go, sideCarName},
+		{v1alpha1.LanguageApacheHttpd, insts.ApacheHttpd, apacheAgentInitContainerName},
+		{v1alpha1.LanguageNginx, insts.Nginx, nginxAgentInitContainerName},
+		{v1alpha1.LanguageRuby, insts.Ruby, rubyInitContainerName},
+		{v1alpha1.LanguagePHP, insts.PHP, phpInitContainerName},
+		{v1alpha1.LanguageSdk, insts.Sdk, ""},
+	}
+
+	var injections []Injection
+	for _, l := range languages {
+		if l.iwc.Instrumentation == nil {
+			continue
+		}
+		var image string
+		if l.container != "" {
+			var found bool
+			if image, found = injectedContainerImage(pod, l.container); !found {
+				// the injection was skipped
+				continue
+			}
+		}
+		containers := l.iwc.Containers
+		if len(containers) == 0 {
+			containers = []string{pod.Spec.Containers[0].Name}
+		}
+		// the containers mapped to different Instrumentations are recorded as one injection per Instrumentation
+		first := len(injections)
+		for _, container := range containers {
+			inst := l.iwc.instrumentationFor(container)
+			name := types.NamespacedName{Namespace: inst.Namespace, Name: inst.Name}.String()
+			if i := indexOfInjection(injections[first:], name); i >= 0 {
+				injections[first+i].Containers = append(injections[first+i].Containers, container)
+				continue
+			}
+			injections = append(injections, Injection{
+				Language:        l.language,
+				Instrumentation: name,
+				Containers:      []string{container},
+				Image:           image,
+			})
+		}
+	}
+	if len(injections) == 0 {
+		return pod, nil
+	}
+
+	value, err := json.Marshal(injections)
+	if err != nil {
+		return pod, err
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[AnnotationInjections] = string(value)
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[LabelInjected] = "true"
+	return pod, nil
+}
+
+func indexOfInjection(injections []Injection, instrumentation string) int {
+	for i, injection := range injections {
+		if injection.Instrumentation == instrumentation {
+			return i
+		}
+	}
+	return -1
+}
+
+func injectedContainerImage(pod corev1.Pod, name string) (string, bool) {
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, container := range containers {
+			if container.Name == name {
+				return container.Image, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestRecordInjections(t *testing.T) {
+	inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Namespace: "observability", Name: "default"}}
+	insts := languageInstrumentations{}
+	insts.Java.Instrumentation = inst
+	insts.Python.Instrumentation = inst
+	insts.Python.Containers = []string{"worker"}
+	insts.NodeJS.Instrumentation = inst
+	insts.Sdk.Instrumentation = inst
+	insts.Sdk.Containers = []string{"proxy"}
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: javaInitContainerName, Image: "java:2.0"},
+				{Name: pythonInitContainerName, Image: "python:0.50"},
+			},
+			Containers: []corev1.Container{{Name: "app"}, {Name: "worker"}, {Name: "proxy"}},
+		},
+	}
+
+	pod, err := recordInjections(insts, pod)
+	require.NoError(t, err)
+
+	assert.Equal(t, "true", pod.Labels[LabelInjected])
+	injections, err := GetInjections(pod)
+	require.NoError(t, err)
+	// the NodeJS injection was skipped, there's no init container for it
+	assert.Equal(t, []Injection{
+		{Language: v1alpha1.LanguageJava, Instrumentation: "observability/default", Containers: []string{"app"}, Image: "java:2.0"},
+		{Language: v1alpha1.LanguagePython, Instrumentation: "observability/default", Containers: []string{"worker"}, Image: "python:0.50"},
+		{Language: v1alpha1.LanguageSdk, Instrumentation: "observability/default", Containers: []string{"proxy"}},
+	}, injections)
+}
+
+func TestRecordInjectionsNothingInjected(t *testing.T) {
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+
+	recorded, err := recordInjections(languageInstrumentations{}, pod)
+	require.NoError(t, err)
+	assert.Equal(t, pod, recorded)
+}
+
+func TestRecordInjectionsContainerInstrumentations(t *testing.T) {
+	inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Namespace: "observability", Name: "default"}}
+	other := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Namespace: "observability", Name: "other"}}
+	insts := languageInstrumentations{}
+	insts.Java.Instrumentation = inst
+	insts.Java.Containers = []string{"app", "worker", "proxy"}
+	insts.Java.ContainerInstrumentations = map[string]*v1alpha1.Instrumentation{"app": inst, "worker": other, "proxy": inst}
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: javaInitContainerName, Image: "java:2.0"}},
+			Containers:     []corev1.Container{{Name: "app"}, {Name: "worker"}, {Name: "proxy"}},
+		},
+	}
+
+	pod, err := recordInjections(insts, pod)
+	require.NoError(t, err)
+
+	injections, err := GetInjections(pod)
+	require.NoError(t, err)
+	assert.Equal(t, []Injection{
+		{Language: v1alpha1.LanguageJava, Instrumentation: "observability/default", Containers: []string{"app", "proxy"}, Image: "java:2.0"},
+		{Language: v1alpha1.LanguageJava, Instrumentation: "observability/other", Containers: []string{"worker"}, Image: "java:2.0"},
+	}, injections)
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	envPHPIniScanDir        = "PHP_INI_SCAN_DIR"
+	envOtelPHPAutoload      = "OTEL_PHP_AUTOLOAD_ENABLED"
+	phpIniScanDir           = "/otel-auto-instrumentation-php/conf.d"
+	phpInstrMountPath       = "/otel-auto-instrumentation-php"
+	phpVolumeName           = volumeName + "-php"
+	phpInitContainerName    = initContainerName + "-php"
+	phpDefaultVersion       = "8.3"
+	phpAutoInstrumentionSrc = "/autoinstrumentation/%s/."
+)
+
+// phpVersions are the PHP versions the extension of the auto-instrumentation image is built for.
+var phpVersions = []string{"8.1", "8.2", "8.3"}
+
+func injectPHPSDK(phpSpec v1alpha1.PHP, pod corev1.Pod, index int, version string) (corev1.Pod, error) {
+	volume := instrVolume(phpSpec.VolumeClaimTemplate, phpVolumeName, phpSpec.VolumeSizeLimit)
+
+	// caller checks if there is at least one container.
+	container := &pod.Spec.Containers[index]
+
+	err := validateContainerEnv(container.Env, envPHPIniScanDir)
+	if err != nil {
+		return pod, err
+	}
+
+	if version == "" {
+		version = phpDefaultVersion
+	}
+	supported := false
+	for _, v := range phpVersions {
+		if v == version {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return pod, fmt.Errorf("provided instrumentation.opentelemetry.io/otel-php-version annotation value '%s' is not supported", version)
+	}
+
+	// inject PHP instrumentation spec env vars.
+	for _, env := range phpSpec.Env {
+		idx := getIndexOfEnv(container.Env, env.Name)
+		if idx == -1 {
+			container.Env = append(container.Env, env)
+		}
+	}
+
+	// A leading empty entry keeps the default scan directory of the PHP image, the ini file of the auto-instrumentation
+	// loads the extension and prepends the autoloader.
+	idx := getIndexOfEnv(container.Env, envPHPIniScanDir)
+	if idx == -1 {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  envPHPIniScanDir,
+			Value: ":" + phpIniScanDir,
+		})
+	} else if idx > -1 {
+		container.Env[idx].Value = fmt.Sprintf("%s:%s", container.Env[idx].Value, phpIniScanDir)
+	}
+
+	idx = getIndexOfEnv(container.Env, envOtelPHPAutoload)
+	if idx == -1 {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  envOtelPHPAutoload,
+			Value: "true",
+		})
+	}
+
+	// Set OTEL_EXPORTER_OTLP_PROTOCOL to http/protobuf if not set by user because it is what our autoinstrumentation supports.
+	idx = getIndexOfEnv(container.Env, envOtelExporterOTLPProtocol)
+	if idx == -1 {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  envOtelExporterOTLPProtocol,
+			Value: "http/protobuf",
+		})
+	}
+
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      volume.Name,
+		MountPath: phpInstrMountPath,
+	})
+
+	// We just inject Volumes and init containers for the first processed container.
+	if isInitContainerMissing(pod, phpInitContainerName) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
+			Name:      phpInitContainerName,
+			Image:     phpSpec.Image,
+			Command:   []string{"cp", "-r", fmt.Sprintf(phpAutoInstrumentionSrc, version), phpInstrMountPath},
+			Resources: phpSpec.Resources,
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      volume.Name,
+				MountPath: phpInstrMountPath,
+			}},
+		})
+	}
+	return pod, nil
+}
+
+// phpImageIncompatible returns whether the image of the application looks like an Alpine (musl) or thread safe (ZTS)
+// PHP image, e.g. php:8.3-zts-alpine, which can't load the extension built for glibc and non thread safe PHP.
+func phpImageIncompatible(image string) bool {
+	reference, _, _ := strings.Cut(image, "@")
+	if i := strings.LastIndex(reference, "/"); i >= 0 {
+		reference = reference[i+1:]
+	}
+	for _, token := range strings.FieldsFunc(reference, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == ':'
+	}) {
+		if strings.HasPrefix(token, "alpine") || token == "zts" {
+			return true
+		}
+	}
+	return false
+}
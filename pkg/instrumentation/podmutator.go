@@ -31,6 +31,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 var (
@@ -47,9 +48,20 @@ type instPodMutator struct {
 }
 
 type instrumentationWithContainers struct {
-	Instrumentation       *v1alpha1.Instrumentation
-	Containers            []string
-	AdditionalAnnotations map[string]string
+	Instrumentation *v1alpha1.Instrumentation
+	Containers      []string
+	// ContainerInstrumentations holds the Instrumentations of the containers listed as <container>=<Instrumentation>
+	// in the inject annotation, the other containers are injected from Instrumentation.
+	ContainerInstrumentations map[string]*v1alpha1.Instrumentation
+	AdditionalAnnotations     map[string]string
+}
+
+// instrumentationFor returns the Instrumentation the container is injected from.
+func (iwc instrumentationWithContainers) instrumentationFor(container string) v1alpha1.Instrumentation {
+	if inst, ok := iwc.ContainerInstrumentations[container]; ok {
+		return *inst
+	}
+	return *iwc.Instrumentation
 }
 
 type languageInstrumentations struct {
@@ -59,6 +71,8 @@ type languageInstrumentations struct {
 	DotNet      instrumentationWithContainers
 	ApacheHttpd instrumentationWithContainers
 	Nginx       instrumentationWithContainers
+	Ruby        instrumentationWithContainers
+	PHP         instrumentationWithContainers
 	Go          instrumentationWithContainers
 	Sdk         instrumentationWithContainers
 }
@@ -119,6 +133,22 @@ func (langInsts languageInstrumentations) areInstrumentedContainersCorrect() (bo
 			instrumentationWithNoContainers = true
 		}
 	}
+	if langInsts.Ruby.Instrumentation != nil {
+		instrWithContainers += isInstrWithContainers(langInsts.Ruby)
+		instrWithoutContainers += isInstrWithoutContainers(langInsts.Ruby)
+		allContainers = append(allContainers, langInsts.Ruby.Containers...)
+		if len(langInsts.Ruby.Containers) == 0 {
+			instrumentationWithNoContainers = true
+		}
+	}
+	if langInsts.PHP.Instrumentation != nil {
+		instrWithContainers += isInstrWithContainers(langInsts.PHP)
+		instrWithoutContainers += isInstrWithoutContainers(langInsts.PHP)
+		allContainers = append(allContainers, langInsts.PHP.Containers...)
+		if len(langInsts.PHP.Containers) == 0 {
+			instrumentationWithNoContainers = true
+		}
+	}
 	if langInsts.Go.Instrumentation != nil {
 		instrWithContainers += isInstrWithContainers(langInsts.Go)
 		instrWithoutContainers += isInstrWithoutContainers(langInsts.Go)
@@ -197,6 +227,12 @@ func (langInsts *languageInstrumentations) setCommonInstrumentedContainers(ns co
 	if langInsts.Nginx.Instrumentation != nil {
 		langInsts.Nginx.Containers = containers
 	}
+	if langInsts.Ruby.Instrumentation != nil {
+		langInsts.Ruby.Containers = containers
+	}
+	if langInsts.PHP.Instrumentation != nil {
+		langInsts.PHP.Containers = containers
+	}
 	if langInsts.Go.Instrumentation != nil {
 		langInsts.Go.Containers = containers
 	}
@@ -239,6 +275,14 @@ func (langInsts *languageInstrumentations) setLanguageSpecificContainers(ns meta
 			iwc:        &langInsts.Nginx,
 			annotation: annotationInjectNginxContainersName,
 		},
+		{
+			iwc:        &langInsts.Ruby,
+			annotation: annotationInjectRubyContainersName,
+		},
+		{
+			iwc:        &langInsts.PHP,
+			annotation: annotationInjectPHPContainersName,
+		},
 		{
 			iwc:        &langInsts.Sdk,
 			annotation: annotationInjectSdkContainersName,
@@ -247,6 +291,10 @@ func (langInsts *languageInstrumentations) setLanguageSpecificContainers(ns meta
 
 	for _, i := range inst {
 		i := i
+		// the containers mapped to Instrumentations in the inject annotation take precedence
+		if i.iwc.ContainerInstrumentations != nil {
+			continue
+		}
 		if err := setContainersFromAnnotation(i.iwc, i.annotation, ns, pod); err != nil {
 			return err
 		}
@@ -270,7 +318,7 @@ func NewMutator(logger logr.Logger, client client.Client, recorder record.EventR
 }
 
 func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (corev1.Pod, error) {
-	logger := pm.Logger.WithValues("namespace", pod.Namespace)
+	logger := podmutation.Logger(ctx, pm.Logger).WithValues("namespace", pod.Namespace)
 	if pod.Name != "" {
 		logger = logger.WithValues("name", pod.Name)
 	} else if pod.GenerateName != "" {
@@ -288,9 +336,16 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 
 	insts := languageInstrumentations{}
 
+	// Instrumentations may select the pod by its labels, the annotations take precedence over them.
+	selected, err := pm.getSelectingInstrumentations(ctx, ns, pod)
+	if err != nil {
+		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		return pod, err
+	}
+
 	// We bail out if any annotation fails to process.
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectJava); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectJava, selected); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -302,7 +357,7 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Java auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNodeJS); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNodeJS, selected); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -314,7 +369,7 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for NodeJS auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectPython); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectPython, selected); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -327,7 +382,7 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Python auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectDotNet); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectDotNet, selected); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -340,7 +395,7 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for .NET auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectGo); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectGo, selected); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -352,7 +407,7 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Go auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectApacheHttpd); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectApacheHttpd, selected); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -364,7 +419,7 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Apache HTTPD auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNginx); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNginx, selected); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -376,19 +431,54 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Nginx auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectSdk); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectRuby, selected); err != nil {
+		// we still allow the pod to be created, but we log a message to the operator's logs
+		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		return pod, err
+	}
+	if pm.config.EnableRubyAutoInstrumentation() || inst == nil {
+		insts.Ruby.Instrumentation = inst
+	} else {
+		logger.Error(nil, "support for Ruby auto instrumentation is not enabled")
+		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Ruby auto instrumentation is not enabled")
+	}
+
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectPHP, selected); err != nil {
+		// we still allow the pod to be created, but we log a message to the operator's logs
+		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		return pod, err
+	}
+	if pm.config.EnablePHPAutoInstrumentation() || inst == nil {
+		insts.PHP.Instrumentation = inst
+		insts.PHP.AdditionalAnnotations = map[string]string{annotationPHPVersion: annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationPHPVersion)}
+	} else {
+		logger.Error(nil, "support for PHP auto instrumentation is not enabled")
+		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for PHP auto instrumentation is not enabled")
+	}
+
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectSdk, selected); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
 	}
 	insts.Sdk.Instrumentation = inst
 
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectAuto, selected); err != nil {
+		// we still allow the pod to be created, but we log a message to the operator's logs
+		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		return pod, err
+	}
+	var guessed []guessedLanguage
+	if inst != nil {
+		guessed = pm.guessLanguages(logger, &insts, inst, ns, pod)
+	}
+
 	if insts.Java.Instrumentation == nil && insts.NodeJS.Instrumentation == nil && insts.Python.Instrumentation == nil &&
 		insts.DotNet.Instrumentation == nil && insts.Go.Instrumentation == nil && insts.ApacheHttpd.Instrumentation == nil &&
-		insts.Nginx.Instrumentation == nil &&
+		insts.Nginx.Instrumentation == nil && insts.Ruby.Instrumentation == nil && insts.PHP.Instrumentation == nil &&
 		insts.Sdk.Instrumentation == nil {
 
-		logger.V(1).Info("annotation not present in deployment, skipping instrumentation injection")
+		logger.V(1).Info("annotation not present in deployment and no Instrumentation selects the pod, skipping instrumentation injection")
 		return pod, nil
 	}
 
@@ -396,6 +486,15 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	if err != nil {
 		return pod, err
 	}
+	// the containers of a guessed language are the ones it was guessed for
+	for _, d := range guessed {
+		d.iwc.Containers = d.containers
+	}
+	// the containers of a language annotated with <container>=<Instrumentation> are the listed ones
+	if err = pm.setContainerInstrumentations(ctx, &insts, ns, pod); err != nil {
+		logger.Error(err, "failed to select the OpenTelemetry Instrumentation instances of the containers")
+		return pod, err
+	}
 
 	if err = pm.validateInstrumentations(ctx, insts, ns.Name); err != nil {
 		logger.Error(err, "failed to validate instrumentations")
@@ -421,17 +520,49 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	// we should inject the instrumentation.
 	modifiedPod := pod
 	modifiedPod = pm.sdkInjector.inject(ctx, insts, ns, modifiedPod, pm.config)
+	if featuregate.EnableInstrumentationStatus.IsEnabled() {
+		if recordedPod, recordErr := recordInjections(insts, modifiedPod); recordErr != nil {
+			logger.Error(recordErr, "failed to record the injected instrumentations")
+		} else {
+			modifiedPod = recordedPod
+		}
+	}
+	if len(guessed) > 0 {
+		if modifiedPod.Annotations == nil {
+			modifiedPod.Annotations = map[string]string{}
+		}
+		modifiedPod.Annotations[annotationGuessedLanguages] = guessedLanguagesValue(guessed)
+	}
 
 	return modifiedPod, nil
 }
 
-func (pm *instPodMutator) getInstrumentationInstance(ctx context.Context, ns corev1.Namespace, pod corev1.Pod, instAnnotation string) (*v1alpha1.Instrumentation, error) {
+func (pm *instPodMutator) getInstrumentationInstance(ctx context.Context, ns corev1.Namespace, pod corev1.Pod, instAnnotation string, selected map[string]*v1alpha1.Instrumentation) (*v1alpha1.Instrumentation, error) {
 	instValue := annotationValue(ns.ObjectMeta, pod.ObjectMeta, instAnnotation)
 
-	if len(instValue) == 0 || strings.EqualFold(instValue, "false") {
+	if len(instValue) == 0 {
+		return selected[instAnnotation], nil
+	}
+
+	if strings.EqualFold(instValue, "false") {
 		return nil, nil
 	}
 
+	if isContainerInstrumentationsValue(instValue) {
+		// the Instrumentation of the first container stands for the language until the containers are set
+		containerInsts, err := parseContainerInstrumentations(instValue)
+		if err != nil {
+			return nil, err
+		}
+		instValue = containerInsts[0].instrumentation
+	}
+
+	return pm.getInstrumentationByValue(ctx, ns, instValue)
+}
+
+// getInstrumentationByValue returns the Instrumentation referenced by the value of an inject annotation, either "true"
+// for the only Instrumentation of the namespace, or the name of the Instrumentation optionally prefixed by its namespace.
+func (pm *instPodMutator) getInstrumentationByValue(ctx context.Context, ns corev1.Namespace, instValue string) (*v1alpha1.Instrumentation, error) {
 	if strings.EqualFold(instValue, "true") {
 		return pm.selectInstrumentationInstanceFromNamespace(ctx, ns)
 	}
@@ -452,6 +583,53 @@ func (pm *instPodMutator) getInstrumentationInstance(ctx context.Context, ns cor
 	return otelInst, nil
 }
 
+// setContainerInstrumentations sets the containers, and their Instrumentation, of the languages whose inject annotation
+// maps containers to Instrumentations. The containers of a language share the init container and volume of its agent,
+// so their Instrumentations must use the same agent image.
+func (pm *instPodMutator) setContainerInstrumentations(ctx context.Context, insts *languageInstrumentations, ns corev1.Namespace, pod corev1.Pod) error {
+	languages := []struct {
+		language v1alpha1.InstrumentationLanguage
+		iwc      *instrumentationWithContainers
+	}{
+		{v1alpha1.LanguageJava, &insts.Java},
+		{v1alpha1.LanguageNodeJS, &insts.NodeJS},
+		{v1alpha1.LanguagePython, &insts.Python},
+		{v1alpha1.LanguageDotNet, &insts.DotNet},
+		{v1alpha1.LanguageGo, &insts.Go},
+		{v1alpha1.LanguageApacheHttpd, &insts.ApacheHttpd},
+		{v1alpha1.LanguageNginx, &insts.Nginx},
+		{v1alpha1.LanguageRuby, &insts.Ruby},
+		{v1alpha1.LanguagePHP, &insts.PHP},
+		{v1alpha1.LanguageSdk, &insts.Sdk},
+	}
+
+	for _, l := range languages {
+		instValue := annotationValue(ns.ObjectMeta, pod.ObjectMeta, languageAnnotations[l.language])
+		if l.iwc.Instrumentation == nil || !isContainerInstrumentationsValue(instValue) {
+			continue
+		}
+		containerInsts, err := parseContainerInstrumentations(instValue)
+		if err != nil {
+			return err
+		}
+
+		l.iwc.Containers = nil
+		l.iwc.ContainerInstrumentations = map[string]*v1alpha1.Instrumentation{}
+		for _, ci := range containerInsts {
+			inst, err := pm.getInstrumentationByValue(ctx, ns, ci.instrumentation)
+			if err != nil {
+				return fmt.Errorf("failed to get the Instrumentation of container %s: %w", ci.container, err)
+			}
+			if image, expected := AgentImage(*inst, l.language), AgentImage(*l.iwc.Instrumentation, l.language); image != expected {
+				return fmt.Errorf("the %s agent image %s of container %s differs from the image %s of the other containers", l.language, image, ci.container, expected)
+			}
+			l.iwc.Containers = append(l.iwc.Containers, ci.container)
+			l.iwc.ContainerInstrumentations[ci.container] = inst
+		}
+	}
+	return nil
+}
+
 func (pm *instPodMutator) selectInstrumentationInstanceFromNamespace(ctx context.Context, ns corev1.Namespace) (*v1alpha1.Instrumentation, error) {
 	var otelInsts v1alpha1.InstrumentationList
 	if err := pm.Client.List(ctx, &otelInsts, client.InNamespace(ns.Name)); err != nil {
@@ -479,6 +657,8 @@ func (pm *instPodMutator) validateInstrumentations(ctx context.Context, inst lan
 		{inst.Go.Instrumentation},
 		{inst.ApacheHttpd.Instrumentation},
 		{inst.Nginx.Instrumentation},
+		{inst.Ruby.Instrumentation},
+		{inst.PHP.Instrumentation},
 		{inst.Sdk.Instrumentation},
 	}
 	var errs []error
@@ -489,6 +669,15 @@ func (pm *instPodMutator) validateInstrumentations(ctx context.Context, inst lan
 			}
 		}
 	}
+	for _, iwc := range []instrumentationWithContainers{inst.Java, inst.Python, inst.NodeJS, inst.DotNet, inst.Go, inst.ApacheHttpd, inst.Nginx, inst.Ruby, inst.PHP, inst.Sdk} {
+		for _, container := range iwc.Containers {
+			if containerInst, ok := iwc.ContainerInstrumentations[container]; ok && client.ObjectKeyFromObject(containerInst) != client.ObjectKeyFromObject(iwc.Instrumentation) {
+				if err := pm.validateInstrumentation(ctx, containerInst, podNamespace); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
 
 	if len(errs) > 0 {
 		return errors.Join(errs...)
@@ -3501,6 +3501,47 @@ func TestMutatePod(t *testing.T) {
 			},
 			err: `instrumentations.opentelemetry.io "doesnotexists" not found`,
 		},
+		{
+			name: "annotation mapping a container to non existing instance",
+			ns: corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "non-existing-container-instance",
+				},
+			},
+			inst: v1alpha1.Instrumentation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "example-inst",
+					Namespace: "non-existing-container-instance",
+				},
+				Spec: v1alpha1.InstrumentationSpec{
+					Java: v1alpha1.Java{
+						Image: "otel/java:1",
+					},
+					Exporter: v1alpha1.Exporter{
+						Endpoint: "http://collector:12345",
+					},
+				},
+			},
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationInjectJava: "app=example-inst,worker=doesnotexists",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "app",
+						},
+						{
+							Name: "worker",
+						},
+					},
+				},
+			},
+			config: config.New(),
+			err:    `failed to get the Instrumentation of container worker: instrumentations.opentelemetry.io "doesnotexists" not found`,
+		},
 		{
 			name: "multi instrumentation for multiple containers feature gate enabled",
 			ns: corev1.Namespace{
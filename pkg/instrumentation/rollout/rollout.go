@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rollout restarts the workloads running an outdated agent image according to the rollout policy of their
+// Instrumentation.
+package rollout
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/status/conditions"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+const (
+	// annotationRestartedAt is the pod template annotation `kubectl rollout restart` uses to restart a workload.
+	annotationRestartedAt = "kubectl.kubernetes.io/restartedAt"
+	// AnnotationRolloutImages records on the pod template the agent images a workload was restarted for. A workload is
+	// restarted only once for the same agent images.
+	AnnotationRolloutImages = "instrumentation.opentelemetry.io/rollout-images"
+)
+
+var languages = []v1alpha1.InstrumentationLanguage{
+	v1alpha1.LanguageJava,
+	v1alpha1.LanguageNodeJS,
+	v1alpha1.LanguagePython,
+	v1alpha1.LanguageDotNet,
+	v1alpha1.LanguageGo,
+	v1alpha1.LanguageApacheHttpd,
+	v1alpha1.LanguageNginx,
+	v1alpha1.LanguageRuby,
+	v1alpha1.LanguagePHP,
+}
+
+// Rollout restarts workloads running an outdated agent image.
+type Rollout struct {
+	Client   client.Client
+	Logger   logr.Logger
+	Recorder record.EventRecorder
+	now      func() time.Time
+}
+
+func New(client client.Client, logger logr.Logger, recorder record.EventRecorder) *Rollout {
+	return &Rollout{
+		Client:   client,
+		Logger:   logger,
+		Recorder: recorder,
+		now:      time.Now,
+	}
+}
+
+// workload is a Deployment, StatefulSet or DaemonSet to restart.
+type workload struct {
+	object   client.Object
+	template *corev1.PodTemplateSpec
+	rollout  conditions.Rollout
+}
+
+// +kubebuilder:rbac:groups=apps,resources=daemonsets;deployments;statefulsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
+
+// Restart restarts the given workloads running an outdated agent image, according to the rollout policy of the
+// Instrumentation. At most MaxConcurrent workloads are rolled out at the same time, batches of restarts are spread by
+// the policy interval, and workloads whose pod disruption budget doesn't allow any disruption are left for later.
+// Workloads outside the namespace of the Instrumentation are only restarted when their namespace opted in to it, see
+// instrumentation.AllowedIn. It returns the time of the last batch of restarts, and when to look at the remaining workloads again.
+func (r *Rollout) Restart(ctx context.Context, inst v1alpha1.Instrumentation, workloads []v1alpha1.InstrumentedWorkload) (*metav1.Time, time.Duration, error) {
+	lastRollout := inst.Status.LastRolloutTime
+	policy := inst.Spec.RolloutPolicy
+	if policy == nil || policy.Mode != v1alpha1.RolloutModeAutomatic {
+		return lastRollout, 0, nil
+	}
+	maxConcurrent := int(policy.MaxConcurrent)
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	interval := policy.Interval.Duration
+	if interval <= 0 {
+		interval = v1alpha1.DefaultRolloutInterval
+	}
+	images := agentImages(inst)
+
+	inProgress := 0
+	var pending []workload
+	allowed := map[string]bool{}
+	for _, key := range outdatedWorkloads(workloads) {
+		ok, err := r.allowedIn(ctx, inst, key.Namespace, allowed)
+		if err != nil {
+			return lastRollout, 0, err
+		}
+		if !ok {
+			r.Logger.V(1).Info("namespace didn't opt in to the Instrumentation, skipping the restart", "kind", key.Kind, "namespace", key.Namespace, "name", key.Name)
+			continue
+		}
+		w, err := r.get(ctx, key)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return lastRollout, 0, err
+		}
+		if w.template.Annotations[AnnotationRolloutImages] == images {
+			// restarted for the current agent images already, pods with an outdated image may still be rolling out
+			if !w.rollout.Complete() {
+				inProgress++
+			}
+			continue
+		}
+		pending = append(pending, w)
+	}
+	if len(pending) == 0 {
+		return lastRollout, 0, nil
+	}
+
+	now := r.now()
+	if lastRollout != nil {
+		if wait := lastRollout.Add(interval).Sub(now); wait > 0 {
+			return lastRollout, wait, nil
+		}
+	}
+
+	restarted := 0
+	for _, w := range pending {
+		if inProgress+restarted >= maxConcurrent {
+			break
+		}
+		blocked, err := r.disruptionBlocked(ctx, w)
+		if err != nil {
+			return lastRollout, 0, err
+		}
+		if blocked {
+			r.Logger.V(1).Info("pod disruption budget doesn't allow any disruption, postponing the restart", "kind", kind(w.object), "namespace", w.object.GetNamespace(), "name", w.object.GetName())
+			continue
+		}
+
+		before := w.object.DeepCopyObject().(client.Object)
+		if w.template.Annotations == nil {
+			w.template.Annotations = map[string]string{}
+		}
+		w.template.Annotations[annotationRestartedAt] = now.Format(time.RFC3339)
+		w.template.Annotations[AnnotationRolloutImages] = images
+		if err := r.Client.Patch(ctx, w.object, client.MergeFrom(before)); err != nil {
+			return lastRollout, 0, fmt.Errorf("failed to restart %s %s/%s: %w", kind(w.object), w.object.GetNamespace(), w.object.GetName(), err)
+		}
+		r.Logger.Info("restarted workload running an outdated agent image", "kind", kind(w.object), "namespace", w.object.GetNamespace(), "name", w.object.GetName())
+		r.Recorder.Event(&inst, corev1.EventTypeNormal, "WorkloadRestarted", fmt.Sprintf("restarted %s %s/%s running an outdated agent image", kind(w.object), w.object.GetNamespace(), w.object.GetName()))
+		restarted++
+	}
+	if restarted > 0 {
+		lastRollout = &metav1.Time{Time: now}
+	}
+	return lastRollout, interval, nil
+}
+
+// allowedIn returns whether the Instrumentation may restart the workloads of the namespace, remembering the answer for
+// every namespace in allowed.
+func (r *Rollout) allowedIn(ctx context.Context, inst v1alpha1.Instrumentation, namespace string, allowed map[string]bool) (bool, error) {
+	if namespace == inst.Namespace {
+		return true, nil
+	}
+	if ok, found := allowed[namespace]; found {
+		return ok, nil
+	}
+	ns := corev1.Namespace{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: namespace}, &ns)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get the namespace %s: %w", namespace, err)
+	}
+	allowed[namespace] = err == nil && instrumentation.AllowedIn(inst, ns)
+	return allowed[namespace], nil
+}
+
+func (r *Rollout) get(ctx context.Context, key v1alpha1.InstrumentedWorkload) (workload, error) {
+	name := types.NamespacedName{Namespace: key.Namespace, Name: key.Name}
+	switch key.Kind {
+	case "Deployment":
+		d := &appsv1.Deployment{}
+		err := r.Client.Get(ctx, name, d)
+		return workload{object: d, template: &d.Spec.Template, rollout: conditions.FromDeployment(d)}, err
+	case "StatefulSet":
+		s := &appsv1.StatefulSet{}
+		err := r.Client.Get(ctx, name, s)
+		return workload{object: s, template: &s.Spec.Template, rollout: conditions.FromStatefulSet(s)}, err
+	default:
+		d := &appsv1.DaemonSet{}
+		err := r.Client.Get(ctx, name, d)
+		return workload{object: d, template: &d.Spec.Template, rollout: conditions.FromDaemonSet(d)}, err
+	}
+}
+
+// disruptionBlocked returns whether a pod disruption budget selecting the pods of the workload doesn't allow any
+// disruption at the moment.
+func (r *Rollout) disruptionBlocked(ctx context.Context, w workload) (bool, error) {
+	var pdbs policyv1.PodDisruptionBudgetList
+	if err := r.Client.List(ctx, &pdbs, client.InNamespace(w.object.GetNamespace())); err != nil {
+		return false, fmt.Errorf("failed to list the pod disruption budgets: %w", err)
+	}
+	for _, pdb := range pdbs.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(w.template.Labels)) && pdb.Status.DisruptionsAllowed < 1 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// outdatedWorkloads returns the Deployments, StatefulSets and DaemonSets with pods running an outdated agent image.
+func outdatedWorkloads(workloads []v1alpha1.InstrumentedWorkload) []v1alpha1.InstrumentedWorkload {
+	seen := map[string]bool{}
+	var outdated []v1alpha1.InstrumentedWorkload
+	for _, w := range workloads {
+		if w.OutdatedPods == 0 || (w.Kind != "Deployment" && w.Kind != "StatefulSet" && w.Kind != "DaemonSet") {
+			continue
+		}
+		id := strings.Join([]string{w.Kind, w.Namespace, w.Name}, "/")
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		outdated = append(outdated, w)
+	}
+	sort.Slice(outdated, func(i, j int) bool {
+		if outdated[i].Namespace != outdated[j].Namespace {
+			return outdated[i].Namespace < outdated[j].Namespace
+		}
+		if outdated[i].Kind != outdated[j].Kind {
+			return outdated[i].Kind < outdated[j].Kind
+		}
+		return outdated[i].Name < outdated[j].Name
+	})
+	return outdated
+}
+
+// agentImages returns a short digest of the agent images of the Instrumentation.
+func agentImages(inst v1alpha1.Instrumentation) string {
+	var images []string
+	for _, language := range languages {
+		images = append(images, fmt.Sprintf("%s=%s", language, instrumentation.AgentImage(inst, language)))
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(images, ","))))[:16]
+}
+
+func kind(obj client.Object) string {
+	switch obj.(type) {
+	case *appsv1.Deployment:
+		return "Deployment"
+	case *appsv1.StatefulSet:
+		return "StatefulSet"
+	default:
+		return "DaemonSet"
+	}
+}
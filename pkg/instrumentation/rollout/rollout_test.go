@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+var now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func deployment(name string, podLabels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+			},
+		},
+	}
+}
+
+// shop is the namespace of the workloads, it opted in to the Instrumentation of the observability namespace.
+var shop = &corev1.Namespace{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:        "shop",
+		Annotations: map[string]string{instrumentation.AnnotationAllowedInstrumentations: "observability/default"},
+	},
+}
+
+func newRollout(t *testing.T, objects ...client.Object) *Rollout {
+	return newRolloutIn(t, shop, objects...)
+}
+
+func newRolloutIn(t *testing.T, ns *corev1.Namespace, objects ...client.Object) *Rollout {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	r := New(fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, ns)...).Build(), logr.Discard(), record.NewFakeRecorder(10))
+	r.now = func() time.Time { return now }
+	return r
+}
+
+func newInstrumentation(mode v1alpha1.RolloutMode, lastRollout *metav1.Time) v1alpha1.Instrumentation {
+	return v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "observability", Name: "default"},
+		Spec: v1alpha1.InstrumentationSpec{
+			Java: v1alpha1.Java{Image: "java:2.0"},
+			RolloutPolicy: &v1alpha1.RolloutPolicy{
+				Mode:          mode,
+				MaxConcurrent: 1,
+				Interval:      metav1.Duration{Duration: 10 * time.Minute},
+			},
+		},
+		Status: v1alpha1.InstrumentationStatus{LastRolloutTime: lastRollout},
+	}
+}
+
+func restartedFor(t *testing.T, r *Rollout, name string) string {
+	d := &appsv1.Deployment{}
+	require.NoError(t, r.Client.Get(context.Background(), types.NamespacedName{Namespace: "shop", Name: name}, d))
+	return d.Spec.Template.Annotations[AnnotationRolloutImages]
+}
+
+var outdated = []v1alpha1.InstrumentedWorkload{
+	{Kind: "Deployment", Namespace: "shop", Name: "cart", Language: v1alpha1.LanguageJava, Pods: 2, OutdatedPods: 2},
+	{Kind: "Deployment", Namespace: "shop", Name: "orders", Language: v1alpha1.LanguageJava, Pods: 1, OutdatedPods: 1},
+	{Kind: "Deployment", Namespace: "shop", Name: "search", Language: v1alpha1.LanguageJava, Pods: 1},
+	{Kind: "Pod", Namespace: "shop", Name: "debug", Language: v1alpha1.LanguageJava, Pods: 1, OutdatedPods: 1},
+}
+
+func TestRestartManual(t *testing.T) {
+	r := newRollout(t, deployment("cart", nil))
+
+	lastRollout, requeue, err := r.Restart(context.Background(), newInstrumentation(v1alpha1.RolloutModeManual, nil), outdated)
+
+	require.NoError(t, err)
+	assert.Nil(t, lastRollout)
+	assert.Zero(t, requeue)
+	assert.Empty(t, restartedFor(t, r, "cart"))
+}
+
+func TestRestartAutomatic(t *testing.T) {
+	r := newRollout(t, deployment("cart", nil), deployment("orders", nil), deployment("search", nil))
+	inst := newInstrumentation(v1alpha1.RolloutModeAutomatic, nil)
+
+	lastRollout, requeue, err := r.Restart(context.Background(), inst, outdated)
+
+	require.NoError(t, err)
+	require.NotNil(t, lastRollout)
+	assert.Equal(t, now, lastRollout.Time)
+	assert.Equal(t, 10*time.Minute, requeue)
+	// a single workload is restarted at a time, in order
+	assert.Equal(t, agentImages(inst), restartedFor(t, r, "cart"))
+	assert.Empty(t, restartedFor(t, r, "orders"))
+	assert.Empty(t, restartedFor(t, r, "search"))
+
+	// the restarted workload is still rolling out
+	inst.Status.LastRolloutTime = &metav1.Time{Time: now.Add(-time.Hour)}
+	_, _, err = r.Restart(context.Background(), inst, outdated)
+	require.NoError(t, err)
+	assert.Empty(t, restartedFor(t, r, "orders"))
+}
+
+func TestRestartRequiresNamespaceOptIn(t *testing.T) {
+	r := newRolloutIn(t, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}}, deployment("cart", nil), deployment("orders", nil))
+
+	lastRollout, requeue, err := r.Restart(context.Background(), newInstrumentation(v1alpha1.RolloutModeAutomatic, nil), outdated)
+
+	require.NoError(t, err)
+	assert.Nil(t, lastRollout)
+	assert.Zero(t, requeue)
+	assert.Empty(t, restartedFor(t, r, "cart"))
+	assert.Empty(t, restartedFor(t, r, "orders"))
+}
+
+func TestRestartInterval(t *testing.T) {
+	r := newRollout(t, deployment("cart", nil))
+	inst := newInstrumentation(v1alpha1.RolloutModeAutomatic, &metav1.Time{Time: now.Add(-4 * time.Minute)})
+
+	lastRollout, requeue, err := r.Restart(context.Background(), inst, outdated)
+
+	require.NoError(t, err)
+	assert.Equal(t, inst.Status.LastRolloutTime, lastRollout)
+	assert.Equal(t, 6*time.Minute, requeue)
+	assert.Empty(t, restartedFor(t, r, "cart"))
+}
+
+func TestRestartRespectsPodDisruptionBudgets(t *testing.T) {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "cart"}},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+	}
+	r := newRollout(t, deployment("cart", map[string]string{"app": "cart"}), deployment("orders", map[string]string{"app": "orders"}), pdb)
+	inst := newInstrumentation(v1alpha1.RolloutModeAutomatic, nil)
+
+	_, _, err := r.Restart(context.Background(), inst, outdated)
+
+	require.NoError(t, err)
+	assert.Empty(t, restartedFor(t, r, "cart"))
+	assert.Equal(t, agentImages(inst), restartedFor(t, r, "orders"))
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	envRubyOpt            = "RUBYOPT"
+	rubyRequireArgument   = " -r/otel-auto-instrumentation-ruby/autoinstrumentation.rb"
+	rubyInitContainerName = initContainerName + "-ruby"
+	rubyVolumeName        = volumeName + "-ruby"
+	rubyInstrMountPath    = "/otel-auto-instrumentation-ruby"
+	// rubyABIVersion is the Ruby version the native gems of the auto-instrumentation image are built for.
+	rubyABIVersion = "3.3"
+)
+
+func injectRubySDK(rubySpec v1alpha1.Ruby, pod corev1.Pod, index int) (corev1.Pod, error) {
+	volume := instrVolume(rubySpec.VolumeClaimTemplate, rubyVolumeName, rubySpec.VolumeSizeLimit)
+
+	// caller checks if there is at least one container.
+	container := &pod.Spec.Containers[index]
+
+	err := validateContainerEnv(container.Env, envRubyOpt)
+	if err != nil {
+		return pod, err
+	}
+
+	// inject Ruby instrumentation spec env vars.
+	for _, env := range rubySpec.Env {
+		idx := getIndexOfEnv(container.Env, env.Name)
+		if idx == -1 {
+			container.Env = append(container.Env, env)
+		}
+	}
+
+	idx := getIndexOfEnv(container.Env, envRubyOpt)
+	if idx == -1 {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  envRubyOpt,
+			Value: rubyRequireArgument,
+		})
+	} else if idx > -1 {
+		container.Env[idx].Value = container.Env[idx].Value + rubyRequireArgument
+	}
+
+	// Set OTEL_EXPORTER_OTLP_PROTOCOL to http/protobuf if not set by user because it is what our autoinstrumentation supports.
+	idx = getIndexOfEnv(container.Env, envOtelExporterOTLPProtocol)
+	if idx == -1 {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  envOtelExporterOTLPProtocol,
+			Value: "http/protobuf",
+		})
+	}
+
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      volume.Name,
+		MountPath: rubyInstrMountPath,
+	})
+
+	// We just inject Volumes and init containers for the first processed container
+	if isInitContainerMissing(pod, rubyInitContainerName) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
+			Name:      rubyInitContainerName,
+			Image:     rubySpec.Image,
+			Command:   []string{"cp", "-r", "/autoinstrumentation/.", rubyInstrMountPath},
+			Resources: rubySpec.Resources,
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      volume.Name,
+				MountPath: rubyInstrMountPath,
+			}},
+		})
+	}
+	return pod, nil
+}
+
+// rubyImageIncompatible returns whether the image of the application looks like an Alpine (musl) Ruby image, or an
+// official ruby image of another version than rubyABIVersion, e.g. ruby:3.2-alpine, which can't load the native gems
+// built for glibc and Ruby 3.3.
+func rubyImageIncompatible(image string) bool {
+	reference, _, _ := strings.Cut(image, "@")
+	if i := strings.LastIndex(reference, "/"); i >= 0 {
+		reference = reference[i+1:]
+	}
+	for _, token := range strings.FieldsFunc(reference, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == ':'
+	}) {
+		if strings.HasPrefix(token, "alpine") {
+			return true
+		}
+	}
+	repository, tag, ok := strings.Cut(reference, ":")
+	if !ok || repository != "ruby" {
+		return false
+	}
+	version, _, _ := strings.Cut(tag, "-")
+	major, rest, ok := strings.Cut(version, ".")
+	if !ok {
+		return false
+	}
+	minor, _, _ := strings.Cut(rest, ".")
+	return major+"."+minor != rubyABIVersion
+}
@@ -37,6 +37,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 )
 
@@ -57,23 +58,25 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	if len(pod.Spec.Containers) < 1 {
 		return pod
 	}
+	logger := podmutation.Logger(ctx, i.logger)
 	if insts.Java.Instrumentation != nil {
 		otelinst := *insts.Java.Instrumentation
 		var err error
-		i.logger.V(1).Info("injecting Java instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		logger.V(1).Info("injecting Java instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		if len(insts.Java.Containers) == 0 {
 			insts.Java.Containers = []string{pod.Spec.Containers[0].Name}
 		}
 
 		for _, container := range insts.Java.Containers {
+			otelinst := insts.Java.instrumentationFor(container)
 			index := getContainerIndex(container, pod)
 			pod, err = injectJavaagent(otelinst.Spec.Java, pod, index)
 			if err != nil {
-				i.logger.Info("Skipping javaagent injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+				logger.Info("Skipping javaagent injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
 			} else {
 				pod = i.injectCommonEnvVar(otelinst, pod, index)
-				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+				pod = i.injectCommonSDKConfig(ctx, otelinst, v1alpha1.LanguageJava, ns, pod, index, index)
 				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, javaInitContainerName)
 			}
 		}
@@ -81,20 +84,21 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	if insts.NodeJS.Instrumentation != nil {
 		otelinst := *insts.NodeJS.Instrumentation
 		var err error
-		i.logger.V(1).Info("injecting NodeJS instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		logger.V(1).Info("injecting NodeJS instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		if len(insts.NodeJS.Containers) == 0 {
 			insts.NodeJS.Containers = []string{pod.Spec.Containers[0].Name}
 		}
 
 		for _, container := range insts.NodeJS.Containers {
+			otelinst := insts.NodeJS.instrumentationFor(container)
 			index := getContainerIndex(container, pod)
 			pod, err = injectNodeJSSDK(otelinst.Spec.NodeJS, pod, index)
 			if err != nil {
-				i.logger.Info("Skipping NodeJS SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+				logger.Info("Skipping NodeJS SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
 			} else {
 				pod = i.injectCommonEnvVar(otelinst, pod, index)
-				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+				pod = i.injectCommonSDKConfig(ctx, otelinst, v1alpha1.LanguageNodeJS, ns, pod, index, index)
 				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, nodejsInitContainerName)
 			}
 		}
@@ -102,89 +106,142 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	if insts.Python.Instrumentation != nil {
 		otelinst := *insts.Python.Instrumentation
 		var err error
-		i.logger.V(1).Info("injecting Python instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		logger.V(1).Info("injecting Python instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		if len(insts.Python.Containers) == 0 {
 			insts.Python.Containers = []string{pod.Spec.Containers[0].Name}
 		}
 
 		for _, container := range insts.Python.Containers {
+			otelinst := insts.Python.instrumentationFor(container)
 			index := getContainerIndex(container, pod)
 			pod, err = injectPythonSDK(otelinst.Spec.Python, pod, index, insts.Python.AdditionalAnnotations[annotationPythonPlatform])
 			if err != nil {
-				i.logger.Info("Skipping Python SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+				logger.Info("Skipping Python SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
 			} else {
 				pod = i.injectCommonEnvVar(otelinst, pod, index)
-				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+				pod = i.injectCommonSDKConfig(ctx, otelinst, v1alpha1.LanguagePython, ns, pod, index, index)
 				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, pythonInitContainerName)
 			}
 		}
 	}
+	if insts.Ruby.Instrumentation != nil {
+		otelinst := *insts.Ruby.Instrumentation
+		var err error
+		logger.V(1).Info("injecting Ruby instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+		if len(insts.Ruby.Containers) == 0 {
+			insts.Ruby.Containers = []string{pod.Spec.Containers[0].Name}
+		}
+
+		for _, container := range insts.Ruby.Containers {
+			otelinst := insts.Ruby.instrumentationFor(container)
+			index := getContainerIndex(container, pod)
+			if rubyImageIncompatible(pod.Spec.Containers[index].Image) {
+				logger.Info("The Ruby auto-instrumentation is built for glibc and Ruby "+rubyABIVersion+", its native gems may not load in an Alpine image or another Ruby version", "container", pod.Spec.Containers[index].Name, "image", pod.Spec.Containers[index].Image)
+			}
+			pod, err = injectRubySDK(otelinst.Spec.Ruby, pod, index)
+			if err != nil {
+				logger.Info("Skipping Ruby SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+			} else {
+				pod = i.injectCommonEnvVar(otelinst, pod, index)
+				pod = i.injectCommonSDKConfig(ctx, otelinst, v1alpha1.LanguageRuby, ns, pod, index, index)
+				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, rubyInitContainerName)
+			}
+		}
+	}
+	if insts.PHP.Instrumentation != nil {
+		otelinst := *insts.PHP.Instrumentation
+		var err error
+		logger.V(1).Info("injecting PHP instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+		if len(insts.PHP.Containers) == 0 {
+			insts.PHP.Containers = []string{pod.Spec.Containers[0].Name}
+		}
+
+		for _, container := range insts.PHP.Containers {
+			otelinst := insts.PHP.instrumentationFor(container)
+			index := getContainerIndex(container, pod)
+			if phpImageIncompatible(pod.Spec.Containers[index].Image) {
+				logger.Info("The PHP auto-instrumentation is built for glibc and non thread safe PHP, it may not load in an Alpine or ZTS image", "container", pod.Spec.Containers[index].Name, "image", pod.Spec.Containers[index].Image)
+			}
+			pod, err = injectPHPSDK(otelinst.Spec.PHP, pod, index, insts.PHP.AdditionalAnnotations[annotationPHPVersion])
+			if err != nil {
+				logger.Info("Skipping PHP SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+			} else {
+				pod = i.injectCommonEnvVar(otelinst, pod, index)
+				pod = i.injectCommonSDKConfig(ctx, otelinst, v1alpha1.LanguagePHP, ns, pod, index, index)
+				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, phpInitContainerName)
+			}
+		}
+	}
 	if insts.DotNet.Instrumentation != nil {
 		otelinst := *insts.DotNet.Instrumentation
 		var err error
-		i.logger.V(1).Info("injecting DotNet instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		logger.V(1).Info("injecting DotNet instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		if len(insts.DotNet.Containers) == 0 {
 			insts.DotNet.Containers = []string{pod.Spec.Containers[0].Name}
 		}
 
 		for _, container := range insts.DotNet.Containers {
+			otelinst := insts.DotNet.instrumentationFor(container)
 			index := getContainerIndex(container, pod)
 			pod, err = injectDotNetSDK(otelinst.Spec.DotNet, pod, index, insts.DotNet.AdditionalAnnotations[annotationDotNetRuntime])
 			if err != nil {
-				i.logger.Info("Skipping DotNet SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+				logger.Info("Skipping DotNet SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
 			} else {
 				pod = i.injectCommonEnvVar(otelinst, pod, index)
-				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+				pod = i.injectCommonSDKConfig(ctx, otelinst, v1alpha1.LanguageDotNet, ns, pod, index, index)
 				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, dotnetInitContainerName)
 			}
 		}
 	}
 	if insts.Go.Instrumentation != nil {
 		origPod := pod
-		otelinst := *insts.Go.Instrumentation
 		var err error
-		i.logger.V(1).Info("injecting Go instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		if len(insts.Go.Containers) == 0 {
 			insts.Go.Containers = []string{pod.Spec.Containers[0].Name}
 		}
 
 		// Go instrumentation supports only single container instrumentation.
+		otelinst := insts.Go.instrumentationFor(insts.Go.Containers[0])
+		logger.V(1).Info("injecting Go instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 		index := getContainerIndex(insts.Go.Containers[0], pod)
 		pod, err = injectGoSDK(otelinst.Spec.Go, pod, cfg)
 		if err != nil {
-			i.logger.Info("Skipping Go SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+			logger.Info("Skipping Go SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
 		} else {
 			// Common env vars and config need to be applied to the agent contain.
 			pod = i.injectCommonEnvVar(otelinst, pod, len(pod.Spec.Containers)-1)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, len(pod.Spec.Containers)-1, 0)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, v1alpha1.LanguageGo, ns, pod, len(pod.Spec.Containers)-1, 0)
 
 			// Ensure that after all the env var coalescing we have a value for OTEL_GO_AUTO_TARGET_EXE
 			idx := getIndexOfEnv(pod.Spec.Containers[len(pod.Spec.Containers)-1].Env, envOtelTargetExe)
 			if idx == -1 {
-				i.logger.Info("Skipping Go SDK injection", "reason", "OTEL_GO_AUTO_TARGET_EXE not set", "container", pod.Spec.Containers[index].Name)
+				logger.Info("Skipping Go SDK injection", "reason", "OTEL_GO_AUTO_TARGET_EXE not set", "container", pod.Spec.Containers[index].Name)
 				pod = origPod
 			}
 		}
 	}
 	if insts.ApacheHttpd.Instrumentation != nil {
 		otelinst := *insts.ApacheHttpd.Instrumentation
-		i.logger.V(1).Info("injecting Apache Httpd instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		logger.V(1).Info("injecting Apache Httpd instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		if len(insts.ApacheHttpd.Containers) == 0 {
 			insts.ApacheHttpd.Containers = []string{pod.Spec.Containers[0].Name}
 		}
 
 		for _, container := range insts.ApacheHttpd.Containers {
+			otelinst := insts.ApacheHttpd.instrumentationFor(container)
 			index := getContainerIndex(container, pod)
 			// Apache agent is configured via config files rather than env vars.
 			// Therefore, service name, otlp endpoint and other attributes are passed to the agent injection method
 			useLabelsForResourceAttributes := otelinst.Spec.Defaults.UseLabelsForResourceAttributes
-			pod = injectApacheHttpdagent(i.logger, otelinst.Spec.ApacheHttpd, pod, useLabelsForResourceAttributes, index, otelinst.Spec.Endpoint, i.createResourceMap(ctx, otelinst, ns, pod, index))
+			pod = injectApacheHttpdagent(logger, otelinst.Spec.ApacheHttpd, pod, useLabelsForResourceAttributes, index, otelinst.Spec.Endpoint, i.createResourceMap(ctx, otelinst, ns, pod, index))
 			pod = i.injectCommonEnvVar(otelinst, pod, index)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, v1alpha1.LanguageApacheHttpd, ns, pod, index, index)
 			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, apacheAgentInitContainerName)
 			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, apacheAgentCloneContainerName)
 		}
@@ -192,35 +249,37 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 
 	if insts.Nginx.Instrumentation != nil {
 		otelinst := *insts.Nginx.Instrumentation
-		i.logger.V(1).Info("injecting Nginx instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		logger.V(1).Info("injecting Nginx instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		if len(insts.Nginx.Containers) == 0 {
 			insts.Nginx.Containers = []string{pod.Spec.Containers[0].Name}
 		}
 
 		for _, container := range insts.Nginx.Containers {
+			otelinst := insts.Nginx.instrumentationFor(container)
 			index := getContainerIndex(container, pod)
 			// Nginx agent is configured via config files rather than env vars.
 			// Therefore, service name, otlp endpoint and other attributes are passed to the agent injection method
 			useLabelsForResourceAttributes := otelinst.Spec.Defaults.UseLabelsForResourceAttributes
-			pod = injectNginxSDK(i.logger, otelinst.Spec.Nginx, pod, useLabelsForResourceAttributes, index, otelinst.Spec.Endpoint, i.createResourceMap(ctx, otelinst, ns, pod, index))
+			pod = injectNginxSDK(logger, otelinst.Spec.Nginx, pod, useLabelsForResourceAttributes, index, otelinst.Spec.Endpoint, i.createResourceMap(ctx, otelinst, ns, pod, index))
 			pod = i.injectCommonEnvVar(otelinst, pod, index)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, v1alpha1.LanguageNginx, ns, pod, index, index)
 		}
 	}
 
 	if insts.Sdk.Instrumentation != nil {
 		otelinst := *insts.Sdk.Instrumentation
-		i.logger.V(1).Info("injecting sdk-only instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		logger.V(1).Info("injecting sdk-only instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		if len(insts.Sdk.Containers) == 0 {
 			insts.Sdk.Containers = []string{pod.Spec.Containers[0].Name}
 		}
 
 		for _, container := range insts.Sdk.Containers {
+			otelinst := insts.Sdk.instrumentationFor(container)
 			index := getContainerIndex(container, pod)
 			pod = i.injectCommonEnvVar(otelinst, pod, index)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, v1alpha1.LanguageSdk, ns, pod, index, index)
 		}
 	}
 
@@ -290,10 +349,10 @@ func (i *sdkInjector) injectCommonEnvVar(otelinst v1alpha1.Instrumentation, pod
 // agentIndex represents the index of the pod the needs the env vars to instrument the application.
 // appIndex represents the index of the pod the will produce the telemetry.
 // When the pod handling the instrumentation is the same as the pod producing the telemetry agentIndex
-// and appIndex should be the same value.  This is true for dotnet, java, nodejs, and python instrumentations.
+// and appIndex should be the same value.  This is true for dotnet, java, nodejs, python, ruby and php instrumentations.
 // Go requires the agent to be a different container in the pod, so the agentIndex should represent this new sidecar
 // and appIndex should represent the application being instrumented.
-func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod, agentIndex int, appIndex int) corev1.Pod {
+func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alpha1.Instrumentation, lang v1alpha1.InstrumentationLanguage, ns corev1.Namespace, pod corev1.Pod, agentIndex int, appIndex int) corev1.Pod {
 	container := &pod.Spec.Containers[agentIndex]
 	useLabelsForResourceAttributes := otelinst.Spec.Defaults.UseLabelsForResourceAttributes
 	resourceMap := i.createResourceMap(ctx, otelinst, ns, pod, appIndex)
@@ -304,7 +363,13 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 			Value: chooseServiceName(pod, useLabelsForResourceAttributes, resourceMap, appIndex),
 		})
 	}
-	configureExporter(otelinst.Spec.Exporter, &pod, container)
+	secretsAvailable := otelinst.Namespace == ns.Name
+	if !secretsAvailable && hasSecretHeaders(otelinst.Spec.Exporter) {
+		podmutation.Logger(ctx, i.logger).Info("skipping the exporter headers sourced from secrets, the secrets aren't in the namespace of the pod",
+			"otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name, "namespace", ns.Name)
+	}
+	configureExporter(otelinst.Spec.Exporter, secretsAvailable, &pod, container)
+	configureConfigFile(otelinst, lang, ns, &pod, container)
 
 	// Always retrieve the pod name from the Downward API. Ensure that the OTEL_RESOURCE_ATTRIBUTES_POD_NAME env exists.
 	container.Env = append(container.Env, corev1.EnvVar{
@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sdkconfig renders the exporter, propagators and sampler of an Instrumentation as an OpenTelemetry
+// declarative configuration file, provided to the SDKs through a ConfigMap.
+package sdkconfig
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+const (
+	// EnvConfigFile is the env var pointing the SDKs to their declarative configuration file.
+	EnvConfigFile = "OTEL_EXPERIMENTAL_CONFIG_FILE"
+	// FileName is the key of the configuration file in the ConfigMap.
+	FileName = "sdk-config.yaml"
+	// VolumeName is the name of the volume of the ConfigMap in the instrumented pods.
+	VolumeName = "otel-auto-sdk-config"
+	// MountPath is the directory the ConfigMap is mounted in. It's not mounted with a subPath, for the kubelet to
+	// update the file when the ConfigMap changes.
+	MountPath = "/otel-auto-instrumentation-sdk-config"
+
+	fileFormat   = "0.3"
+	otlpGRPCPort = "4317"
+
+	envTracesExporter  = "OTEL_TRACES_EXPORTER"
+	envMetricsExporter = "OTEL_METRICS_EXPORTER"
+	envLogsExporter    = "OTEL_LOGS_EXPORTER"
+	exporterNone       = "none"
+)
+
+var languages = []v1alpha1.InstrumentationLanguage{
+	v1alpha1.LanguageJava,
+	v1alpha1.LanguageNodeJS,
+	v1alpha1.LanguagePython,
+	v1alpha1.LanguageDotNet,
+	v1alpha1.LanguageGo,
+	v1alpha1.LanguageApacheHttpd,
+	v1alpha1.LanguageNginx,
+	v1alpha1.LanguageRuby,
+	v1alpha1.LanguagePHP,
+}
+
+// ConfigMapName returns the name of the ConfigMap holding the configuration file of the Instrumentation.
+func ConfigMapName(inst v1alpha1.Instrumentation) string {
+	return naming.Truncate("%s-sdk-config", 63, inst.Name)
+}
+
+// FileNameFor returns the key of the configuration file used by a language in the ConfigMap. The languages whose env
+// sets the exporter of a signal get a file of their own, the others share the file of the Instrumentation.
+func FileNameFor(inst v1alpha1.Instrumentation, lang v1alpha1.InstrumentationLanguage) string {
+	for _, env := range languageEnv(inst, lang) {
+		switch env.Name {
+		case envTracesExporter, envMetricsExporter, envLogsExporter:
+			return fmt.Sprintf("sdk-config-%s.yaml", lang)
+		}
+	}
+	return FileName
+}
+
+// ConfigMap returns the ConfigMap holding the configuration files of the Instrumentation, in its namespace.
+func ConfigMap(inst v1alpha1.Instrumentation) (*corev1.ConfigMap, error) {
+	data := map[string]string{}
+	for _, lang := range append([]v1alpha1.InstrumentationLanguage{v1alpha1.LanguageSdk}, languages...) {
+		name := FileNameFor(inst, lang)
+		if _, ok := data[name]; ok {
+			continue
+		}
+		file, err := Render(inst, lang)
+		if err != nil {
+			return nil, err
+		}
+		data[name] = file
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(inst),
+			Namespace: inst.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				"app.kubernetes.io/component":  "sdk-config",
+				"app.kubernetes.io/instance":   naming.Truncate("%s.%s", 63, inst.Namespace, inst.Name),
+			},
+		},
+		Data: data,
+	}, nil
+}
+
+type configuration struct {
+	FileFormat     string          `yaml:"file_format"`
+	Resource       resource        `yaml:"resource"`
+	Propagator     *propagator     `yaml:"propagator,omitempty"`
+	TracerProvider *tracerProvider `yaml:"tracer_provider,omitempty"`
+	MeterProvider  *meterProvider  `yaml:"meter_provider,omitempty"`
+	LoggerProvider *loggerProvider `yaml:"logger_provider,omitempty"`
+}
+
+type resource struct {
+	Attributes     []attribute `yaml:"attributes"`
+	AttributesList string      `yaml:"attributes_list"`
+}
+
+type attribute struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type propagator struct {
+	Composite []string `yaml:"composite"`
+}
+
+type tracerProvider struct {
+	Processors []batchProcessor `yaml:"processors"`
+	Sampler    *sampler         `yaml:"sampler,omitempty"`
+}
+
+type meterProvider struct {
+	Readers []periodicReader `yaml:"readers"`
+}
+
+type loggerProvider struct {
+	Processors []batchProcessor `yaml:"processors"`
+}
+
+type batchProcessor struct {
+	Batch exporter `yaml:"batch"`
+}
+
+type periodicReader struct {
+	Periodic exporter `yaml:"periodic"`
+}
+
+type exporter struct {
+	Exporter struct {
+		OTLP otlp `yaml:"otlp"`
+	} `yaml:"exporter"`
+}
+
+type otlp struct {
+	Protocol          string      `yaml:"protocol"`
+	Endpoint          string      `yaml:"endpoint,omitempty"`
+	Certificate       string      `yaml:"certificate,omitempty"`
+	ClientKey         string      `yaml:"client_key,omitempty"`
+	ClientCertificate string      `yaml:"client_certificate,omitempty"`
+	Headers           []attribute `yaml:"headers,omitempty"`
+	Compression       string      `yaml:"compression,omitempty"`
+	Timeout           int64       `yaml:"timeout,omitempty"`
+}
+
+type sampler struct {
+	AlwaysOn          *struct{}          `yaml:"always_on,omitempty"`
+	AlwaysOff         *struct{}          `yaml:"always_off,omitempty"`
+	TraceIDRatioBased *traceIDRatioBased `yaml:"trace_id_ratio_based,omitempty"`
+	ParentBased       *parentBased       `yaml:"parent_based,omitempty"`
+}
+
+type traceIDRatioBased struct {
+	Ratio float64 `yaml:"ratio"`
+}
+
+type parentBased struct {
+	Root sampler `yaml:"root"`
+}
+
+// Render returns the declarative configuration file of the Instrumentation for a language. The resource is taken from
+// the env vars the operator injects, as it differs in each pod. The TLS files are referenced through their env vars as
+// well, as they can only change along with the volumes of the pods. The providers of the signals whose exporter is
+// set to none by the env of the language or of the Instrumentation are left out.
+func Render(inst v1alpha1.Instrumentation, lang v1alpha1.InstrumentationLanguage) (string, error) {
+	cfg := configuration{
+		FileFormat: fileFormat,
+		Resource: resource{
+			Attributes:     []attribute{{Name: "service.name", Value: envRef(constants.EnvOTELServiceName)}},
+			AttributesList: envRef(constants.EnvOTELResourceAttrs),
+		},
+	}
+	if len(inst.Spec.Propagators) > 0 {
+		cfg.Propagator = &propagator{}
+		for _, p := range inst.Spec.Propagators {
+			cfg.Propagator.Composite = append(cfg.Propagator.Composite, string(p))
+		}
+	}
+	s, err := newSampler(inst.Spec.Sampler)
+	if err != nil {
+		return "", err
+	}
+
+	if signalEnabled(inst, lang, envTracesExporter) {
+		cfg.TracerProvider = &tracerProvider{
+			Processors: []batchProcessor{{Batch: newExporter(inst.Spec.Exporter, "traces")}},
+			Sampler:    s,
+		}
+	}
+	if signalEnabled(inst, lang, envMetricsExporter) {
+		cfg.MeterProvider = &meterProvider{Readers: []periodicReader{{Periodic: newExporter(inst.Spec.Exporter, "metrics")}}}
+	}
+	if signalEnabled(inst, lang, envLogsExporter) {
+		cfg.LoggerProvider = &loggerProvider{Processors: []batchProcessor{{Batch: newExporter(inst.Spec.Exporter, "logs")}}}
+	}
+
+	var out strings.Builder
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg); err != nil {
+		return "", fmt.Errorf("failed to marshal the SDK configuration file: %w", err)
+	}
+	return out.String(), nil
+}
+
+// languageEnv returns the env vars the Instrumentation defines for a language. They take precedence over the env vars
+// of the Instrumentation, as in the instrumented containers.
+func languageEnv(inst v1alpha1.Instrumentation, lang v1alpha1.InstrumentationLanguage) []corev1.EnvVar {
+	switch lang {
+	case v1alpha1.LanguageJava:
+		return inst.Spec.Java.Env
+	case v1alpha1.LanguageNodeJS:
+		return inst.Spec.NodeJS.Env
+	case v1alpha1.LanguagePython:
+		return inst.Spec.Python.Env
+	case v1alpha1.LanguageDotNet:
+		return inst.Spec.DotNet.Env
+	case v1alpha1.LanguageGo:
+		return inst.Spec.Go.Env
+	case v1alpha1.LanguageApacheHttpd:
+		return inst.Spec.ApacheHttpd.Env
+	case v1alpha1.LanguageNginx:
+		return inst.Spec.Nginx.Env
+	case v1alpha1.LanguageRuby:
+		return inst.Spec.Ruby.Env
+	case v1alpha1.LanguagePHP:
+		return inst.Spec.PHP.Env
+	}
+	return nil
+}
+
+// signalEnabled returns whether the effective env var setting the exporter of a signal for a language doesn't disable
+// it.
+func signalEnabled(inst v1alpha1.Instrumentation, lang v1alpha1.InstrumentationLanguage, name string) bool {
+	for _, env := range [][]corev1.EnvVar{languageEnv(inst, lang), inst.Spec.Env} {
+		for _, e := range env {
+			if e.Name == name {
+				return strings.TrimSpace(e.Value) != exporterNone
+			}
+		}
+	}
+	return true
+}
+
+// newExporter returns the OTLP exporter of a signal. Without an explicit protocol, the endpoint is used with gRPC when
+// it's on the OTLP gRPC port, otherwise with HTTP. The signal path is appended to an HTTP endpoint without one, unless
+// the endpoint is specific to the signal.
+func newExporter(spec v1alpha1.Exporter, signal string) exporter {
+	e := exporter{}
+	endpoint, signalEndpoint := spec.Endpoint, ""
+	switch signal {
+	case "traces":
+		signalEndpoint = spec.TracesEndpoint
+	case "metrics":
+		signalEndpoint = spec.MetricsEndpoint
+	case "logs":
+		signalEndpoint = spec.LogsEndpoint
+	}
+	if signalEndpoint != "" {
+		endpoint = signalEndpoint
+	}
+
+	e.Exporter.OTLP.Protocol = string(spec.Protocol)
+	u, err := url.Parse(endpoint)
+	if e.Exporter.OTLP.Protocol == "" {
+		e.Exporter.OTLP.Protocol = string(v1alpha1.ExporterProtocolHTTPProtobuf)
+		if endpoint != "" && err == nil && u.Port() == otlpGRPCPort {
+			e.Exporter.OTLP.Protocol = string(v1alpha1.ExporterProtocolGRPC)
+		}
+	}
+	e.Exporter.OTLP.Endpoint = endpoint
+	if endpoint != "" && signalEndpoint == "" && err == nil && e.Exporter.OTLP.Protocol != string(v1alpha1.ExporterProtocolGRPC) && strings.Trim(u.Path, "/") == "" {
+		e.Exporter.OTLP.Endpoint = strings.TrimSuffix(endpoint, "/") + "/v1/" + signal
+	}
+
+	if spec.TLS != nil {
+		if spec.TLS.CA != "" {
+			e.Exporter.OTLP.Certificate = envRef(constants.EnvOTELExporterCertificate)
+		}
+		if spec.TLS.Cert != "" {
+			e.Exporter.OTLP.ClientCertificate = envRef(constants.EnvOTELExporterClientCertificate)
+		}
+		if spec.TLS.Key != "" {
+			e.Exporter.OTLP.ClientKey = envRef(constants.EnvOTELExporterClientKey)
+		}
+	}
+	for _, header := range spec.Headers {
+		value := header.Value
+		if header.SecretKeyRef != nil {
+			value = envRef(header.EnvName())
+		}
+		e.Exporter.OTLP.Headers = append(e.Exporter.OTLP.Headers, attribute{Name: header.Name, Value: value})
+	}
+	e.Exporter.OTLP.Compression = string(spec.Compression)
+	if spec.Timeout != nil {
+		e.Exporter.OTLP.Timeout = spec.Timeout.Milliseconds()
+	}
+	return e
+}
+
+// newSampler returns the sampler of the configuration file, or nil when the Instrumentation leaves it to the SDKs.
+func newSampler(spec v1alpha1.Sampler) (*sampler, error) {
+	ratio := func() (*traceIDRatioBased, error) {
+		if spec.Argument == "" {
+			return &traceIDRatioBased{Ratio: 1}, nil
+		}
+		value, err := strconv.ParseFloat(spec.Argument, 64)
+		if err != nil {
+			return nil, fmt.Errorf("the sampler argument is not a number: %s", spec.Argument)
+		}
+		return &traceIDRatioBased{Ratio: value}, nil
+	}
+
+	switch spec.Type {
+	case "":
+		return nil, nil
+	case v1alpha1.AlwaysOn:
+		return &sampler{AlwaysOn: &struct{}{}}, nil
+	case v1alpha1.AlwaysOff:
+		return &sampler{AlwaysOff: &struct{}{}}, nil
+	case v1alpha1.TraceIDRatio:
+		r, err := ratio()
+		if err != nil {
+			return nil, err
+		}
+		return &sampler{TraceIDRatioBased: r}, nil
+	case v1alpha1.ParentBasedAlwaysOn:
+		return &sampler{ParentBased: &parentBased{Root: sampler{AlwaysOn: &struct{}{}}}}, nil
+	case v1alpha1.ParentBasedAlwaysOff:
+		return &sampler{ParentBased: &parentBased{Root: sampler{AlwaysOff: &struct{}{}}}}, nil
+	case v1alpha1.ParentBasedTraceIDRatio:
+		r, err := ratio()
+		if err != nil {
+			return nil, err
+		}
+		return &sampler{ParentBased: &parentBased{Root: sampler{TraceIDRatioBased: r}}}, nil
+	default:
+		return nil, fmt.Errorf("the sampler %s is not supported in the SDK configuration file", spec.Type)
+	}
+}
+
+func envRef(name string) string {
+	return fmt.Sprintf("${%s}", name)
+}
@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdkconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestRender(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-inst"},
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{
+				Endpoint: "https://collector:4318",
+				TLS:      &v1alpha1.TLS{SecretName: "certs", CA: "ca.crt"},
+			},
+			Propagators: []v1alpha1.Propagator{v1alpha1.TraceContext, v1alpha1.Baggage},
+			Sampler:     v1alpha1.Sampler{Type: v1alpha1.ParentBasedTraceIDRatio, Argument: "0.25"},
+		},
+	}
+
+	file, err := Render(inst, v1alpha1.LanguageJava)
+	require.NoError(t, err)
+	assert.Equal(t, `file_format: "0.3"
+resource:
+  attributes:
+    - name: service.name
+      value: ${OTEL_SERVICE_NAME}
+  attributes_list: ${OTEL_RESOURCE_ATTRIBUTES}
+propagator:
+  composite:
+    - tracecontext
+    - baggage
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp:
+            protocol: http/protobuf
+            endpoint: https://collector:4318/v1/traces
+            certificate: ${OTEL_EXPORTER_OTLP_CERTIFICATE}
+  sampler:
+    parent_based:
+      root:
+        trace_id_ratio_based:
+          ratio: 0.25
+meter_provider:
+  readers:
+    - periodic:
+        exporter:
+          otlp:
+            protocol: http/protobuf
+            endpoint: https://collector:4318/v1/metrics
+            certificate: ${OTEL_EXPORTER_OTLP_CERTIFICATE}
+logger_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp:
+            protocol: http/protobuf
+            endpoint: https://collector:4318/v1/logs
+            certificate: ${OTEL_EXPORTER_OTLP_CERTIFICATE}
+`, file)
+
+	configMap, err := ConfigMap(inst)
+	require.NoError(t, err)
+	assert.Equal(t, "my-inst-sdk-config", configMap.Name)
+	assert.Equal(t, "my-ns", configMap.Namespace)
+	assert.Equal(t, map[string]string{FileName: file}, configMap.Data)
+}
+
+func TestRenderDisabledSignals(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-inst"},
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"},
+			Env: []corev1.EnvVar{
+				{Name: "OTEL_METRICS_EXPORTER", Value: "none"},
+				{Name: "OTEL_LOGS_EXPORTER", Value: "none"},
+			},
+			Python: v1alpha1.Python{
+				Env: []corev1.EnvVar{{Name: "OTEL_LOGS_EXPORTER", Value: "otlp"}},
+			},
+			Java: v1alpha1.Java{
+				Env: []corev1.EnvVar{{Name: "OTEL_TRACES_EXPORTER", Value: "none"}},
+			},
+		},
+	}
+
+	file, err := Render(inst, v1alpha1.LanguageNodeJS)
+	require.NoError(t, err)
+	assert.Contains(t, file, "tracer_provider:")
+	assert.NotContains(t, file, "meter_provider:")
+	assert.NotContains(t, file, "logger_provider:")
+
+	file, err = Render(inst, v1alpha1.LanguagePython)
+	require.NoError(t, err)
+	assert.Contains(t, file, "tracer_provider:")
+	assert.NotContains(t, file, "meter_provider:")
+	assert.Contains(t, file, "logger_provider:")
+
+	file, err = Render(inst, v1alpha1.LanguageJava)
+	require.NoError(t, err)
+	assert.NotContains(t, file, "tracer_provider:")
+	assert.NotContains(t, file, "meter_provider:")
+	assert.NotContains(t, file, "logger_provider:")
+
+	assert.Equal(t, FileName, FileNameFor(inst, v1alpha1.LanguageNodeJS))
+	assert.Equal(t, "sdk-config-python.yaml", FileNameFor(inst, v1alpha1.LanguagePython))
+	assert.Equal(t, "sdk-config-java.yaml", FileNameFor(inst, v1alpha1.LanguageJava))
+
+	configMap, err := ConfigMap(inst)
+	require.NoError(t, err)
+	assert.Len(t, configMap.Data, 3)
+	assert.Contains(t, configMap.Data["sdk-config-python.yaml"], "logger_provider:")
+	assert.NotContains(t, configMap.Data[FileName], "logger_provider:")
+}
+
+func TestNewExporter(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter v1alpha1.Exporter
+		expected otlp
+	}{
+		{
+			name:     "no endpoint",
+			expected: otlp{Protocol: "http/protobuf"},
+		},
+		{
+			name:     "grpc port",
+			exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"},
+			expected: otlp{Protocol: "grpc", Endpoint: "http://collector:4317"},
+		},
+		{
+			name: "explicit protocol, headers, compression and timeout",
+			exporter: v1alpha1.Exporter{
+				Endpoint:    "http://collector:4317",
+				Protocol:    v1alpha1.ExporterProtocolHTTPProtobuf,
+				Compression: v1alpha1.ExporterCompressionGzip,
+				Timeout:     &metav1.Duration{Duration: 3 * time.Second},
+				Headers: []v1alpha1.ExporterHeader{
+					{Name: "x-tenant", Value: "shop"},
+					{Name: "Authorization", SecretKeyRef: &corev1.SecretKeySelector{Key: "token"}},
+				},
+			},
+			expected: otlp{
+				Protocol:    "http/protobuf",
+				Endpoint:    "http://collector:4317/v1/traces",
+				Compression: "gzip",
+				Timeout:     3000,
+				Headers: []attribute{
+					{Name: "x-tenant", Value: "shop"},
+					{Name: "Authorization", Value: "${OTEL_EXPORTER_OTLP_HEADER_AUTHORIZATION}"},
+				},
+			},
+		},
+		{
+			name: "signal endpoint",
+			exporter: v1alpha1.Exporter{
+				Endpoint:       "http://collector:4318",
+				TracesEndpoint: "http://traces:4318",
+			},
+			expected: otlp{Protocol: "http/protobuf", Endpoint: "http://traces:4318"},
+		},
+		{
+			name:     "http endpoint with a path",
+			exporter: v1alpha1.Exporter{Endpoint: "http://collector:4318/custom/traces"},
+			expected: otlp{Protocol: "http/protobuf", Endpoint: "http://collector:4318/custom/traces"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := newExporter(test.exporter, "traces")
+			assert.Equal(t, test.expected, e.Exporter.OTLP)
+		})
+	}
+}
+
+func TestNewSampler(t *testing.T) {
+	tests := []struct {
+		name     string
+		sampler  v1alpha1.Sampler
+		expected *sampler
+		err      string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:     "always on",
+			sampler:  v1alpha1.Sampler{Type: v1alpha1.AlwaysOn},
+			expected: &sampler{AlwaysOn: &struct{}{}},
+		},
+		{
+			name:     "parent based always off",
+			sampler:  v1alpha1.Sampler{Type: v1alpha1.ParentBasedAlwaysOff},
+			expected: &sampler{ParentBased: &parentBased{Root: sampler{AlwaysOff: &struct{}{}}}},
+		},
+		{
+			name:     "trace id ratio without argument",
+			sampler:  v1alpha1.Sampler{Type: v1alpha1.TraceIDRatio},
+			expected: &sampler{TraceIDRatioBased: &traceIDRatioBased{Ratio: 1}},
+		},
+		{
+			name:    "invalid ratio",
+			sampler: v1alpha1.Sampler{Type: v1alpha1.TraceIDRatio, Argument: "abc"},
+			err:     "the sampler argument is not a number: abc",
+		},
+		{
+			name:    "unsupported sampler",
+			sampler: v1alpha1.Sampler{Type: v1alpha1.XRaySampler},
+			err:     "the sampler xray is not supported in the SDK configuration file",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := newSampler(test.sampler)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, s)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// AnnotationAllowedInstrumentations opts a namespace in to the Instrumentations of other namespaces. It's a comma
+// separated list of <namespace>/<name> entries, <namespace>/* allowing all the Instrumentations of a namespace.
+const AnnotationAllowedInstrumentations = "instrumentation.opentelemetry.io/allowed-instrumentations"
+
+// languageAnnotations maps the languages of an Instrumentation selector to the annotation requesting them.
+var languageAnnotations = map[v1alpha1.InstrumentationLanguage]string{
+	v1alpha1.LanguageJava:        annotationInjectJava,
+	v1alpha1.LanguageNodeJS:      annotationInjectNodeJS,
+	v1alpha1.LanguagePython:      annotationInjectPython,
+	v1alpha1.LanguageDotNet:      annotationInjectDotNet,
+	v1alpha1.LanguageGo:          annotationInjectGo,
+	v1alpha1.LanguageApacheHttpd: annotationInjectApacheHttpd,
+	v1alpha1.LanguageNginx:       annotationInjectNginx,
+	v1alpha1.LanguageRuby:        annotationInjectRuby,
+	v1alpha1.LanguagePHP:         annotationInjectPHP,
+	v1alpha1.LanguageSdk:         annotationInjectSdk,
+}
+
+// getSelectingInstrumentations returns the Instrumentations selecting the pod through their selector, keyed by the
+// inject annotation of the language they're selected for.
+func (pm *instPodMutator) getSelectingInstrumentations(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (map[string]*v1alpha1.Instrumentation, error) {
+	var otelInsts v1alpha1.InstrumentationList
+	if err := pm.Client.List(ctx, &otelInsts); err != nil {
+		return nil, err
+	}
+	return selectInstrumentations(otelInsts.Items, ns, pod), nil
+}
+
+// selectInstrumentations returns, for every language, the Instrumentation selecting the pod. When several
+// Instrumentations select the pod for the same language, the one in the namespace of the pod is preferred, then the
+// first one ordered by namespace and name.
+func selectInstrumentations(insts []v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod) map[string]*v1alpha1.Instrumentation {
+	var candidates []*v1alpha1.Instrumentation
+	for i := range insts {
+		if selects(insts[i], ns, pod) {
+			candidates = append(candidates, &insts[i])
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		iLocal, jLocal := candidates[i].Namespace == ns.Name, candidates[j].Namespace == ns.Name
+		if iLocal != jLocal {
+			return iLocal
+		}
+		if candidates[i].Namespace != candidates[j].Namespace {
+			return candidates[i].Namespace < candidates[j].Namespace
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	selected := map[string]*v1alpha1.Instrumentation{}
+	for _, inst := range candidates {
+		for _, language := range inst.Spec.Selector.Languages {
+			annotation, ok := languageAnnotations[language]
+			if !ok {
+				continue
+			}
+			if _, exists := selected[annotation]; !exists {
+				selected[annotation] = inst
+			}
+		}
+	}
+	return selected
+}
+
+func selects(inst v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod) bool {
+	if !SelectsNamespace(inst, ns) {
+		return false
+	}
+	selector := inst.Spec.Selector
+	return selector.PodSelector == nil || matchesLabels(selector.PodSelector, pod.Labels)
+}
+
+// SelectsNamespace returns whether the selector of the Instrumentation may select pods in the namespace.
+func SelectsNamespace(inst v1alpha1.Instrumentation, ns corev1.Namespace) bool {
+	selector := inst.Spec.Selector
+	if selector == nil {
+		return false
+	}
+	if selector.NamespaceSelector == nil {
+		return inst.Namespace == ns.Name
+	}
+	return matchesLabels(selector.NamespaceSelector, ns.Labels) && AllowedIn(inst, ns)
+}
+
+// AllowedIn returns whether the Instrumentation may affect the workloads of the namespace: always in its own
+// namespace, and in other namespaces only when they list it in their AnnotationAllowedInstrumentations annotation.
+// This keeps an Instrumentation from instrumenting, or restarting, the workloads of other tenants.
+func AllowedIn(inst v1alpha1.Instrumentation, ns corev1.Namespace) bool {
+	if inst.Namespace == ns.Name {
+		return true
+	}
+	for _, entry := range strings.Split(ns.Annotations[AnnotationAllowedInstrumentations], ",") {
+		namespace, name, ok := strings.Cut(strings.TrimSpace(entry), "/")
+		if ok && namespace == inst.Namespace && (name == "*" || name == inst.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesLabels(selector *metav1.LabelSelector, set map[string]string) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		// invalid selectors are rejected by the Instrumentation webhook, they don't select anything
+		return false
+	}
+	return s.Matches(labels.Set(set))
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestSelectInstrumentations(t *testing.T) {
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "shop",
+			Labels:      map[string]string{"team": "checkout"},
+			Annotations: map[string]string{AnnotationAllowedInstrumentations: "observability/java, a/*,b/java"},
+		},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"app": "cart"},
+		},
+	}
+	inst := func(namespace, name string, selector *v1alpha1.InstrumentationSelector) v1alpha1.Instrumentation {
+		return v1alpha1.Instrumentation{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       v1alpha1.InstrumentationSpec{Selector: selector},
+		}
+	}
+
+	for _, tt := range []struct {
+		desc     string
+		insts    []v1alpha1.Instrumentation
+		expected map[string]string
+	}{
+		{
+			desc: "no selector",
+			insts: []v1alpha1.Instrumentation{
+				inst("shop", "default", nil),
+			},
+			expected: map[string]string{},
+		},
+		{
+			desc: "pod selector in the pod namespace",
+			insts: []v1alpha1.Instrumentation{
+				inst("shop", "java", &v1alpha1.InstrumentationSelector{
+					PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "cart"}},
+					Languages:   []v1alpha1.InstrumentationLanguage{v1alpha1.LanguageJava, v1alpha1.LanguageSdk},
+				}),
+				inst("shop", "python", &v1alpha1.InstrumentationSelector{
+					PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "payment"}},
+					Languages:   []v1alpha1.InstrumentationLanguage{v1alpha1.LanguagePython},
+				}),
+			},
+			expected: map[string]string{
+				annotationInjectJava: "shop/java",
+				annotationInjectSdk:  "shop/java",
+			},
+		},
+		{
+			desc: "no namespace selector only selects the own namespace",
+			insts: []v1alpha1.Instrumentation{
+				inst("observability", "java", &v1alpha1.InstrumentationSelector{
+					Languages: []v1alpha1.InstrumentationLanguage{v1alpha1.LanguageJava},
+				}),
+			},
+			expected: map[string]string{},
+		},
+		{
+			desc: "namespace selector",
+			insts: []v1alpha1.Instrumentation{
+				inst("observability", "java", &v1alpha1.InstrumentationSelector{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "checkout"}},
+					Languages:         []v1alpha1.InstrumentationLanguage{v1alpha1.LanguageJava},
+				}),
+				inst("observability", "nodejs", &v1alpha1.InstrumentationSelector{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "search"}},
+					Languages:         []v1alpha1.InstrumentationLanguage{v1alpha1.LanguageNodeJS},
+				}),
+			},
+			expected: map[string]string{
+				annotationInjectJava: "observability/java",
+			},
+		},
+		{
+			desc: "namespace selector without the namespace opt-in",
+			insts: []v1alpha1.Instrumentation{
+				inst("observability", "python", &v1alpha1.InstrumentationSelector{
+					NamespaceSelector: &metav1.LabelSelector{},
+					Languages:         []v1alpha1.InstrumentationLanguage{v1alpha1.LanguagePython},
+				}),
+				inst("c", "java", &v1alpha1.InstrumentationSelector{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "checkout"}},
+					Languages:         []v1alpha1.InstrumentationLanguage{v1alpha1.LanguageJava},
+				}),
+			},
+			expected: map[string]string{},
+		},
+		{
+			desc: "pod namespace wins, then namespace and name order",
+			insts: []v1alpha1.Instrumentation{
+				inst("b", "java", &v1alpha1.InstrumentationSelector{
+					NamespaceSelector: &metav1.LabelSelector{},
+					Languages:         []v1alpha1.InstrumentationLanguage{v1alpha1.LanguageJava, v1alpha1.LanguageGo},
+				}),
+				inst("a", "go", &v1alpha1.InstrumentationSelector{
+					NamespaceSelector: &metav1.LabelSelector{},
+					Languages:         []v1alpha1.InstrumentationLanguage{v1alpha1.LanguageJava, v1alpha1.LanguageGo},
+				}),
+				inst("shop", "local", &v1alpha1.InstrumentationSelector{
+					Languages: []v1alpha1.InstrumentationLanguage{v1alpha1.LanguageJava},
+				}),
+			},
+			expected: map[string]string{
+				annotationInjectJava: "shop/local",
+				annotationInjectGo:   "a/go",
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			selected := selectInstrumentations(tt.insts, ns, pod)
+			actual := map[string]string{}
+			for annotation, inst := range selected {
+				actual[annotation] = inst.Namespace + "/" + inst.Name
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestAllowedIn(t *testing.T) {
+	inst := v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Namespace: "observability", Name: "java"}}
+	for _, tt := range []struct {
+		desc     string
+		ns       corev1.Namespace
+		expected bool
+	}{
+		{
+			desc:     "own namespace",
+			ns:       corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "observability"}},
+			expected: true,
+		},
+		{
+			desc: "no opt-in",
+			ns:   corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		},
+		{
+			desc: "opt-in by name",
+			ns: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Annotations: map[string]string{
+				AnnotationAllowedInstrumentations: "platform/*, observability/java",
+			}}},
+			expected: true,
+		},
+		{
+			desc: "opt-in by namespace",
+			ns: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Annotations: map[string]string{
+				AnnotationAllowedInstrumentations: "observability/*",
+			}}},
+			expected: true,
+		},
+		{
+			desc: "opt-in for another Instrumentation",
+			ns: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Annotations: map[string]string{
+				AnnotationAllowedInstrumentations: "observability/python,java",
+			}}},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, AllowedIn(inst, tt.ns))
+		})
+	}
+}
@@ -44,6 +44,8 @@ type InstrumentationUpgrade struct {
 	DefaultAutoInstApacheHttpd string
 	DefaultAutoInstNginx       string
 	DefaultAutoInstGo          string
+	DefaultAutoInstRuby        string
+	DefaultAutoInstPHP         string
 	defaultAnnotationToConfig  map[string]autoInstConfig
 }
 
@@ -56,6 +58,8 @@ func NewInstrumentationUpgrade(client client.Client, logger logr.Logger, recorde
 		constants.AnnotationDefaultAutoInstrumentationPython:      {constants.FlagPython, cfg.EnablePythonAutoInstrumentation()},
 		constants.AnnotationDefaultAutoInstrumentationNodeJS:      {constants.FlagNodeJS, cfg.EnableNodeJSAutoInstrumentation()},
 		constants.AnnotationDefaultAutoInstrumentationJava:        {constants.FlagJava, cfg.EnableJavaAutoInstrumentation()},
+		constants.AnnotationDefaultAutoInstrumentationRuby:        {constants.FlagRuby, cfg.EnableRubyAutoInstrumentation()},
+		constants.AnnotationDefaultAutoInstrumentationPHP:         {constants.FlagPHP, cfg.EnablePHPAutoInstrumentation()},
 	}
 
 	return &InstrumentationUpgrade{
@@ -68,6 +72,8 @@ func NewInstrumentationUpgrade(client client.Client, logger logr.Logger, recorde
 		DefaultAutoInstGo:          cfg.AutoInstrumentationGoImage(),
 		DefaultAutoInstApacheHttpd: cfg.AutoInstrumentationApacheHttpdImage(),
 		DefaultAutoInstNginx:       cfg.AutoInstrumentationNginxImage(),
+		DefaultAutoInstRuby:        cfg.AutoInstrumentationRubyImage(),
+		DefaultAutoInstPHP:         cfg.AutoInstrumentationPHPImage(),
 		Recorder:                   recorder,
 		defaultAnnotationToConfig:  defaultAnnotationToConfig,
 	}
@@ -144,6 +150,16 @@ func (u *InstrumentationUpgrade) upgrade(_ context.Context, inst v1alpha1.Instru
 						upgraded.Spec.Java.Image = u.DefaultAutoInstJava
 						upgraded.Annotations[annotation] = u.DefaultAutoInstJava
 					}
+				case constants.AnnotationDefaultAutoInstrumentationRuby:
+					if inst.Spec.Ruby.Image == autoInst {
+						upgraded.Spec.Ruby.Image = u.DefaultAutoInstRuby
+						upgraded.Annotations[annotation] = u.DefaultAutoInstRuby
+					}
+				case constants.AnnotationDefaultAutoInstrumentationPHP:
+					if inst.Spec.PHP.Image == autoInst {
+						upgraded.Spec.PHP.Image = u.DefaultAutoInstPHP
+						upgraded.Annotations[annotation] = u.DefaultAutoInstPHP
+					}
 				}
 
 			} else {
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// injection records what was injected into a pod template, so that it can be reverted without undoing the changes
+// made to the workload since.
+type injection struct {
+	// InitContainers, Containers and Volumes list the names of the added items.
+	InitContainers []string `json:"initContainers,omitempty"`
+	Containers     []string `json:"containers,omitempty"`
+	Volumes        []string `json:"volumes,omitempty"`
+	// ContainerChanges lists the changes to the containers of the workload.
+	ContainerChanges []containerChange `json:"containerChanges,omitempty"`
+	// ShareProcessNamespace is set when the injection changed the process namespace sharing of the pod.
+	ShareProcessNamespace *shareProcessNamespace `json:"shareProcessNamespace,omitempty"`
+	// Labels and Annotations hold the original value of the changed pod labels and annotations, nil for the added ones.
+	Labels      map[string]*string `json:"labels,omitempty"`
+	Annotations map[string]*string `json:"annotations,omitempty"`
+}
+
+type containerChange struct {
+	Name string `json:"name"`
+	// Env holds the original environment variables that were changed, nil for the added ones.
+	Env map[string]*corev1.EnvVar `json:"env,omitempty"`
+	// VolumeMounts lists the names of the added volume mounts.
+	VolumeMounts []string `json:"volumeMounts,omitempty"`
+}
+
+type shareProcessNamespace struct {
+	Original *bool `json:"original"`
+}
+
+// newInjection records the differences between the original pod template and the injected one.
+func newInjection(original, injected corev1.PodTemplateSpec) injection {
+	inj := injection{
+		InitContainers: addedContainers(original.Spec.InitContainers, injected.Spec.InitContainers),
+		Containers:     addedContainers(original.Spec.Containers, injected.Spec.Containers),
+		Labels:         changedValues(original.Labels, injected.Labels),
+		Annotations:    changedValues(original.Annotations, injected.Annotations),
+	}
+	for _, volume := range injected.Spec.Volumes {
+		if !slices.ContainsFunc(original.Spec.Volumes, func(v corev1.Volume) bool { return v.Name == volume.Name }) {
+			inj.Volumes = append(inj.Volumes, volume.Name)
+		}
+	}
+	if !equality.Semantic.DeepEqual(original.Spec.ShareProcessNamespace, injected.Spec.ShareProcessNamespace) {
+		inj.ShareProcessNamespace = &shareProcessNamespace{Original: original.Spec.ShareProcessNamespace}
+	}
+
+	for _, container := range original.Spec.Containers {
+		i := slices.IndexFunc(injected.Spec.Containers, func(c corev1.Container) bool { return c.Name == container.Name })
+		if i < 0 {
+			continue
+		}
+		change := containerChange{Name: container.Name}
+		for _, env := range injected.Spec.Containers[i].Env {
+			j := slices.IndexFunc(container.Env, func(e corev1.EnvVar) bool { return e.Name == env.Name })
+			if j < 0 {
+				change.addEnv(env.Name, nil)
+			} else if !equality.Semantic.DeepEqual(container.Env[j], env) {
+				change.addEnv(env.Name, container.Env[j].DeepCopy())
+			}
+		}
+		for _, mount := range injected.Spec.Containers[i].VolumeMounts {
+			if !slices.ContainsFunc(container.VolumeMounts, func(m corev1.VolumeMount) bool { return m.Name == mount.Name }) {
+				change.VolumeMounts = append(change.VolumeMounts, mount.Name)
+			}
+		}
+		if change.Env != nil || change.VolumeMounts != nil {
+			inj.ContainerChanges = append(inj.ContainerChanges, change)
+		}
+	}
+	return inj
+}
+
+func (c *containerChange) addEnv(name string, original *corev1.EnvVar) {
+	if c.Env == nil {
+		c.Env = map[string]*corev1.EnvVar{}
+	}
+	c.Env[name] = original
+}
+
+// revert removes the injection from the pod template.
+func (inj injection) revert(template *corev1.PodTemplateSpec) {
+	template.Spec.InitContainers = removeContainers(template.Spec.InitContainers, inj.InitContainers)
+	template.Spec.Containers = removeContainers(template.Spec.Containers, inj.Containers)
+	template.Spec.Volumes = slices.DeleteFunc(template.Spec.Volumes, func(v corev1.Volume) bool {
+		return slices.Contains(inj.Volumes, v.Name)
+	})
+	if inj.ShareProcessNamespace != nil {
+		template.Spec.ShareProcessNamespace = inj.ShareProcessNamespace.Original
+	}
+	template.Labels = revertValues(template.Labels, inj.Labels)
+	template.Annotations = revertValues(template.Annotations, inj.Annotations)
+
+	for _, change := range inj.ContainerChanges {
+		i := slices.IndexFunc(template.Spec.Containers, func(c corev1.Container) bool { return c.Name == change.Name })
+		if i < 0 {
+			continue
+		}
+		container := &template.Spec.Containers[i]
+		container.Env = slices.DeleteFunc(container.Env, func(e corev1.EnvVar) bool {
+			original, changed := change.Env[e.Name]
+			return changed && original == nil
+		})
+		for j, env := range container.Env {
+			if original := change.Env[env.Name]; original != nil {
+				container.Env[j] = *original
+			}
+		}
+		container.VolumeMounts = slices.DeleteFunc(container.VolumeMounts, func(m corev1.VolumeMount) bool {
+			return slices.Contains(change.VolumeMounts, m.Name)
+		})
+		if len(container.Env) == 0 {
+			container.Env = nil
+		}
+		if len(container.VolumeMounts) == 0 {
+			container.VolumeMounts = nil
+		}
+	}
+	if len(template.Spec.InitContainers) == 0 {
+		template.Spec.InitContainers = nil
+	}
+	if len(template.Spec.Volumes) == 0 {
+		template.Spec.Volumes = nil
+	}
+}
+
+func addedContainers(original, injected []corev1.Container) []string {
+	var added []string
+	for _, container := range injected {
+		if !slices.ContainsFunc(original, func(c corev1.Container) bool { return c.Name == container.Name }) {
+			added = append(added, container.Name)
+		}
+	}
+	return added
+}
+
+func removeContainers(containers []corev1.Container, names []string) []corev1.Container {
+	return slices.DeleteFunc(containers, func(c corev1.Container) bool {
+		return slices.Contains(names, c.Name)
+	})
+}
+
+func changedValues(original, injected map[string]string) map[string]*string {
+	var changed map[string]*string
+	for key, value := range injected {
+		originalValue, ok := original[key]
+		if ok && originalValue == value {
+			continue
+		}
+		if changed == nil {
+			changed = map[string]*string{}
+		}
+		if ok {
+			changed[key] = &originalValue
+		} else {
+			changed[key] = nil
+		}
+	}
+	return changed
+}
+
+func revertValues(values map[string]string, changed map[string]*string) map[string]string {
+	for key, original := range changed {
+		if original == nil {
+			delete(values, key)
+		} else if values != nil {
+			values[key] = *original
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
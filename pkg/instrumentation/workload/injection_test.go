@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInjectionRevert(t *testing.T) {
+	shareProcessNamespace := true
+	original := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"app": "my-app"},
+			Annotations: map[string]string{"instrumentation.opentelemetry.io/inject-go": "true"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "app",
+				Env: []corev1.EnvVar{
+					{Name: "KEEP", Value: "value"},
+					{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "team=a"},
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}},
+			}},
+			Volumes: []corev1.Volume{{Name: "data"}},
+		},
+	}
+	injected := *original.DeepCopy()
+	injected.Labels["instrumentation.opentelemetry.io/injected"] = "true"
+	injected.Spec.ShareProcessNamespace = &shareProcessNamespace
+	injected.Spec.InitContainers = []corev1.Container{{Name: "opentelemetry-auto-instrumentation-java"}}
+	injected.Spec.Containers = append(injected.Spec.Containers, corev1.Container{Name: "opentelemetry-auto-instrumentation"})
+	injected.Spec.Volumes = append(injected.Spec.Volumes, corev1.Volume{Name: "opentelemetry-auto-instrumentation-java"})
+	injected.Spec.Containers[0].Env = append([]corev1.EnvVar{{Name: "OTEL_NODE_IP"}}, injected.Spec.Containers[0].Env...)
+	injected.Spec.Containers[0].Env[2].Value = "team=a,k8s.pod.name=$(OTEL_POD_NAME)"
+	injected.Spec.Containers[0].VolumeMounts = append(injected.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "opentelemetry-auto-instrumentation-java"})
+
+	inj := newInjection(original, injected)
+	// the injection is stored in an annotation
+	value, err := json.Marshal(inj)
+	require.NoError(t, err)
+	var stored injection
+	require.NoError(t, json.Unmarshal(value, &stored))
+	assert.Equal(t, inj, stored)
+
+	// the changes made since the injection are kept
+	changed := *injected.DeepCopy()
+	changed.Labels["version"] = "2"
+	changed.Spec.Containers[0].Env = append(changed.Spec.Containers[0].Env, corev1.EnvVar{Name: "ADDED", Value: "later"})
+	stored.revert(&changed)
+
+	expected := *original.DeepCopy()
+	expected.Labels["version"] = "2"
+	expected.Spec.Containers[0].Env = append(expected.Spec.Containers[0].Env, corev1.EnvVar{Name: "ADDED", Value: "later"})
+	assert.Equal(t, expected, changed)
+}
@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package workload injects the auto-instrumentation directly into the pod templates of workloads, for clusters where
+// the pod mutation webhook can't be used.
+package workload
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+// AnnotationInjection records on a workload what was injected into its pod template, so that it can be reverted once
+// the pod template no longer requests an auto-instrumentation or its Instrumentation is removed.
+const AnnotationInjection = "instrumentation.opentelemetry.io/workload-injection"
+
+// Kind is a kind of workload whose pod template is injected.
+type Kind struct {
+	Name      string
+	NewObject func() client.Object
+	NewList   func() client.ObjectList
+}
+
+// Kinds lists the kinds of workloads whose pod template is injected.
+var Kinds = []Kind{
+	{
+		Name:      "Deployment",
+		NewObject: func() client.Object { return &appsv1.Deployment{} },
+		NewList:   func() client.ObjectList { return &appsv1.DeploymentList{} },
+	},
+	{
+		Name:      "StatefulSet",
+		NewObject: func() client.Object { return &appsv1.StatefulSet{} },
+		NewList:   func() client.ObjectList { return &appsv1.StatefulSetList{} },
+	},
+	{
+		Name:      "DaemonSet",
+		NewObject: func() client.Object { return &appsv1.DaemonSet{} },
+		NewList:   func() client.ObjectList { return &appsv1.DaemonSetList{} },
+	},
+}
+
+// Injector injects the auto-instrumentation into the pod templates of workloads, the same way the pod mutation webhook
+// injects it into pods.
+type Injector struct {
+	client  client.Client
+	scheme  *runtime.Scheme
+	mutator podmutation.PodMutator
+	logger  logr.Logger
+}
+
+// NewInjector creates an Injector. As the Instrumentation webhook may not be running either, the Instrumentations are
+// defaulted before being injected.
+func NewInjector(cl client.Client, scheme *runtime.Scheme, logger logr.Logger, recorder record.EventRecorder, cfg config.Config) *Injector {
+	defaulting := defaultingClient{
+		Client:    cl,
+		defaulter: v1alpha1.NewInstrumentationWebhook(logger, scheme, cfg),
+	}
+	return &Injector{
+		client:  cl,
+		scheme:  scheme,
+		mutator: instrumentation.NewMutator(logger, defaulting, recorder, cfg),
+		logger:  logger,
+	}
+}
+
+// Inject injects the auto-instrumentation requested by the pod template of the workload, after reverting the previous
+// injection. The workload is only updated when its pod template changes.
+func (i *Injector) Inject(ctx context.Context, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, i.scheme)
+	if err != nil {
+		return err
+	}
+	logger := i.logger.WithValues("kind", gvk.Kind, "namespace", obj.GetNamespace(), "name", obj.GetName())
+	changed := obj.DeepCopyObject().(client.Object)
+	template, err := PodTemplate(changed)
+	if err != nil {
+		return err
+	}
+
+	if value, ok := changed.GetAnnotations()[AnnotationInjection]; ok {
+		var previous injection
+		if err = json.Unmarshal([]byte(value), &previous); err != nil {
+			return fmt.Errorf("failed to parse the %s annotation: %w", AnnotationInjection, err)
+		}
+		previous.revert(template)
+	}
+
+	ns := corev1.Namespace{}
+	if err = i.client.Get(ctx, types.NamespacedName{Name: obj.GetNamespace()}, &ns); err != nil {
+		return err
+	}
+
+	var inj *injection
+	injected, err := i.mutator.Mutate(ctx, ns, templatePod(obj, gvk, *template))
+	switch {
+	case isTransient(err):
+		// keep the current pod template until the injection can be computed again
+		return err
+	case err != nil:
+		logger.Error(err, "failed to inject the auto-instrumentation into the pod template, reverting it")
+	default:
+		injectedTemplate := corev1.PodTemplateSpec{ObjectMeta: *template.ObjectMeta.DeepCopy(), Spec: injected.Spec}
+		injectedTemplate.Labels = injected.Labels
+		injectedTemplate.Annotations = injected.Annotations
+		if !equality.Semantic.DeepEqual(*template, injectedTemplate) {
+			recorded := newInjection(*template, injectedTemplate)
+			inj = &recorded
+			*template = injectedTemplate
+		}
+	}
+
+	annotations := changed.GetAnnotations()
+	if inj == nil {
+		delete(annotations, AnnotationInjection)
+	} else {
+		value, marshalErr := json.Marshal(inj)
+		if marshalErr != nil {
+			return marshalErr
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[AnnotationInjection] = string(value)
+	}
+	changed.SetAnnotations(annotations)
+
+	if equality.Semantic.DeepEqual(obj, changed) {
+		return nil
+	}
+	if inj == nil {
+		logger.Info("reverting the auto-instrumentation of the pod template")
+	} else {
+		logger.Info("injecting the auto-instrumentation into the pod template")
+	}
+	return i.client.Patch(ctx, changed, client.MergeFromWithOptions(obj, client.MergeFromWithOptimisticLock{}))
+}
+
+// templatePod returns the pod the mutator injects, the workload is set as its owner to compute the same resource
+// attributes as for its pods.
+func templatePod(obj client.Object, gvk schema.GroupVersionKind, template corev1.PodTemplateSpec) corev1.Pod {
+	pod := corev1.Pod{ObjectMeta: *template.ObjectMeta.DeepCopy(), Spec: *template.Spec.DeepCopy()}
+	pod.Namespace = obj.GetNamespace()
+	pod.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       obj.GetName(),
+		UID:        obj.GetUID(),
+	}}
+	return pod
+}
+
+// PodTemplate returns the pod template of the workload.
+func PodTemplate(obj client.Object) (*corev1.PodTemplateSpec, error) {
+	switch workload := obj.(type) {
+	case *appsv1.Deployment:
+		return &workload.Spec.Template, nil
+	case *appsv1.StatefulSet:
+		return &workload.Spec.Template, nil
+	case *appsv1.DaemonSet:
+		return &workload.Spec.Template, nil
+	default:
+		return nil, fmt.Errorf("unsupported workload %T", obj)
+	}
+}
+
+// isTransient returns whether the error may not happen again, the injection is then retried instead of reverted.
+func isTransient(err error) bool {
+	return apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) || apierrors.IsInternalError(err) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// defaultingClient defaults the Instrumentations it reads, as the Instrumentation webhook would have.
+type defaultingClient struct {
+	client.Client
+	defaulter *v1alpha1.InstrumentationWebhook
+}
+
+func (c defaultingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.Client.Get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	if inst, ok := obj.(*v1alpha1.Instrumentation); ok {
+		return c.defaulter.Default(ctx, inst)
+	}
+	return nil
+}
+
+func (c defaultingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	if insts, ok := list.(*v1alpha1.InstrumentationList); ok {
+		for idx := range insts.Items {
+			if err := c.defaulter.Default(ctx, &insts.Items[idx]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+const javaAnnotation = "instrumentation.opentelemetry.io/inject-java"
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func newDeployment(annotations map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-app"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "my-app"}, Annotations: annotations},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "app",
+						Image: "my-app:1.0",
+						Env:   []corev1.EnvVar{{Name: "JAVA_TOOL_OPTIONS", Value: "-Xmx1g"}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func newInjector(t *testing.T, objs ...client.Object) (*Injector, client.Client) {
+	scheme := newScheme(t)
+	objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-ns"}})
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	cfg := config.New(config.WithAutoInstrumentationJavaImage("java:default"))
+	return NewInjector(cl, scheme, logr.Discard(), record.NewFakeRecorder(10), cfg), cl
+}
+
+func inject(t *testing.T, injector *Injector, cl client.Client) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKey{Namespace: "my-ns", Name: "my-app"}, deployment))
+	require.NoError(t, injector.Inject(context.Background(), deployment))
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKey{Namespace: "my-ns", Name: "my-app"}, deployment))
+	return deployment
+}
+
+func TestInjectAndRevert(t *testing.T) {
+	inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "java"}}
+	original := newDeployment(map[string]string{javaAnnotation: "java"})
+	injector, cl := newInjector(t, inst, original.DeepCopy())
+
+	deployment := inject(t, injector, cl)
+	template := deployment.Spec.Template
+	require.Len(t, template.Spec.InitContainers, 1)
+	// the Instrumentation is defaulted as by its webhook
+	assert.Equal(t, "java:default", template.Spec.InitContainers[0].Image)
+	assert.Contains(t, template.Spec.Containers[0].Env, corev1.EnvVar{Name: "OTEL_SERVICE_NAME", Value: "my-app"})
+	assert.Contains(t, deployment.Annotations, AnnotationInjection)
+
+	// the injection is only applied once
+	resourceVersion := deployment.ResourceVersion
+	deployment = inject(t, injector, cl)
+	assert.Equal(t, resourceVersion, deployment.ResourceVersion)
+
+	// the pod template is reverted once the Instrumentation is removed, keeping the changes made to the workload since
+	deployment.Spec.Template.Spec.Containers[0].Image = "my-app:2.0"
+	require.NoError(t, cl.Update(context.Background(), deployment))
+	require.NoError(t, cl.Delete(context.Background(), inst))
+	deployment = inject(t, injector, cl)
+
+	expected := original.Spec.Template.DeepCopy()
+	expected.Spec.Containers[0].Image = "my-app:2.0"
+	assert.Equal(t, *expected, deployment.Spec.Template)
+	assert.NotContains(t, deployment.Annotations, AnnotationInjection)
+}
+
+func TestInjectRevertsRemovedAnnotation(t *testing.T) {
+	inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "java"}}
+	injector, cl := newInjector(t, inst, newDeployment(map[string]string{javaAnnotation: "true"}))
+
+	deployment := inject(t, injector, cl)
+	require.NotEmpty(t, deployment.Spec.Template.Spec.InitContainers)
+
+	delete(deployment.Spec.Template.Annotations, javaAnnotation)
+	require.NoError(t, cl.Update(context.Background(), deployment))
+	deployment = inject(t, injector, cl)
+
+	assert.Equal(t, newDeployment(nil).Spec.Template, deployment.Spec.Template)
+	assert.NotContains(t, deployment.Annotations, AnnotationInjection)
+}
+
+func TestInjectNotRequested(t *testing.T) {
+	injector, cl := newInjector(t, newDeployment(nil))
+
+	deployment := inject(t, injector, cl)
+
+	assert.Equal(t, newDeployment(nil).Spec.Template, deployment.Spec.Template)
+	assert.Empty(t, deployment.Annotations)
+}
@@ -25,29 +25,77 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 const (
-	injectedLabel = "sidecar.opentelemetry.io/injected"
+	injectedLabel = constants.LabelSidecarInjected
 	confEnvVar    = "OTEL_CONFIG"
+	// sidecarConfigDir is where the collector container mounts its configuration.
+	sidecarConfigDir = "/conf"
 )
 
 // add a new sidecar container to the given pod, based on the given OpenTelemetryCollector.
-func add(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector, pod corev1.Pod, attributes []corev1.EnvVar) (corev1.Pod, error) {
+func add(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector, ns corev1.Namespace, pod corev1.Pod, attributes []corev1.EnvVar) (corev1.Pod, error) {
+	// the hash of the collector configuration, before the sidecar template overrides
+	configHash, err := manifestutils.GetConfigMapSHA(otelcol.Spec.Config)
+	if err != nil {
+		return pod, err
+	}
+
+	overrides, err := templateOverrides(otelcol, ns)
+	if err != nil {
+		return pod, err
+	}
+	overriddenConfig := false
+	if len(overrides) > 0 {
+		otelcol = *otelcol.DeepCopy()
+		for _, override := range overrides {
+			if len(override.ExporterHeaders) > 0 && addExporterHeaders(&otelcol.Spec.Config, override.ExporterHeaders) {
+				overriddenConfig = true
+			}
+		}
+	}
+
 	otelColCfg, err := collector.ReplaceConfig(otelcol, nil)
 	if err != nil {
 		return pod, err
 	}
 
-	container := collector.Container(cfg, logger, otelcol, false)
-	container.Args = append(container.Args, fmt.Sprintf("--config=env:%s", confEnvVar))
+	var container corev1.Container
+	// the ConfigMap can only be mounted in the namespace of the collector, and holds the configuration without overrides
+	if featuregate.EnableSidecarConfigMap.IsEnabled() && otelcol.Namespace == ns.Name && !overriddenConfig {
+		// the configuration is mounted from the ConfigMap the collector controller updates in place
+		container = collector.Container(cfg, logger, otelcol, true)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: naming.ConfigMapVolume(),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: naming.SidecarConfigMap(otelcol.Name)},
+					Items: []corev1.KeyToPath{{
+						Key:  cfg.CollectorConfigMapEntry(),
+						Path: cfg.CollectorConfigMapEntry(),
+					}},
+				},
+			},
+		})
+		pod = addConfigReloader(cfg, logger, pod)
+	} else {
+		container = collector.Container(cfg, logger, otelcol, false)
+		container.Args = append(container.Args, fmt.Sprintf("--config=env:%s", confEnvVar))
+		container.Env = append(container.Env, corev1.EnvVar{Name: confEnvVar, Value: otelColCfg})
+	}
 
-	container.Env = append(container.Env, corev1.EnvVar{Name: confEnvVar, Value: otelColCfg})
 	if !hasResourceAttributeEnvVar(container.Env) {
 		container.Env = append(container.Env, attributes...)
 	}
+	for _, override := range overrides {
+		addResourceAttributes(&container, override.ResourceAttributes)
+	}
+	applyRecommendedResources(otelcol, ns, pod, &container)
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, otelcol.Spec.InitContainers...)
 
 	if featuregate.EnableNativeSidecarContainers.IsEnabled() {
@@ -66,25 +114,67 @@ func add(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCol
 		pod.Labels = map[string]string{}
 	}
 	pod.Labels[injectedLabel] = naming.Truncate("%s.%s", 63, otelcol.Namespace, otelcol.Name)
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[constants.AnnotationSidecarConfigHash] = configHash
 
 	return pod, nil
 }
 
+// addConfigReloader adds the container signaling the sidecar collector to reload its configuration once the kubelet
+// updated the mounted ConfigMap, when a reloader image is configured. The reloader needs to see the processes of the
+// collector container: it's only added to the pods already sharing their process namespace, which the operator
+// doesn't change on behalf of the application.
+func addConfigReloader(cfg config.Config, logger logr.Logger, pod corev1.Pod) corev1.Pod {
+	if cfg.SidecarConfigReloaderImage() == "" {
+		return pod
+	}
+	if pod.Spec.ShareProcessNamespace == nil || !*pod.Spec.ShareProcessNamespace {
+		logger.V(1).Info("skipping the sidecar config reloader, the pod doesn't share its process namespace")
+		return pod
+	}
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+		Name:  naming.SidecarConfigReloader(),
+		Image: cfg.SidecarConfigReloaderImage(),
+		Args: []string{
+			"--reload-method=signal",
+			fmt.Sprintf("--process-executable-name=%s", cfg.SidecarConfigReloaderProcessName()),
+			fmt.Sprintf("--watched-dir=%s", sidecarConfigDir),
+		},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      naming.ConfigMapVolume(),
+			MountPath: sidecarConfigDir,
+			ReadOnly:  true,
+		}},
+	})
+	return pod
+}
+
 func isOtelColContainer(c corev1.Container) bool { return c.Name == naming.Container() }
 
-// remove the sidecar container from the given pod.
+// remove the sidecar container from the given pod, along with its config reloader, ConfigMap volume, label and
+// annotation.
 func remove(pod corev1.Pod) corev1.Pod {
 	if !existsIn(pod) {
 		return pod
 	}
 
 	pod.Spec.Containers = slices.DeleteFunc(pod.Spec.Containers, isOtelColContainer)
+	pod.Spec.Containers = slices.DeleteFunc(pod.Spec.Containers, func(c corev1.Container) bool {
+		return c.Name == naming.SidecarConfigReloader()
+	})
 
 	if featuregate.EnableNativeSidecarContainers.IsEnabled() {
 		// NOTE: we also remove init containers (native sidecars) since k8s 1.28.
 		// This should have no side effects.
 		pod.Spec.InitContainers = slices.DeleteFunc(pod.Spec.InitContainers, isOtelColContainer)
 	}
+	pod.Spec.Volumes = slices.DeleteFunc(pod.Spec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == naming.ConfigMapVolume()
+	})
+	delete(pod.Labels, injectedLabel)
+	delete(pod.Annotations, constants.AnnotationSidecarConfigHash)
 	return pod
 }
 
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -81,7 +82,7 @@ func TestAddNativeSidecar(t *testing.T) {
 	cfg := config.New(config.WithCollectorImage("some-default-image"))
 
 	// test
-	changed, err := add(cfg, logger, otelcol, pod, nil)
+	changed, err := add(cfg, logger, otelcol, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "some-app"}}, pod, nil)
 
 	// verify
 	assert.NoError(t, err)
@@ -167,7 +168,7 @@ func TestAddSidecarWhenNoSidecarExists(t *testing.T) {
 	cfg := config.New(config.WithCollectorImage("some-default-image"))
 
 	// test
-	changed, err := add(cfg, logger, otelcol, pod, nil)
+	changed, err := add(cfg, logger, otelcol, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "some-app"}}, pod, nil)
 
 	// verify
 	assert.NoError(t, err)
@@ -220,7 +221,7 @@ func TestAddSidecarWhenOneExistsAlready(t *testing.T) {
 	cfg := config.New(config.WithCollectorImage("some-default-image"))
 
 	// test
-	changed, err := add(cfg, logger, otelcol, pod, nil)
+	changed, err := add(cfg, logger, otelcol, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "some-app"}}, pod, nil)
 
 	// verify
 	assert.NoError(t, err)
@@ -337,7 +338,7 @@ func TestAddSidecarWithAditionalEnv(t *testing.T) {
 	}
 
 	// test
-	changed, err := add(cfg, logger, otelcol, pod, []corev1.EnvVar{
+	changed, err := add(cfg, logger, otelcol, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "some-app"}}, pod, []corev1.EnvVar{
 		extraEnv,
 	})
 
@@ -347,3 +348,164 @@ func TestAddSidecarWithAditionalEnv(t *testing.T) {
 	assert.Contains(t, changed.Spec.Containers[1].Env, extraEnv)
 
 }
+
+func TestAddSidecarWithConfigMap(t *testing.T) {
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableSidecarConfigMap.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableSidecarConfigMap.ID(), false))
+	})
+
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+		},
+	}
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-sample",
+			Namespace: "some-app",
+		},
+	}
+
+	t.Run("without reloader", func(t *testing.T) {
+		cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+		changed, err := add(cfg, logger, otelcol, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "some-app"}}, pod, nil)
+
+		require.NoError(t, err)
+		require.Len(t, changed.Spec.Containers, 2)
+		assert.Equal(t, []string{"--config=/conf/collector.yaml"}, changed.Spec.Containers[1].Args)
+		assert.Equal(t, []corev1.VolumeMount{{Name: "otc-internal", MountPath: "/conf"}}, changed.Spec.Containers[1].VolumeMounts)
+		assert.Equal(t, []corev1.Volume{{
+			Name: "otc-internal",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "otelcol-sample-collector-sidecar"},
+					Items:                []corev1.KeyToPath{{Key: "collector.yaml", Path: "collector.yaml"}},
+				},
+			},
+		}}, changed.Spec.Volumes)
+		assert.NotEmpty(t, changed.Annotations["sidecar.opentelemetry.io/config-hash"])
+		assert.Nil(t, changed.Spec.ShareProcessNamespace)
+	})
+
+	t.Run("with reloader", func(t *testing.T) {
+		cfg := config.New(
+			config.WithCollectorImage("some-default-image"),
+			config.WithSidecarConfigReloaderImage("some-reloader-image"),
+		)
+		shareProcessNamespace := true
+		sharingPod := *pod.DeepCopy()
+		sharingPod.Spec.ShareProcessNamespace = &shareProcessNamespace
+
+		changed, err := add(cfg, logger, otelcol, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "some-app"}}, sharingPod, nil)
+
+		require.NoError(t, err)
+		require.Len(t, changed.Spec.Containers, 3)
+		assert.Equal(t, corev1.Container{
+			Name:  "otc-config-reloader",
+			Image: "some-reloader-image",
+			Args: []string{
+				"--reload-method=signal",
+				"--process-executable-name=otelcol",
+				"--watched-dir=/conf",
+			},
+			VolumeMounts: []corev1.VolumeMount{{Name: "otc-internal", MountPath: "/conf", ReadOnly: true}},
+		}, changed.Spec.Containers[1])
+
+		removed := remove(changed)
+		assert.Equal(t, []corev1.Container{{Name: "my-app"}}, removed.Spec.Containers)
+		assert.Empty(t, removed.Spec.Volumes)
+		assert.Equal(t, &shareProcessNamespace, removed.Spec.ShareProcessNamespace)
+		assert.Empty(t, removed.Labels)
+		assert.Empty(t, removed.Annotations)
+	})
+
+	t.Run("with reloader without shared process namespace", func(t *testing.T) {
+		cfg := config.New(
+			config.WithCollectorImage("some-default-image"),
+			config.WithSidecarConfigReloaderImage("some-reloader-image"),
+		)
+
+		changed, err := add(cfg, logger, otelcol, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "some-app"}}, pod, nil)
+
+		require.NoError(t, err)
+		require.Len(t, changed.Spec.Containers, 2)
+		assert.Nil(t, changed.Spec.ShareProcessNamespace)
+
+		removed := remove(changed)
+		assert.Equal(t, []corev1.Container{{Name: "my-app"}}, removed.Spec.Containers)
+		assert.Empty(t, removed.Spec.Volumes)
+	})
+}
+
+func TestAddSidecarWithRecommendedResources(t *testing.T) {
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableSidecarResourceRecommendations.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableSidecarResourceRecommendations.ID(), false))
+	})
+
+	controller := true
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:          map[string]string{"pod-template-hash": "5d8f7c"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "my-app-5d8f7c", Controller: &controller}},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "my-app"}},
+		},
+	}
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-sample",
+			Namespace: "some-app",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+				},
+			},
+			SidecarResources: &v1beta1.SidecarResourcesSpec{Mode: v1beta1.SidecarResourcesModeRecommend},
+		},
+		Status: v1beta1.OpenTelemetryCollectorStatus{
+			SidecarResources: []v1beta1.SidecarResourcesRecommendation{{
+				Kind:      "Deployment",
+				Namespace: "some-app",
+				Name:      "my-app",
+				Requests:  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32Mi")},
+				Limits:    corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+			}},
+		},
+	}
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "some-app"}}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	changed, err := add(cfg, logger, otelcol, ns, pod, nil)
+	require.NoError(t, err)
+	assert.Equal(t, otelcol.Spec.Resources, changed.Spec.Containers[1].Resources, "recommendations are only applied in Auto mode")
+
+	otelcol.Spec.SidecarResources.Mode = v1beta1.SidecarResourcesModeAuto
+	changed, err = add(cfg, logger, otelcol, ns, pod, nil)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("32Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+	}, changed.Spec.Containers[1].Resources)
+
+	otherWorkload := pod.DeepCopy()
+	otherWorkload.OwnerReferences[0].Name = "other-5d8f7c"
+	changed, err = add(cfg, logger, otelcol, ns, *otherWorkload, nil)
+	require.NoError(t, err)
+	assert.Equal(t, otelcol.Spec.Resources, changed.Spec.Containers[1].Resources)
+}
@@ -28,13 +28,16 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 var (
 	errMultipleInstancesPossible = errors.New("multiple OpenTelemetry Collector instances available, cannot determine which one to select")
 	errNoInstancesAvailable      = errors.New("no OpenTelemetry Collector instances available")
 	errInstanceNotSidecar        = errors.New("the OpenTelemetry Collector's mode is not set to sidecar")
+	errNamespaceNotAllowed       = errors.New("the OpenTelemetry Collector's sidecar template doesn't select the namespace of the pod")
 )
 
 type sidecarPodMutator struct {
@@ -54,7 +57,7 @@ func NewMutator(logger logr.Logger, config config.Config, client client.Client)
 }
 
 func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (corev1.Pod, error) {
-	logger := p.logger.WithValues("namespace", pod.Namespace, "name", pod.Name)
+	logger := podmutation.Logger(ctx, p.logger).WithValues("namespace", pod.Namespace, "name", pod.Name)
 
 	// if no annotations are found at all, just return the same pod
 	annValue := annotationValue(ns, pod)
@@ -79,7 +82,7 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 	// which instance should it talk to?
 	otelcol, err := p.getCollectorInstance(ctx, ns, annValue)
 	if err != nil {
-		if errors.Is(err, errMultipleInstancesPossible) || errors.Is(err, errNoInstancesAvailable) || errors.Is(err, errInstanceNotSidecar) {
+		if errors.Is(err, errMultipleInstancesPossible) || errors.Is(err, errNoInstancesAvailable) || errors.Is(err, errInstanceNotSidecar) || errors.Is(err, errNamespaceNotAllowed) {
 			// we still allow the pod to be created, but we log a message to the operator's logs
 			logger.Error(err, "failed to select an OpenTelemetry Collector instance for this pod's sidecar")
 			return pod, nil
@@ -89,6 +92,15 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 		return pod, err
 	}
 
+	if _, err = collector.ApplyConfigFragments(ctx, p.client, &otelcol); err != nil {
+		// we still allow the pod to be created, but without a sidecar whose config would be incomplete
+		logger.Error(err, "failed to apply the config fragments of the OpenTelemetry Collector instance for this pod's sidecar")
+		return pod, nil
+	}
+	if featuregate.EnableHealthCheckInjection.IsEnabled() {
+		otelcol.Spec.Config.InjectHealthCheck()
+	}
+
 	// getting pod references, if any
 	references := p.podReferences(ctx, pod.OwnerReferences, ns)
 	attributes := getResourceAttributesEnv(ns, references)
@@ -97,7 +109,7 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 	// we should add the sidecar.
 	logger.V(1).Info("injecting sidecar into pod", "otelcol-namespace", otelcol.Namespace, "otelcol-name", otelcol.Name)
 
-	return add(p.config, p.logger, otelcol, pod, attributes)
+	return add(p.config, p.logger, otelcol, ns, pod, attributes)
 }
 
 func (p *sidecarPodMutator) getCollectorInstance(ctx context.Context, ns corev1.Namespace, ann string) (v1beta1.OpenTelemetryCollector, error) {
@@ -122,6 +134,14 @@ func (p *sidecarPodMutator) getCollectorInstance(ctx context.Context, ns corev1.
 		return v1beta1.OpenTelemetryCollector{}, errInstanceNotSidecar
 	}
 
+	allowed, err := allowedByTemplate(otelcol, ns)
+	if err != nil {
+		return v1beta1.OpenTelemetryCollector{}, err
+	}
+	if !allowed {
+		return v1beta1.OpenTelemetryCollector{}, errNamespaceNotAllowed
+	}
+
 	return otelcol, nil
 }
 
@@ -142,6 +162,15 @@ func (p *sidecarPodMutator) selectCollectorInstance(ctx context.Context, ns core
 		}
 	}
 
+	if len(sidecars) == 0 {
+		// fall back to the sidecar templates of other namespaces selecting this one, and it opted in to
+		templates, err := p.selectSidecarTemplates(ctx, ns)
+		if err != nil {
+			return v1beta1.OpenTelemetryCollector{}, err
+		}
+		sidecars = templates
+	}
+
 	switch {
 	case len(sidecars) == 0:
 		return v1beta1.OpenTelemetryCollector{}, errNoInstancesAvailable
@@ -152,6 +181,32 @@ func (p *sidecarPodMutator) selectCollectorInstance(ctx context.Context, ns core
 	}
 }
 
+// selectSidecarTemplates returns the sidecar collectors of other namespaces whose sidecar template selects the given
+// namespace, among the ones the namespace opted in to.
+func (p *sidecarPodMutator) selectSidecarTemplates(ctx context.Context, ns corev1.Namespace) ([]v1beta1.OpenTelemetryCollector, error) {
+	otelcols := v1beta1.OpenTelemetryCollectorList{}
+	if err := p.client.List(ctx, &otelcols); err != nil {
+		return nil, err
+	}
+
+	var templates []v1beta1.OpenTelemetryCollector
+	for i := range otelcols.Items {
+		coll := otelcols.Items[i]
+		if coll.Spec.Mode != v1beta1.ModeSidecar || coll.Spec.SidecarTemplate == nil || coll.Namespace == ns.Name || !optedIn(coll, ns) {
+			continue
+		}
+		allowed, err := allowedByTemplate(coll, ns)
+		if err != nil {
+			p.logger.Error(err, "failed to evaluate the sidecar template", "otelcol-namespace", coll.Namespace, "otelcol-name", coll.Name)
+			continue
+		}
+		if allowed {
+			templates = append(templates, coll)
+		}
+	}
+	return templates, nil
+}
+
 func (p *sidecarPodMutator) podReferences(ctx context.Context, ownerReferences []metav1.OwnerReference, ns corev1.Namespace) podReferences {
 	references := &podReferences{}
 	replicaSet := p.getReplicaSetReference(ctx, ownerReferences, ns)
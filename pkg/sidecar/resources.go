@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidecar
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// OwningWorkload returns the kind and name of the workload owning the pod. The Deployment of a ReplicaSet is derived
+// from the name of the ReplicaSet, which is the name of the Deployment followed by the pod template hash.
+func OwningWorkload(pod corev1.Pod) (string, string) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return "Pod", pod.Name
+	}
+	if owner.Kind == "ReplicaSet" {
+		if hash, ok := pod.Labels["pod-template-hash"]; ok && strings.HasSuffix(owner.Name, "-"+hash) {
+			return "Deployment", strings.TrimSuffix(owner.Name, "-"+hash)
+		}
+	}
+	return owner.Kind, owner.Name
+}
+
+// applyRecommendedResources sets the resources recommended for the workload of the pod on the sidecar container, when
+// the collector applies its recommendations.
+func applyRecommendedResources(otelcol v1beta1.OpenTelemetryCollector, ns corev1.Namespace, pod corev1.Pod, container *corev1.Container) {
+	if !featuregate.EnableSidecarResourceRecommendations.IsEnabled() || otelcol.Spec.SidecarResources == nil ||
+		otelcol.Spec.SidecarResources.Mode != v1beta1.SidecarResourcesModeAuto {
+		return
+	}
+	kind, name := OwningWorkload(pod)
+	for _, recommendation := range otelcol.Status.SidecarResources {
+		if recommendation.Kind != kind || recommendation.Namespace != ns.Name || recommendation.Name != name {
+			continue
+		}
+		container.Resources.Requests = mergeResources(container.Resources.Requests, recommendation.Requests)
+		container.Resources.Limits = mergeResources(container.Resources.Limits, recommendation.Limits)
+		return
+	}
+}
+
+func mergeResources(resources, recommended corev1.ResourceList) corev1.ResourceList {
+	if len(recommended) == 0 {
+		return resources
+	}
+	merged := resources.DeepCopy()
+	if merged == nil {
+		merged = corev1.ResourceList{}
+	}
+	for name, quantity := range recommended {
+		merged[name] = quantity.DeepCopy()
+	}
+	return merged
+}
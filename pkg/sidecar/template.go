@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidecar
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// AnnotationAllowedSidecarTemplates opts a namespace in to the sidecar templates of other namespaces for the pods
+// annotated with "true". It's a comma separated list of <namespace>/<name> entries, <namespace>/* allowing all the
+// sidecar collectors of a namespace.
+const AnnotationAllowedSidecarTemplates = "sidecar.opentelemetry.io/allowed-templates"
+
+// headerExporterTypes are the exporters the sidecar template headers are added to.
+var headerExporterTypes = []string{"otlp", "otlphttp"}
+
+// allowedByTemplate checks whether the pods of the given namespace can use the given sidecar collector. Collectors
+// without a sidecar template can be referenced from any namespace, as they always could.
+func allowedByTemplate(otelcol v1beta1.OpenTelemetryCollector, ns corev1.Namespace) (bool, error) {
+	if otelcol.Namespace == ns.Name || otelcol.Spec.SidecarTemplate == nil {
+		return true, nil
+	}
+	return selectsNamespace(otelcol.Spec.SidecarTemplate.NamespaceSelector, ns)
+}
+
+// optedIn returns whether the namespace lists the given sidecar collector in its AnnotationAllowedSidecarTemplates
+// annotation. This keeps the sidecar templates of other tenants from being picked for the pods of the namespace.
+func optedIn(otelcol v1beta1.OpenTelemetryCollector, ns corev1.Namespace) bool {
+	for _, entry := range strings.Split(ns.Annotations[AnnotationAllowedSidecarTemplates], ",") {
+		namespace, name, ok := strings.Cut(strings.TrimSpace(entry), "/")
+		if ok && namespace == otelcol.Namespace && (name == "*" || name == otelcol.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// templateOverrides returns the overrides of the sidecar template of the collector selecting the given namespace.
+func templateOverrides(otelcol v1beta1.OpenTelemetryCollector, ns corev1.Namespace) ([]v1beta1.SidecarTemplateOverride, error) {
+	if otelcol.Spec.SidecarTemplate == nil {
+		return nil, nil
+	}
+	var overrides []v1beta1.SidecarTemplateOverride
+	for _, override := range otelcol.Spec.SidecarTemplate.Overrides {
+		selected, err := selectsNamespace(override.NamespaceSelector, ns)
+		if err != nil {
+			return nil, err
+		}
+		if selected {
+			overrides = append(overrides, override)
+		}
+	}
+	return overrides, nil
+}
+
+func selectsNamespace(selector metav1.LabelSelector, ns corev1.Namespace) (bool, error) {
+	s, err := metav1.LabelSelectorAsSelector(&selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector: %w", err)
+	}
+	return s.Matches(labels.Set(ns.Labels)), nil
+}
+
+// addExporterHeaders adds the given headers to the otlp and otlphttp exporters of the given configuration, and
+// reports whether the configuration changed.
+func addExporterHeaders(cfg *v1beta1.Config, headers map[string]string) bool {
+	changed := false
+	for name, exporter := range cfg.Exporters.Object {
+		exporterType, _, _ := strings.Cut(name, "/")
+		if !slices.Contains(headerExporterTypes, exporterType) {
+			continue
+		}
+		original, ok := exporter.(map[string]interface{})
+		if !ok && exporter != nil {
+			continue
+		}
+		// the nested maps are shared with the collector the config was copied from, don't modify them
+		exporterCfg := maps.Clone(original)
+		if exporterCfg == nil {
+			exporterCfg = map[string]interface{}{}
+		}
+		exporterHeaders := map[string]interface{}{}
+		if existing, ok := exporterCfg["headers"].(map[string]interface{}); ok {
+			maps.Copy(exporterHeaders, existing)
+		}
+		for k, v := range headers {
+			exporterHeaders[k] = v
+		}
+		exporterCfg["headers"] = exporterHeaders
+		cfg.Exporters.Object[name] = exporterCfg
+		changed = true
+	}
+	return changed
+}
+
+// addResourceAttributes appends the given attributes to the OTEL_RESOURCE_ATTRIBUTES of the given container.
+func addResourceAttributes(container *corev1.Container, attributes map[string]string) {
+	if len(attributes) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, attributes[k]))
+	}
+	value := strings.Join(parts, ",")
+
+	for i := range container.Env {
+		if container.Env[i].Name != resourceAttributesEnvName {
+			continue
+		}
+		if container.Env[i].Value != "" {
+			value = container.Env[i].Value + "," + value
+		}
+		container.Env[i].Value = value
+		return
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: resourceAttributesEnvName, Value: value})
+}
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidecar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func sharedSidecar(name, namespace string) v1beta1.OpenTelemetryCollector {
+	return v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeSidecar,
+			Config: v1beta1.Config{
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"otlp": map[string]interface{}{
+						"endpoint": "gateway:4317",
+						"headers":  map[string]interface{}{"x-scope": "platform"},
+					},
+					"otlphttp/backup": nil,
+					"debug":           map[string]interface{}{},
+				}},
+			},
+			SidecarTemplate: &v1beta1.SidecarTemplate{
+				NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"sidecar": "shared"}},
+				Overrides: []v1beta1.SidecarTemplateOverride{
+					{
+						NamespaceSelector:  metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+						ResourceAttributes: map[string]string{"team": "a"},
+						ExporterHeaders:    map[string]string{"x-tenant": "team-a"},
+					},
+					{
+						NamespaceSelector:  metav1.LabelSelector{},
+						ResourceAttributes: map[string]string{"platform": "shared"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestAllowedByTemplate(t *testing.T) {
+	otelcol := sharedSidecar("shared", "platform")
+	withoutTemplate := sharedSidecar("plain", "platform")
+	withoutTemplate.Spec.SidecarTemplate = nil
+
+	for _, tt := range []struct {
+		name     string
+		otelcol  v1beta1.OpenTelemetryCollector
+		ns       corev1.Namespace
+		expected bool
+	}{
+		{
+			name:     "selected namespace",
+			otelcol:  otelcol,
+			ns:       corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"sidecar": "shared"}}},
+			expected: true,
+		},
+		{
+			name:    "namespace not selected",
+			otelcol: otelcol,
+			ns:      corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		},
+		{
+			name:     "namespace of the collector",
+			otelcol:  otelcol,
+			ns:       corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform"}},
+			expected: true,
+		},
+		{
+			name:     "collector without template",
+			otelcol:  withoutTemplate,
+			ns:       corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+			expected: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := allowedByTemplate(tt.otelcol, tt.ns)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+}
+
+func TestAddSidecarFromTemplate(t *testing.T) {
+	otelcol := sharedSidecar("shared", "platform")
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "app",
+		Labels: map[string]string{"sidecar": "shared", "team": "a"},
+	}}
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "my-app"}}}}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	changed, err := add(cfg, logger, otelcol, ns, pod, []corev1.EnvVar{
+		{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "k8s.namespace.name=app"},
+	})
+	require.NoError(t, err)
+	require.Len(t, changed.Spec.Containers, 2)
+
+	env := map[string]string{}
+	for _, e := range changed.Spec.Containers[1].Env {
+		env[e.Name] = e.Value
+	}
+	assert.Equal(t, "k8s.namespace.name=app,team=a,platform=shared", env["OTEL_RESOURCE_ATTRIBUTES"])
+	assert.YAMLEq(t, `receivers: {}
+exporters:
+  debug: {}
+  otlp:
+    endpoint: gateway:4317
+    headers:
+      x-scope: platform
+      x-tenant: team-a
+  otlphttp/backup:
+    headers:
+      x-tenant: team-a
+service:
+  pipelines: {}
+`, env["OTEL_CONFIG"])
+	assert.Equal(t, "platform.shared", changed.Labels["sidecar.opentelemetry.io/injected"])
+
+	// the collector the sidecar was created from is left unchanged
+	assert.Equal(t, map[string]interface{}{"x-scope": "platform"},
+		otelcol.Spec.Config.Exporters.Object["otlp"].(map[string]interface{})["headers"])
+	assert.Nil(t, otelcol.Spec.Config.Exporters.Object["otlphttp/backup"])
+}
+
+func TestSelectSidecarTemplate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	shared := sharedSidecar("shared", "platform")
+	other := sharedSidecar("other", "platform")
+	other.Spec.SidecarTemplate.NamespaceSelector = metav1.LabelSelector{MatchLabels: map[string]string{"sidecar": "other"}}
+	local := sharedSidecar("local", "own-sidecar")
+	local.Spec.SidecarTemplate = nil
+	// a tenant's sidecar collector selecting every namespace
+	unrelated := sharedSidecar("unrelated", "tenant")
+	unrelated.Spec.SidecarTemplate.NamespaceSelector = metav1.LabelSelector{}
+	mutator := NewMutator(logger, config.New(), fake.NewClientBuilder().WithScheme(scheme).WithObjects(&shared, &other, &local, &unrelated).Build())
+	optedIn := map[string]string{AnnotationAllowedSidecarTemplates: "platform/*"}
+
+	for _, tt := range []struct {
+		name        string
+		ns          corev1.Namespace
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "template selecting the namespace",
+			ns:       corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"sidecar": "shared"}, Annotations: optedIn}},
+			expected: "shared",
+		},
+		{
+			name: "template allowed by name",
+			ns: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"sidecar": "other"}, Annotations: map[string]string{
+				AnnotationAllowedSidecarTemplates: "platform/shared, platform/other",
+			}}},
+			expected: "other",
+		},
+		{
+			name:        "template of a namespace not opted in to",
+			ns:          corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"sidecar": "shared"}}},
+			expectedErr: errNoInstancesAvailable,
+		},
+		{
+			name:        "several templates opted in to",
+			ns:          corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"sidecar": "shared"}, Annotations: map[string]string{AnnotationAllowedSidecarTemplates: "platform/*,tenant/*"}}},
+			expectedErr: errMultipleInstancesPossible,
+		},
+		{
+			name:     "sidecar of the namespace takes precedence",
+			ns:       corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "own-sidecar", Labels: map[string]string{"sidecar": "shared"}}},
+			expected: "local",
+		},
+		{
+			name:        "no template selecting the namespace",
+			ns:          corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Annotations: optedIn}},
+			expectedErr: errNoInstancesAvailable,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol, err := mutator.getCollectorInstance(context.Background(), tt.ns, "true")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, otelcol.Name)
+		})
+	}
+
+	t.Run("reference from a namespace not selected", func(t *testing.T) {
+		ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+		_, err := mutator.getCollectorInstance(context.Background(), ns, "platform/shared")
+		assert.ErrorIs(t, err, errNamespaceNotAllowed)
+	})
+}